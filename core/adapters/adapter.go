@@ -11,6 +11,8 @@ import (
 )
 
 var (
+	// TaskTypeAggregate is the identifier for the Aggregate adapter.
+	TaskTypeAggregate = models.MustNewTaskType("aggregate")
 	// TaskTypeCopy is the identifier for the Copy adapter.
 	TaskTypeCopy = models.MustNewTaskType("copy")
 	// TaskTypeEthBool is the identifier for the EthBool adapter.
@@ -25,6 +27,10 @@ var (
 	TaskTypeEthTx = models.MustNewTaskType("ethtx")
 	// TaskTypeEthTxABIEncode is the identifier for the EthTxABIEncode adapter.
 	TaskTypeEthTxABIEncode = models.MustNewTaskType("ethtxabiencode")
+	// TaskTypeExpression is the identifier for the Expression adapter.
+	TaskTypeExpression = models.MustNewTaskType("expression")
+	// TaskTypeGraphQL is the identifier for the GraphQL adapter.
+	TaskTypeGraphQL = models.MustNewTaskType("graphql")
 	// TaskTypeHTTPGetWithUnrestrictedNetworkAccess is the identifier for the HTTPGet adapter, with local/private IP access enabled.
 	TaskTypeHTTPGetWithUnrestrictedNetworkAccess = models.MustNewTaskType("httpgetwithunrestrictednetworkaccess")
 	// TaskTypeHTTPPostWithUnrestrictedNetworkAccess is the identifier for the HTTPPost adapter, with local/private IP access enabled.
@@ -51,6 +57,8 @@ var (
 	TaskTypeCompare = models.MustNewTaskType("compare")
 	// TaskTypeQuotient is the identifier for the Quotient adapter.
 	TaskTypeQuotient = models.MustNewTaskType("quotient")
+	// TaskTypeWebSocket is the identifier for the WebSocket adapter.
+	TaskTypeWebSocket = models.MustNewTaskType("websocket")
 )
 
 // BaseAdapter is the minimum interface required to create an adapter. Only core
@@ -113,6 +121,8 @@ func For(task models.TaskSpec, config orm.ConfigReader, orm *orm.ORM) (*Pipeline
 // FindNativeAdapterFor find the native adapter for a given task
 func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 	switch task.Type {
+	case TaskTypeAggregate:
+		return &Aggregate{}
 	case TaskTypeCopy:
 		return &Copy{}
 	case TaskTypeEthBool:
@@ -127,6 +137,10 @@ func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 		return &EthTx{}
 	case TaskTypeEthTxABIEncode:
 		return &EthTxABIEncode{}
+	case TaskTypeExpression:
+		return &Expression{}
+	case TaskTypeGraphQL:
+		return &GraphQL{}
 	case TaskTypeHTTPGetWithUnrestrictedNetworkAccess:
 		return &HTTPGet{AllowUnrestrictedNetworkAccess: true}
 	case TaskTypeHTTPPostWithUnrestrictedNetworkAccess:
@@ -153,6 +167,8 @@ func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 		return &Compare{}
 	case TaskTypeQuotient:
 		return &Quotient{}
+	case TaskTypeWebSocket:
+		return &WebSocket{}
 	default:
 		return nil
 	}
@@ -0,0 +1,209 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// Aggregation methods supported by the Aggregate adapter.
+const (
+	AggregateMedian = "median"
+	AggregateMean   = "mean"
+	AggregateMode   = "mode"
+)
+
+// Aggregate adapter reduces several upstream values to a single result.
+//
+// When the task depends on more than one other task, the values are the
+// results of those tasks. Otherwise the input's result is expected to be an
+// array of values.
+type Aggregate struct {
+	// Method is one of median, mean or mode, and defaults to median.
+	Method string `json:"method"`
+	// MinResponses is the number of values that must remain after outliers
+	// are discarded for the aggregate to be computed.
+	MinResponses int `json:"minResponses"`
+	// MaxDeviation, if set, discards values that differ from the median of
+	// all values by more than this fraction of the median, e.g. 0.1 for 10%.
+	MaxDeviation *decimal.Decimal `json:"maxDeviation,omitempty"`
+}
+
+// TaskType returns the type of Adapter.
+func (a *Aggregate) TaskType() models.TaskType {
+	return TaskTypeAggregate
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, rejecting
+// aggregation methods that aren't supported.
+func (a *Aggregate) UnmarshalJSON(input []byte) error {
+	type plain Aggregate
+	if err := json.Unmarshal(input, (*plain)(a)); err != nil {
+		return err
+	}
+
+	switch a.Method {
+	case "":
+		a.Method = AggregateMedian
+	case AggregateMedian, AggregateMean, AggregateMode:
+	default:
+		return fmt.Errorf("aggregate method %q is not one of %s, %s or %s", a.Method, AggregateMedian, AggregateMean, AggregateMode)
+	}
+	if a.MinResponses < 0 {
+		return errors.New("aggregate minResponses must not be negative")
+	}
+	if a.MaxDeviation != nil && a.MaxDeviation.IsNegative() {
+		return errors.New("aggregate maxDeviation must not be negative")
+	}
+	return nil
+}
+
+// Perform computes the median, mean or mode of the upstream values, after
+// discarding any values that are not numbers or that deviate too far from
+// the median.
+//
+// For example, with a method of "median" and a maxDeviation of 0.1, the
+// values ["100", "101", "150"] aggregate to "100.5", as "150" is discarded.
+func (a *Aggregate) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	values := aggregateInputValues(input)
+	if len(values) == 0 {
+		return models.NewRunOutputError(errors.New("aggregate requires at least one numeric value"))
+	}
+
+	values = a.discardOutliers(values)
+
+	minResponses := a.MinResponses
+	if minResponses == 0 {
+		minResponses = 1
+	}
+	if len(values) < minResponses {
+		return models.NewRunOutputError(fmt.Errorf(
+			"aggregate quorum not met: %d usable values, %d required", len(values), minResponses))
+	}
+
+	var (
+		result decimal.Decimal
+		err    error
+	)
+	switch a.Method {
+	case AggregateMean:
+		result = mean(values)
+	case AggregateMode:
+		result, err = mode(values)
+	default:
+		result = median(values)
+	}
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(result.String())
+}
+
+func (a *Aggregate) discardOutliers(values []decimal.Decimal) []decimal.Decimal {
+	if a.MaxDeviation == nil {
+		return values
+	}
+
+	mid := median(values)
+	kept := []decimal.Decimal{}
+	for _, v := range values {
+		diff := v.Sub(mid).Abs()
+		if mid.IsZero() {
+			if diff.LessThanOrEqual(*a.MaxDeviation) {
+				kept = append(kept, v)
+			}
+		} else if diff.Div(mid.Abs()).LessThanOrEqual(*a.MaxDeviation) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// aggregateInputValues returns the numeric values found in the results of
+// the parents of the task, or else in the input's "result" if it is an array
+// or a single value.
+func aggregateInputValues(input models.RunInput) []decimal.Decimal {
+	elements := input.ParentResults()
+	if len(elements) == 0 {
+		raw := input.Result()
+		if raw.IsArray() {
+			elements = raw.Array()
+		} else if raw.Exists() {
+			elements = []gjson.Result{raw}
+		}
+	}
+
+	values := []decimal.Decimal{}
+	for _, e := range elements {
+		if e.Type != gjson.Number && e.Type != gjson.String {
+			continue
+		}
+		v, err := decimal.NewFromString(e.String())
+		if err != nil {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func sortedDecimals(values []decimal.Decimal) []decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	return sorted
+}
+
+// median returns the middle value, or the average of the two middle values
+// if there are an even number of them.
+func median(values []decimal.Decimal) decimal.Decimal {
+	sorted := sortedDecimals(values)
+	k := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[k]
+	}
+	return sorted[k].Add(sorted[k-1]).Div(decimal.NewFromInt(2))
+}
+
+func mean(values []decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	return sum.Div(decimal.NewFromInt(int64(len(values))))
+}
+
+// mode returns the most common value, and errors if several values are
+// equally common.
+func mode(values []decimal.Decimal) (decimal.Decimal, error) {
+	sorted := sortedDecimals(values)
+
+	var best decimal.Decimal
+	bestCount, count, tied := 0, 0, false
+	for i, v := range sorted {
+		if i > 0 && v.Equal(sorted[i-1]) {
+			count++
+		} else {
+			count = 1
+		}
+
+		if count > bestCount {
+			best, bestCount, tied = v, count, false
+		} else if count == bestCount {
+			tied = true
+		}
+	}
+	if tied {
+		return decimal.Decimal{}, errors.New("aggregate mode is ambiguous: several values are equally common")
+	}
+	return best, nil
+}
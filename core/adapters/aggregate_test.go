@@ -0,0 +1,92 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestAggregate_Perform_Success(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		json    string
+		parents string
+		want    string
+	}{
+		{"median odd", `{"method":"median"}`, `{}`, `["3","1","2"]`, "2"},
+		{"median even", `{}`, `{}`, `[1,2,3,4]`, "2.5"},
+		{"mean", `{"method":"mean"}`, `{}`, `["1.5",2.5,"5"]`, "3"},
+		{"mode", `{"method":"mode"}`, `{}`, `["7","8","7"]`, "7"},
+		{"array result", `{"method":"median"}`, `{"result":[10,20,30]}`, ``, "20"},
+		{"single result", `{"method":"mean"}`, `{"result":"42"}`, ``, "42"},
+		{"results in the input data", `{"method":"mean"}`, `{"result":"42","results":[1,2]}`, ``, "42"},
+		{"ignores non numeric values", `{"method":"median"}`, `{}`, `["1",null,"abc",{"a":1},"3"]`, "2"},
+		{"discards outliers", `{"method":"median","maxDeviation":0.1}`, `{}`, `["100","101","150"]`, "100.5"},
+		{"quorum met", `{"method":"mean","minResponses":2}`, `{}`, `["1","3"]`, "2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json).
+				WithParentResults(gjson.Parse(test.parents).Array())
+			adapter := adapters.Aggregate{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestAggregate_Perform_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		json    string
+		parents string
+	}{
+		{"no values", `{}`, `{}`, `[]`},
+		{"no numeric values", `{}`, `{}`, `["a","b"]`},
+		{"quorum not met", `{"minResponses":3}`, `{}`, `["1",null,"2"]`},
+		{"quorum not met after outliers", `{"minResponses":3,"maxDeviation":"0.05"}`, `{}`, `["100","101","200"]`},
+		{"ambiguous mode", `{"method":"mode"}`, `{}`, `["1","2"]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json).
+				WithParentResults(gjson.Parse(test.parents).Array())
+			adapter := adapters.Aggregate{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			assert.Error(t, result.Error())
+		})
+	}
+}
+
+func TestAggregate_Unmarshal_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"unknown method", `{"method":"max"}`},
+		{"negative minResponses", `{"minResponses":-1}`},
+		{"negative maxDeviation", `{"maxDeviation":-0.1}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Aggregate{}
+			assert.Error(t, json.Unmarshal([]byte(test.params), &adapter))
+		})
+	}
+}
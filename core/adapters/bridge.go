@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
@@ -69,6 +71,11 @@ func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, store *
 		return models.NewRunOutputError(baRunResultError("handling data param", err))
 	}
 
+	ttl, err := ba.cacheTTL()
+	if err != nil {
+		return models.NewRunOutputError(baRunResultError("handling cacheTTL param", err))
+	}
+
 	responseURL := store.Config.BridgeResponseURL()
 	if *responseURL != *zeroURL {
 		responseURL.Path += fmt.Sprintf("/v2/runs/%s", input.JobRunID().String())
@@ -76,15 +83,108 @@ func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, store *
 
 	httpConfig := defaultHTTPConfig(store)
 
-	body, err := ba.postToExternalAdapter(input, meta, responseURL, httpConfig)
+	body, err := store.Responses.Fetch(ba.Name.String(), ba.cacheKey(data), ttl, func() ([]byte, bool, error) {
+		body, err := ba.post(store, input, meta, responseURL, httpConfig)
+		return body, cacheableBridgeResponse(body), err
+	})
+	if err == errBridgesUnhealthy {
+		err = fmt.Errorf("bridge %s and its fallbacks are unhealthy", ba.Name)
+		if ba.PendWhenUnhealthy {
+			return models.NewRunOutputPendingRetry(err, store.Clock.Now().Add(store.Config.BridgeCircuitBreakerCooldown().Duration()))
+		}
+	}
 	if err != nil {
-		return models.NewRunOutputError(baRunResultError("post to external adapter", err))
+		return requestErrorOutput(store.Clock, baRunResultError("post to external adapter", err))
 	}
 
 	input = input.CloneWithData(data)
 	return ba.responseToRunResult(body, input)
 }
 
+var errBridgesUnhealthy = errors.New("bridges unhealthy")
+
+// post sends the request to the bridge, or to its first fallback which is
+// up if it is down or over the limits of its host, and returns
+// errBridgesUnhealthy if the circuits of the bridge and its fallbacks are
+// all open.
+func (ba *Bridge) post(
+	store *store.Store,
+	input models.RunInput,
+	meta *models.JSON,
+	responseURL *url.URL,
+	httpConfig HTTPRequestConfig,
+) ([]byte, error) {
+	err := errBridgesUnhealthy
+	for _, bt := range ba.candidates(store) {
+		if !store.BridgeHealth.Allow(bt.Name) {
+			continue
+		}
+
+		var body []byte
+		body, err = ba.postToExternalAdapter(bt, input, meta, responseURL, httpConfig)
+		if hostLimited(err) {
+			store.BridgeHealth.Skip(bt.Name)
+			continue
+		} else if err != nil && bridgeDown(err) {
+			store.BridgeHealth.RecordFailure(bt.Name, err)
+			continue
+		}
+		store.BridgeHealth.RecordSuccess(bt.Name)
+		return body, err
+	}
+	return nil, err
+}
+
+// cacheTTL returns how long the bridge's responses are cached for, as set
+// by the cacheTTL param of the task.
+func (ba *Bridge) cacheTTL() (time.Duration, error) {
+	ttl := ba.Params.Get("cacheTTL")
+	if !ttl.Exists() {
+		return 0, nil
+	}
+	return time.ParseDuration(ttl.String())
+}
+
+// cacheKey returns the key of the bridge's responses to the data in the
+// response cache, which leaves out the job run ID and meta sent with it.
+func (ba *Bridge) cacheKey(data models.JSON) string {
+	return store.ResponseCacheKey("POST", ba.URL.String(), nil, []byte(data.String()))
+}
+
+// cacheableBridgeResponse returns true if the bridge answered with a
+// result, rather than with an error or by going pending.
+func cacheableBridgeResponse(body []byte) bool {
+	var brr models.BridgeRunResult
+	if err := json.Unmarshal(body, &brr); err != nil {
+		return false
+	}
+	return !brr.HasError() && !brr.ExternalPending
+}
+
+// bridgeDown returns true if err shows that a bridge failed to answer, or
+// answered with a server error, rather than rejecting the request.
+func bridgeDown(err error) bool {
+	if e, ok := errors.Cause(err).(models.StatusCodeError); ok {
+		return e.StatusCode() >= 500
+	}
+	return true
+}
+
+// candidates returns the bridge followed by its fallbacks, in the order
+// they are tried.
+func (ba *Bridge) candidates(store *store.Store) []models.BridgeType {
+	candidates := []models.BridgeType{ba.BridgeType}
+	for _, name := range ba.Fallbacks {
+		fallback, err := store.FindBridge(name)
+		if err != nil {
+			logger.Warnw("Unable to find fallback bridge", "bridge", ba.Name, "fallback", name, "error", err)
+			continue
+		}
+		candidates = append(candidates, fallback)
+	}
+	return candidates
+}
+
 func (ba *Bridge) responseToRunResult(body []byte, input models.RunInput) models.RunOutput {
 	var brr models.BridgeRunResult
 	err := json.Unmarshal(body, &brr)
@@ -113,6 +213,7 @@ func (ba *Bridge) responseToRunResult(body []byte, input models.RunInput) models
 }
 
 func (ba *Bridge) postToExternalAdapter(
+	bt models.BridgeType,
 	input models.RunInput,
 	meta *models.JSON,
 	bridgeResponseURL *url.URL,
@@ -132,12 +233,13 @@ func (ba *Bridge) postToExternalAdapter(
 		return nil, fmt.Errorf("marshaling request body: %v", err)
 	}
 
-	request, err := http.NewRequest("POST", ba.URL.String(), bytes.NewBuffer(in))
+	request, err := http.NewRequest("POST", bt.URL.String(), bytes.NewBuffer(in))
 	if err != nil {
 		return nil, fmt.Errorf("building outgoing bridge http post: %v", err)
 	}
-	request.Header.Set("Authorization", "Bearer "+ba.BridgeType.OutgoingToken)
+	request.Header.Set("Authorization", "Bearer "+bt.OutgoingToken)
 	request.Header.Set("Content-Type", "application/json")
+	bt.SignRequest(request, in, time.Now())
 
 	client := http.Client{}
 
@@ -148,15 +250,15 @@ func (ba *Bridge) postToExternalAdapter(
 	}
 
 	if statusCode >= 400 {
-		err = fmt.Errorf("%v %v", statusCode, string(bytes))
-		return nil, fmt.Errorf("POST request: %v", err)
+		err = &HTTPResponseError{statusCode, fmt.Sprintf("%v %v", statusCode, string(bytes))}
+		return nil, errors.Wrap(err, "POST request")
 	}
 
 	return bytes, nil
 }
 
 func baRunResultError(str string, err error) error {
-	return fmt.Errorf("ExternalBridge %v: %v", str, err)
+	return errors.Wrapf(err, "ExternalBridge %v", str)
 }
 
 type bridgeOutgoing struct {
@@ -4,12 +4,17 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -253,3 +258,160 @@ func TestBridgeResponse_TooLarge(t *testing.T) {
 	assert.Contains(t, result.Error().Error(), "HTTP response too large")
 	assert.Equal(t, "", result.Result().String())
 }
+
+func newBridgeHealthStore(t *testing.T) (*store.Store, func()) {
+	config, cfgCleanup := cltest.NewConfig(t)
+	config.Set("BRIDGE_RESPONSE_URL", "")
+	config.Set("DEFAULT_MAX_HTTP_ATTEMPTS", "1")
+	config.Set("BRIDGE_CIRCUIT_BREAKER_THRESHOLD", "1")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	return store, func() {
+		cleanup()
+		cfgCleanup()
+	}
+}
+
+func TestBridge_Perform_Fallbacks(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newBridgeHealthStore(t)
+	defer cleanup()
+
+	down, _ := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "")
+	down.Close()
+	up, ensureCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", `{"data":{"result":"from fallback"}}`)
+	defer ensureCalled()
+
+	_, fallback := cltest.NewBridgeType(t, "fallback", up.URL)
+	require.NoError(t, store.CreateBridgeType(fallback))
+	_, bt := cltest.NewBridgeType(t, "primary", down.URL)
+	bt.Fallbacks = models.TaskTypes{fallback.Name}
+	ba := &adapters.Bridge{BridgeType: *bt}
+
+	result := ba.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "from fallback", result.Result().String())
+	assert.False(t, store.BridgeHealth.Health(bt.Name).Healthy())
+
+	result = ba.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "from fallback", result.Result().String())
+}
+
+func TestBridge_Perform_Unhealthy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		pendWhenUnhealthy bool
+		wantStatus        models.RunStatus
+	}{
+		{"fails fast", false, models.RunStatusErrored},
+		{"pends", true, models.RunStatusPendingSleep},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := newBridgeHealthStore(t)
+			defer cleanup()
+
+			down, _ := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "")
+			down.Close()
+			_, bt := cltest.NewBridgeType(t, "unhealthy", down.URL)
+			bt.PendWhenUnhealthy = test.pendWhenUnhealthy
+			ba := &adapters.Bridge{BridgeType: *bt}
+
+			result := ba.Perform(cltest.NewRunInputWithResult("100"), store)
+			require.Error(t, result.Error())
+			assert.Equal(t, models.RunStatusErrored, result.Status(), "a failed request should error")
+
+			result = ba.Perform(cltest.NewRunInputWithResult("100"), store)
+			require.Error(t, result.Error())
+			assert.Contains(t, result.Error().Error(), "unhealthy")
+			assert.Equal(t, test.wantStatus, result.Status())
+			retryAt, pending := result.RetryAt()
+			assert.Equal(t, test.pendWhenUnhealthy, pending)
+			if pending {
+				assert.True(t, retryAt.After(store.Clock.Now()))
+			}
+		})
+	}
+}
+
+func TestBridge_Perform_HostLimitedProbe(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := newBridgeHealthStore(t)
+	defer cleanup()
+	s.BridgeHealth = store.NewBridgeHealthMonitor(nil, 1, 0, 0, utils.Clock{})
+
+	down, _ := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "")
+	down.Close()
+	_, bt := cltest.NewBridgeType(t, "limited", down.URL)
+	ba := &adapters.Bridge{BridgeType: *bt}
+
+	result := ba.Perform(cltest.NewRunInputWithResult("100"), s)
+	require.Error(t, result.Error())
+	require.Equal(t, store.CircuitOpen, s.BridgeHealth.Health(bt.Name).Circuit)
+
+	// A probe over the limits of its host is not sent, which leaves the
+	// circuit open for the next request to probe
+	s.HostLimiter = store.NewHostLimiter(orm.HTTPHostLimit{RequestsPerSecond: 0.1}, nil)
+	u := url.URL(bt.URL)
+	_, err := s.HostLimiter.Acquire(&u)
+	require.NoError(t, err)
+
+	result = ba.Perform(cltest.NewRunInputWithResult("100"), s)
+	require.Error(t, result.Error())
+	assert.Equal(t, store.CircuitOpen, s.BridgeHealth.Health(bt.Name).Circuit)
+	assert.True(t, s.BridgeHealth.Allow(bt.Name))
+}
+
+func TestBridge_Perform_Cached(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newBridgeHealthStore(t)
+	defer cleanup()
+
+	var requests, pending int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&pending) == 1 {
+			fmt.Fprint(w, `{"pending":true}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"result":"%d"}}`, n)
+	}))
+	defer mock.Close()
+
+	_, bt := cltest.NewBridgeType(t, "cached", mock.URL)
+	cached := &adapters.Bridge{BridgeType: *bt, Params: cltest.JSONFromString(t, `{"cacheTTL":"1m"}`)}
+	uncached := &adapters.Bridge{BridgeType: *bt}
+
+	result := cached.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "1", result.Result().String())
+
+	result = cached.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "1", result.Result().String(), "should answer identical requests from the cache")
+
+	result = cached.Perform(cltest.NewRunInputWithResult("200"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "2", result.Result().String(), "should send requests with other data")
+
+	result = uncached.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "3", result.Result().String(), "should not cache without a cacheTTL")
+
+	atomic.StoreInt32(&pending, 1)
+	cached.Params = cltest.JSONFromString(t, `{"cacheTTL":"1m","other":true}`)
+	result = cached.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.True(t, result.Status().PendingBridge())
+	result = cached.Perform(cltest.NewRunInputWithResult("100"), store)
+	require.NoError(t, result.Error())
+	assert.True(t, result.Status().PendingBridge())
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests), "should not cache pending responses")
+}
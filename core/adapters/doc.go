@@ -1,5 +1,14 @@
 // Package adapters contain the core adapters used by the Chainlink node.
 //
+// Aggregate
+//
+// The Aggregate adapter reduces several values to their median, mean or mode.
+// When the task depends on several other tasks the values are their results,
+// otherwise the input's result is expected to be an array. Values deviating
+// from the median by more than maxDeviation are discarded, and the task errors
+// if fewer than minResponses values remain.
+//  { "type": "Aggregate", "params": {"method": "median", "minResponses": 2, "maxDeviation": 0.1 }}
+//
 // Bridge
 //
 // The Bridge adapter is used to send and receive data to and from external adapters.
@@ -174,6 +174,12 @@ func ensureTxRunResult(input models.RunInput, str *strpkg.Store) models.RunOutpu
 			return models.NewRunOutputError(err)
 		}
 
+		// A cancelled transaction confirms a transfer to ourselves, rather
+		// than the transaction the job asked for
+		if tx, _, err := str.FindTxByAttempt(hash); err == nil && tx.Cancelled {
+			return models.NewRunOutputError(fmt.Errorf("transaction %s was cancelled", hash.Hex()))
+		}
+
 		return addReceiptToResult(*receipt, input, output)
 	}
 
@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// EthTxRecorder stands in for the EthTx and EthTxABIEncode adapters when a
+// job is simulated. Instead of sending a transaction, it returns the
+// transaction that would have been sent.
+type EthTxRecorder struct {
+	Adapter BaseAdapter
+}
+
+// RecordEthTx returns an EthTxRecorder in place of the adapter if it sends
+// Ethereum transactions, and the adapter itself otherwise.
+func RecordEthTx(adapter BaseAdapter) BaseAdapter {
+	switch adapter.(type) {
+	case *EthTx, *EthTxABIEncode:
+		return &EthTxRecorder{Adapter: adapter}
+	default:
+		return adapter
+	}
+}
+
+// TaskType returns the type of the recorded adapter.
+func (r *EthTxRecorder) TaskType() models.TaskType {
+	return r.Adapter.TaskType()
+}
+
+// Perform encodes the transaction the recorded adapter would send for the
+// input, and completes with its data as the result, e.g.
+//
+//  {"result": "0x...", "transaction": {"to": "0x...", "data": "0x...", "gasLimit": 0}}
+func (r *EthTxRecorder) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	var (
+		to       common.Address
+		data     []byte
+		gasPrice *utils.Big
+		gasLimit uint64
+	)
+	switch adapter := r.Adapter.(type) {
+	case *EthTx:
+		value, err := getTxData(adapter, input)
+		if err != nil {
+			return models.NewRunOutputError(errors.Wrap(err, "while constructing EthTx data"))
+		}
+		to, gasPrice, gasLimit = adapter.Address, adapter.GasPrice, adapter.GasLimit
+		data = utils.ConcatBytes(adapter.FunctionSelector.Bytes(), adapter.DataPrefix, value)
+	case *EthTxABIEncode:
+		value, err := adapter.abiEncode(&input)
+		if err != nil {
+			return models.NewRunOutputError(errors.Wrap(err, "while constructing EthTxABIEncode data"))
+		}
+		to, gasPrice, gasLimit, data = adapter.Address, adapter.GasPrice, adapter.GasLimit, value
+	default:
+		return models.NewRunOutputError(errors.Errorf("cannot record transactions of %s tasks", r.Adapter.TaskType()))
+	}
+
+	tx := map[string]interface{}{
+		"to":       to.Hex(),
+		"data":     hexutil.Encode(data),
+		"gasLimit": gasLimit,
+	}
+	if gasPrice != nil {
+		tx["gasPrice"] = gasPrice.String()
+	}
+	output, err := models.JSON{}.MultiAdd(models.KV{
+		"result":      hexutil.Encode(data),
+		"transaction": tx,
+	})
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputComplete(output)
+}
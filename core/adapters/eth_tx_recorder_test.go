@@ -0,0 +1,53 @@
+package adapters_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEthTx(t *testing.T) {
+	t.Parallel()
+
+	ethTx := &adapters.EthTx{}
+	assert.IsType(t, &adapters.EthTxRecorder{}, adapters.RecordEthTx(ethTx))
+	assert.IsType(t, &adapters.EthTxRecorder{}, adapters.RecordEthTx(&adapters.EthTxABIEncode{}))
+
+	noop := &adapters.NoOp{}
+	assert.Equal(t, noop, adapters.RecordEthTx(noop))
+}
+
+func TestEthTxRecorder_Perform(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	address := cltest.NewAddress()
+	recorder := adapters.RecordEthTx(&adapters.EthTx{
+		Address:          address,
+		FunctionSelector: eth.HexToFunctionSelector("0x609ff1bd"),
+		GasPrice:         utils.NewBig(big.NewInt(187)),
+		GasLimit:         911,
+	})
+
+	input := cltest.NewRunInputWithResult("0x0000000000000000000000000000000000000000000000000000000000000096")
+	result := recorder.Perform(input, store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, models.RunStatusCompleted, result.Status())
+
+	data := "0x609ff1bd0000000000000000000000000000000000000000000000000000000000000096"
+	assert.Equal(t, data, result.Result().String())
+	assert.Equal(t, address.Hex(), result.Data().Get("transaction.to").String())
+	assert.Equal(t, data, result.Data().Get("transaction.data").String())
+	assert.Equal(t, uint64(911), result.Data().Get("transaction.gasLimit").Uint())
+	assert.Equal(t, "187", result.Data().Get("transaction.gasPrice").String())
+}
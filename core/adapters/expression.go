@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// expressionTimeout is the time an expression can run for.
+const expressionTimeout = time.Second
+
+// Expression evaluates an expression over the data of its input, to
+// transform results without an external adapter.
+//
+// Expressions support decimal arithmetic (+, -, *, /, %), comparisons,
+// logical operators (&&, ||, !), conditionals (cond ? a : b), and the
+// functions abs, ceil, floor, round, min, max, pow, number, string, concat,
+// len, lower, upper, trim, contains, substr and replace. The fields of the
+// input data are variables, so that the result of the previous task is
+// `result`, and those of several parent tasks `results[0]`, `results[1]`,
+// and so on, in place of any `results` field of the data. Strings holding numbers, as results often are, can be used as
+// numbers.
+//
+// Numbers are returned as strings, to keep their precision.
+type Expression struct {
+	Expression string `json:"expression"`
+	parsed     exprNode
+}
+
+// TaskType returns the type of Adapter.
+func (e *Expression) TaskType() models.TaskType {
+	return TaskTypeExpression
+}
+
+// UnmarshalJSON parses the expression, so that invalid expressions are
+// rejected when the job is created.
+func (e *Expression) UnmarshalJSON(input []byte) error {
+	type plain Expression
+	if err := json.Unmarshal(input, (*plain)(e)); err != nil {
+		return err
+	}
+	parsed, err := parseExpression(e.Expression)
+	if err != nil {
+		return err
+	}
+	e.parsed = parsed
+	return nil
+}
+
+// decodeJSONNumbers decodes JSON, keeping numbers as json.Number to preserve
+// their precision.
+func decodeJSONNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// Perform returns the value of the expression.
+//
+// For example, if the input data is {"result": "2.5", "bid": 2.4}, the
+// expression "round((result + bid) / 2, 1)" returns "2.5".
+func (e *Expression) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	parsed := e.parsed
+	if parsed == nil {
+		var err error
+		if parsed, err = parseExpression(e.Expression); err != nil {
+			return models.NewRunOutputError(err)
+		}
+	}
+
+	data, err := input.Data().MarshalJSON()
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	variables := map[string]interface{}{}
+	if err := decodeJSONNumbers(data, &variables); err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if parents := input.ParentResults(); len(parents) > 0 {
+		results := make([]interface{}, len(parents))
+		for i, parent := range parents {
+			if !parent.Exists() {
+				continue
+			}
+			if err := decodeJSONNumbers([]byte(parent.Raw), &results[i]); err != nil {
+				return models.NewRunOutputError(err)
+			}
+		}
+		variables["results"] = results
+	}
+
+	env := &exprEnv{
+		variables: variables,
+		deadline:  time.Now().Add(expressionTimeout),
+	}
+	result, err := evalNode(env, parsed)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(exprResult(result))
+}
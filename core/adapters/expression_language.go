@@ -0,0 +1,956 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// maxExpressionLength is the length of the longest expression accepted.
+	maxExpressionLength = 4096
+	// maxExpressionSteps is the number of operations an expression can
+	// perform before being stopped.
+	maxExpressionSteps = 10000
+	// maxExpressionStringLength is the length of the longest string an
+	// expression can build.
+	maxExpressionStringLength = 1 << 16
+	// maxExpressionNumberBits is the size of the largest number an
+	// expression can compute.
+	maxExpressionNumberBits = 1024
+	// maxExpressionNumberExponent is the largest power of ten, positive or
+	// negative, of a number an expression can operate on. Arithmetic aligns
+	// the exponents of its operands, so unbounded exponents would let a
+	// short input build a number of any size.
+	maxExpressionNumberExponent = 1000
+	// expressionDivisionPrecision is the number of decimal places kept by
+	// divisions.
+	expressionDivisionPrecision = 18
+)
+
+// exprNode is a node of a parsed expression.
+type exprNode interface {
+	eval(env *exprEnv) (interface{}, error)
+}
+
+// exprEnv holds the variables of an expression, and the limits its
+// evaluation must stay within.
+type exprEnv struct {
+	variables map[string]interface{}
+	steps     int
+	deadline  time.Time
+}
+
+func (env *exprEnv) step() error {
+	env.steps++
+	if env.steps > maxExpressionSteps {
+		return fmt.Errorf("expression exceeded %d steps", maxExpressionSteps)
+	}
+	if time.Now().After(env.deadline) {
+		return errors.New("expression timed out")
+	}
+	return nil
+}
+
+// evalNode evaluates a node after counting it against the limits.
+func evalNode(env *exprEnv, node exprNode) (interface{}, error) {
+	if err := env.step(); err != nil {
+		return nil, err
+	}
+	return node.eval(env)
+}
+
+// exprValue converts decoded JSON to an expression value, with numbers as
+// decimals.
+func exprValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		d, err := decimal.NewFromString(v.String())
+		if err != nil {
+			return nil, err
+		}
+		return checkNumber(d)
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	default:
+		return v, nil
+	}
+}
+
+// exprResult converts an expression value to a run result, with decimals
+// as strings so that they keep their precision.
+func exprResult(value interface{}) interface{} {
+	if d, ok := value.(decimal.Decimal); ok {
+		return d.String()
+	}
+	return value
+}
+
+func exprTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case decimal.Decimal:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func checkNumber(d decimal.Decimal) (interface{}, error) {
+	if err := checkNumberRange(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func checkNumberRange(d decimal.Decimal) error {
+	if d.Coefficient().BitLen() > maxExpressionNumberBits {
+		return fmt.Errorf("number exceeds %d bits", maxExpressionNumberBits)
+	}
+	if e := d.Exponent(); e > maxExpressionNumberExponent || e < -maxExpressionNumberExponent {
+		return fmt.Errorf("number exponent exceeds %d", maxExpressionNumberExponent)
+	}
+	return nil
+}
+
+func checkString(s string) (interface{}, error) {
+	if len(s) > maxExpressionStringLength {
+		return nil, fmt.Errorf("string exceeds %d bytes", maxExpressionStringLength)
+	}
+	return s, nil
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(*exprEnv) (interface{}, error) {
+	return n.value, nil
+}
+
+type variableNode struct{ name string }
+
+func (n variableNode) eval(env *exprEnv) (interface{}, error) {
+	value, ok := env.variables[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %s", n.name)
+	}
+	return exprValue(value)
+}
+
+// memberNode gets a field of an object, or an element of an array. Missing
+// fields and elements are null.
+type memberNode struct{ object, key exprNode }
+
+func (n memberNode) eval(env *exprEnv) (interface{}, error) {
+	object, err := evalNode(env, n.object)
+	if err != nil {
+		return nil, err
+	}
+	key, err := evalNode(env, n.key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := object.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot index object with %s", exprTypeName(key))
+		}
+		return exprValue(o[k])
+	case []interface{}:
+		k, ok := key.(decimal.Decimal)
+		if !ok || !k.Equal(k.Truncate(0)) {
+			return nil, fmt.Errorf("cannot index array with %v", exprResult(key))
+		}
+		i := int(k.IntPart())
+		if i < 0 {
+			i += len(o)
+		}
+		if i < 0 || i >= len(o) {
+			return nil, nil
+		}
+		return exprValue(o[i])
+	default:
+		return nil, fmt.Errorf("cannot get %v of %s", exprResult(key), exprTypeName(object))
+	}
+}
+
+type unaryNode struct {
+	operator string
+	operand  exprNode
+}
+
+func (n unaryNode) eval(env *exprEnv) (interface{}, error) {
+	operand, err := evalNode(env, n.operand)
+	if err != nil {
+		return nil, err
+	}
+	switch n.operator {
+	case "-":
+		if d, ok := operand.(decimal.Decimal); ok {
+			return d.Neg(), nil
+		}
+	case "!":
+		if b, ok := operand.(bool); ok {
+			return !b, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid operand for %s: %s", n.operator, exprTypeName(operand))
+}
+
+// logicalNode evaluates && and ||, only evaluating its right operand if
+// needed.
+type logicalNode struct {
+	operator    string
+	left, right exprNode
+}
+
+func (n logicalNode) eval(env *exprEnv) (interface{}, error) {
+	left, err := evalBool(env, n.left, n.operator)
+	if err != nil {
+		return nil, err
+	}
+	if (n.operator == "&&" && !left) || (n.operator == "||" && left) {
+		return left, nil
+	}
+	return evalBool(env, n.right, n.operator)
+}
+
+func evalBool(env *exprEnv, node exprNode, operator string) (bool, error) {
+	value, err := evalNode(env, node)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid operand for %s: %s", operator, exprTypeName(value))
+	}
+	return b, nil
+}
+
+type conditionalNode struct{ condition, then, otherwise exprNode }
+
+func (n conditionalNode) eval(env *exprEnv) (interface{}, error) {
+	condition, err := evalBool(env, n.condition, "?")
+	if err != nil {
+		return nil, err
+	}
+	if condition {
+		return evalNode(env, n.then)
+	}
+	return evalNode(env, n.otherwise)
+}
+
+type binaryNode struct {
+	operator    string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(env *exprEnv) (interface{}, error) {
+	left, err := evalNode(env, n.left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := evalNode(env, n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.operator {
+	case "==":
+		return exprEqual(left, right)
+	case "!=":
+		equal, err := exprEqual(left, right)
+		if err != nil {
+			return nil, err
+		}
+		return !equal, nil
+	}
+
+	l, lok, err := toNumber(left)
+	if err != nil {
+		return nil, err
+	}
+	r, rok, err := toNumber(right)
+	if err != nil {
+		return nil, err
+	}
+	if lok && rok {
+		return arithmetic(n.operator, l, r)
+	}
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok {
+			if cmp, ok := compare(n.operator, strings.Compare(ls, rs)); ok {
+				return cmp, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid operands for %s: %s and %s", n.operator, exprTypeName(left), exprTypeName(right))
+}
+
+// toNumber returns the value as a number, if it is a number or a string
+// holding one, as results often are. Numbers outside of the range an
+// expression can operate on are rejected before any arithmetic is done.
+func toNumber(value interface{}) (decimal.Decimal, bool, error) {
+	var d decimal.Decimal
+	switch v := value.(type) {
+	case decimal.Decimal:
+		d = v
+	case string:
+		var err error
+		if d, err = decimal.NewFromString(strings.TrimSpace(v)); err != nil {
+			return decimal.Decimal{}, false, nil
+		}
+	default:
+		return decimal.Decimal{}, false, nil
+	}
+	if err := checkNumberRange(d); err != nil {
+		return decimal.Decimal{}, false, err
+	}
+	return d, true, nil
+}
+
+func arithmetic(operator string, l, r decimal.Decimal) (interface{}, error) {
+	if cmp, ok := compare(operator, l.Cmp(r)); ok {
+		return cmp, nil
+	}
+	switch operator {
+	case "+":
+		return checkNumber(l.Add(r))
+	case "-":
+		return checkNumber(l.Sub(r))
+	case "*":
+		return checkNumber(l.Mul(r))
+	case "/":
+		if r.IsZero() {
+			return nil, errors.New("division by zero")
+		}
+		return checkNumber(l.DivRound(r, expressionDivisionPrecision))
+	case "%":
+		if r.IsZero() {
+			return nil, errors.New("division by zero")
+		}
+		return checkNumber(l.Mod(r))
+	}
+	return nil, fmt.Errorf("unknown operator %s", operator)
+}
+
+func compare(operator string, cmp int) (bool, bool) {
+	switch operator {
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case ">":
+		return cmp > 0, true
+	case ">=":
+		return cmp >= 0, true
+	}
+	return false, false
+}
+
+// exprEqual compares numbers by value, including a number with a string
+// holding one, and other values strictly.
+func exprEqual(left, right interface{}) (bool, error) {
+	_, lok := left.(decimal.Decimal)
+	_, rok := right.(decimal.Decimal)
+	if lok || rok {
+		l, lok, err := toNumber(left)
+		if err != nil {
+			return false, err
+		}
+		r, rok, err := toNumber(right)
+		if err != nil {
+			return false, err
+		}
+		return lok && rok && l.Equal(r), nil
+	}
+	return reflect.DeepEqual(left, right), nil
+}
+
+func exprString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case decimal.Decimal:
+		return v.String()
+	case nil:
+		return "null"
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+type callNode struct {
+	name      string
+	function  exprFunction
+	arguments []exprNode
+}
+
+func (n callNode) eval(env *exprEnv) (interface{}, error) {
+	if len(n.arguments) < n.function.minArgs || (n.function.maxArgs >= 0 && len(n.arguments) > n.function.maxArgs) {
+		return nil, fmt.Errorf("wrong number of arguments for %s: %d", n.name, len(n.arguments))
+	}
+	arguments := make([]interface{}, len(n.arguments))
+	for i, argument := range n.arguments {
+		value, err := evalNode(env, argument)
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = value
+	}
+	result, err := n.function.call(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", n.name, err)
+	}
+	return result, nil
+}
+
+// exprFunction is a function available to expressions, taking between
+// minArgs and maxArgs arguments, or any number if maxArgs is -1.
+type exprFunction struct {
+	minArgs, maxArgs int
+	call             func(arguments []interface{}) (interface{}, error)
+}
+
+func numberArg(arguments []interface{}, i int) (decimal.Decimal, error) {
+	d, ok, err := toNumber(arguments[i])
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("argument %d must be a number, got %s", i+1, exprTypeName(arguments[i]))
+	}
+	return d, nil
+}
+
+func intArg(arguments []interface{}, i int, min, max int64) (int64, error) {
+	d, err := numberArg(arguments, i)
+	if err != nil {
+		return 0, err
+	}
+	if !d.Equal(d.Truncate(0)) || d.IntPart() < min || d.IntPart() > max {
+		return 0, fmt.Errorf("argument %d must be an integer between %d and %d", i+1, min, max)
+	}
+	return d.IntPart(), nil
+}
+
+func stringArg(arguments []interface{}, i int) (string, error) {
+	s, ok := arguments[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %s", i+1, exprTypeName(arguments[i]))
+	}
+	return s, nil
+}
+
+func numberFunction(f func(decimal.Decimal) decimal.Decimal) exprFunction {
+	return exprFunction{1, 1, func(arguments []interface{}) (interface{}, error) {
+		d, err := numberArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		return f(d), nil
+	}}
+}
+
+func stringFunction(f func(string) string) exprFunction {
+	return exprFunction{1, 1, func(arguments []interface{}) (interface{}, error) {
+		s, err := stringArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		return f(s), nil
+	}}
+}
+
+func extremumFunction(keep func(cmp int) bool) exprFunction {
+	return exprFunction{1, -1, func(arguments []interface{}) (interface{}, error) {
+		values := arguments
+		if array, ok := arguments[0].([]interface{}); ok && len(arguments) == 1 {
+			values = make([]interface{}, len(array))
+			for i := range array {
+				value, err := exprValue(array[i])
+				if err != nil {
+					return nil, err
+				}
+				values[i] = value
+			}
+		}
+		if len(values) == 0 {
+			return nil, errors.New("no values")
+		}
+		var result decimal.Decimal
+		for i := range values {
+			d, err := numberArg(values, i)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 || keep(d.Cmp(result)) {
+				result = d
+			}
+		}
+		return result, nil
+	}}
+}
+
+var exprFunctions = map[string]exprFunction{
+	"abs":   numberFunction(decimal.Decimal.Abs),
+	"ceil":  numberFunction(decimal.Decimal.Ceil),
+	"floor": numberFunction(decimal.Decimal.Floor),
+	"round": {1, 2, func(arguments []interface{}) (interface{}, error) {
+		d, err := numberArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		var places int64
+		if len(arguments) == 2 {
+			if places, err = intArg(arguments, 1, -expressionDivisionPrecision, expressionDivisionPrecision); err != nil {
+				return nil, err
+			}
+		}
+		return d.Round(int32(places)), nil
+	}},
+	"min": extremumFunction(func(cmp int) bool { return cmp < 0 }),
+	"max": extremumFunction(func(cmp int) bool { return cmp > 0 }),
+	"pow": {2, 2, func(arguments []interface{}) (interface{}, error) {
+		d, err := numberArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		exponent, err := intArg(arguments, 1, -maxExpressionNumberBits, maxExpressionNumberBits)
+		if err != nil {
+			return nil, err
+		}
+		if d.IsZero() && exponent < 0 {
+			return nil, errors.New("division by zero")
+		}
+		// Bound the size of the result before computing it
+		if bits := int64(d.Coefficient().BitLen()) * abs64(exponent); bits > maxExpressionNumberBits {
+			return nil, fmt.Errorf("number exceeds %d bits", maxExpressionNumberBits)
+		}
+		result := decimal.New(1, 0)
+		for i := int64(0); i < abs64(exponent); i++ {
+			result = result.Mul(d)
+		}
+		if exponent < 0 {
+			result = decimal.New(1, 0).DivRound(result, expressionDivisionPrecision)
+		}
+		return checkNumber(result)
+	}},
+	"number": {1, 1, func(arguments []interface{}) (interface{}, error) {
+		d, ok, err := toNumber(arguments[0])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %v to a number", exprResult(arguments[0]))
+		}
+		return d, nil
+	}},
+	"string": {1, 1, func(arguments []interface{}) (interface{}, error) {
+		return checkString(exprString(arguments[0]))
+	}},
+	"concat": {1, -1, func(arguments []interface{}) (interface{}, error) {
+		var sb strings.Builder
+		for _, argument := range arguments {
+			sb.WriteString(exprString(argument))
+			if sb.Len() > maxExpressionStringLength {
+				return nil, fmt.Errorf("string exceeds %d bytes", maxExpressionStringLength)
+			}
+		}
+		return sb.String(), nil
+	}},
+	"len": {1, 1, func(arguments []interface{}) (interface{}, error) {
+		switch v := arguments[0].(type) {
+		case string:
+			return decimal.New(int64(utf8.RuneCountInString(v)), 0), nil
+		case []interface{}:
+			return decimal.New(int64(len(v)), 0), nil
+		case map[string]interface{}:
+			return decimal.New(int64(len(v)), 0), nil
+		default:
+			return nil, fmt.Errorf("cannot get length of %s", exprTypeName(v))
+		}
+	}},
+	"lower": stringFunction(strings.ToLower),
+	"upper": stringFunction(strings.ToUpper),
+	"trim":  stringFunction(strings.TrimSpace),
+	"contains": {2, 2, func(arguments []interface{}) (interface{}, error) {
+		s, err := stringArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		substr, err := stringArg(arguments, 1)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Contains(s, substr), nil
+	}},
+	"substr": {2, 3, func(arguments []interface{}) (interface{}, error) {
+		s, err := stringArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		runes := []rune(s)
+		start, err := intArg(arguments, 1, 0, int64(len(runes)))
+		if err != nil {
+			return nil, err
+		}
+		end := int64(len(runes))
+		if len(arguments) == 3 {
+			length, err := intArg(arguments, 2, 0, end-start)
+			if err != nil {
+				return nil, err
+			}
+			end = start + length
+		}
+		return string(runes[start:end]), nil
+	}},
+	"replace": {3, 3, func(arguments []interface{}) (interface{}, error) {
+		s, err := stringArg(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		old, err := stringArg(arguments, 1)
+		if err != nil {
+			return nil, err
+		}
+		replacement, err := stringArg(arguments, 2)
+		if err != nil {
+			return nil, err
+		}
+		if old != "" && len(replacement) > len(old) &&
+			len(s)+strings.Count(s, old)*(len(replacement)-len(old)) > maxExpressionStringLength {
+			return nil, fmt.Errorf("string exceeds %d bytes", maxExpressionStringLength)
+		}
+		return checkString(strings.Replace(s, old, replacement, -1))
+	}},
+}
+
+func abs64(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// parseExpression parses an expression such as
+// `result * 100 > 5 ? round(result, 2) : concat("low ", upper(data.symbol))`.
+func parseExpression(expression string) (exprNode, error) {
+	if len(expression) > maxExpressionLength {
+		return nil, fmt.Errorf("expression exceeds %d bytes", maxExpressionLength)
+	}
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at %d", p.peek().text, p.peek().pos)
+	}
+	return node, nil
+}
+
+type exprTokenKind int
+
+const (
+	exprTokenNumber exprTokenKind = iota
+	exprTokenString
+	exprTokenIdentifier
+	exprTokenPunctuation
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// exprPunctuations are sorted so that longer operators match first.
+var exprPunctuations = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")", "[", "]", ".", ",",
+}
+
+func isIdentifierByte(b byte, first bool) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || (!first && '0' <= b && b <= '9')
+}
+
+func tokenizeExpression(expression string) ([]exprToken, error) {
+	var tokens []exprToken
+	for pos := 0; pos < len(expression); {
+		b := expression[pos]
+		start := pos
+		switch {
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			pos++
+			continue
+		case '0' <= b && b <= '9':
+			for pos < len(expression) && (('0' <= expression[pos] && expression[pos] <= '9') || expression[pos] == '.') {
+				pos++
+			}
+			tokens = append(tokens, exprToken{exprTokenNumber, expression[start:pos], start})
+			continue
+		case isIdentifierByte(b, true):
+			for pos < len(expression) && isIdentifierByte(expression[pos], false) {
+				pos++
+			}
+			tokens = append(tokens, exprToken{exprTokenIdentifier, expression[start:pos], start})
+			continue
+		case b == '"' || b == '\'':
+			var sb strings.Builder
+			pos++
+			for ; pos < len(expression) && expression[pos] != b; pos++ {
+				if expression[pos] == '\\' && pos+1 < len(expression) {
+					pos++
+				}
+				sb.WriteByte(expression[pos])
+			}
+			if pos == len(expression) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			pos++
+			tokens = append(tokens, exprToken{exprTokenString, sb.String(), start})
+			continue
+		}
+
+		matched := false
+		for _, punctuation := range exprPunctuations {
+			if strings.HasPrefix(expression[pos:], punctuation) {
+				tokens = append(tokens, exprToken{exprTokenPunctuation, punctuation, start})
+				pos += len(punctuation)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unexpected %q at %d", b, pos)
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *exprParser) peek() exprToken {
+	if p.done() {
+		return exprToken{kind: exprTokenPunctuation, pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+// consume consumes the next token if it is one of the given punctuations.
+func (p *exprParser) consume(punctuations ...string) (string, bool) {
+	token := p.peek()
+	if p.done() || token.kind != exprTokenPunctuation {
+		return "", false
+	}
+	for _, punctuation := range punctuations {
+		if token.text == punctuation {
+			p.pos++
+			return punctuation, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(punctuation string) error {
+	if _, ok := p.consume(punctuation); !ok {
+		if p.done() {
+			return fmt.Errorf("expected %q at end of expression", punctuation)
+		}
+		return fmt.Errorf("expected %q at %d", punctuation, p.peek().pos)
+	}
+	return nil
+}
+
+func (p *exprParser) parseConditional() (exprNode, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.consume("?"); !ok {
+		return condition, nil
+	}
+	then, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	return conditionalNode{condition: condition, then: then, otherwise: otherwise}, nil
+}
+
+// exprPrecedences lists the binary operators from the lowest precedence to
+// the highest.
+var exprPrecedences = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *exprParser) parseBinary(level int) (exprNode, error) {
+	if level == len(exprPrecedences) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.consume(exprPrecedences[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if operator == "&&" || operator == "||" {
+			left = logicalNode{operator: operator, left: left, right: right}
+		} else {
+			left = binaryNode{operator: operator, left: left, right: right}
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if operator, ok := p.consume("-", "!"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{operator: operator, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.consume("."); ok {
+			token := p.peek()
+			if p.done() || token.kind != exprTokenIdentifier {
+				return nil, fmt.Errorf("expected a field name at %d", token.pos)
+			}
+			p.pos++
+			node = memberNode{object: node, key: literalNode{token.text}}
+		} else if _, ok := p.consume("["); ok {
+			key, err := p.parseConditional()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = memberNode{object: node, key: key}
+		} else {
+			return node, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.done() {
+		return nil, errors.New("unexpected end of expression")
+	}
+	token := p.peek()
+	p.pos++
+
+	switch token.kind {
+	case exprTokenNumber:
+		d, err := decimal.NewFromString(token.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", token.text, token.pos)
+		}
+		if err := checkNumberRange(d); err != nil {
+			return nil, fmt.Errorf("%v at %d", err, token.pos)
+		}
+		return literalNode{d}, nil
+	case exprTokenString:
+		return literalNode{token.text}, nil
+	case exprTokenIdentifier:
+		switch token.text {
+		case "true":
+			return literalNode{true}, nil
+		case "false":
+			return literalNode{false}, nil
+		case "null":
+			return literalNode{nil}, nil
+		}
+		if _, ok := p.consume("("); !ok {
+			return variableNode{name: token.text}, nil
+		}
+		function, ok := exprFunctions[token.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %s at %d", token.text, token.pos)
+		}
+		var arguments []exprNode
+		if _, ok := p.consume(")"); !ok {
+			for {
+				argument, err := p.parseConditional()
+				if err != nil {
+					return nil, err
+				}
+				arguments = append(arguments, argument)
+				if _, ok := p.consume(","); !ok {
+					break
+				}
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+		return callNode{name: token.text, function: function, arguments: arguments}, nil
+	default:
+		if token.text == "(" {
+			node, err := p.parseConditional()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+		return nil, fmt.Errorf("unexpected %q at %d", token.text, token.pos)
+	}
+}
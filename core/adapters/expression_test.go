@@ -0,0 +1,150 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestExpression_Perform(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+		"result": "2.5",
+		"results": [1.1, "2.2", 3.3],
+		"bid": 2.4,
+		"quote": {"symbol": "eth", "prices": [231.57, 232.01]},
+		"large": 123456789012345678901234567890
+	}`
+
+	tests := []struct {
+		name       string
+		expression string
+		want       interface{}
+		wantError  bool
+	}{
+		{"arithmetic", "result * 100 - 50 / 2", "225", false},
+		{"precedence", "(1 + 2) * 3 % 4", "1", false},
+		{"decimal division", "1 / 3", "0.333333333333333333", false},
+		{"big decimal precision", "large + 1", "123456789012345678901234567891", false},
+		{"mean", "round((result + bid) / 2, 1)", "2.5", false},
+		{"numeric strings", "result + 1 == 3.5 && \"10\" > 9", true, false},
+		{"array fields", "results[0] + results[1] + results[-1]", "6.6", false},
+		{"nested fields", "quote.prices[1] - quote['prices'][0]", "0.44", false},
+		{"missing field", "quote.volume == null", true, false},
+		{"conditional", `bid > 2 ? "high" : "low"`, "high", false},
+		{"logic", "!(bid > 3) && (bid < 1 || bid >= 2.4)", true, false},
+		{"string ops", `concat(upper(quote.symbol), "-", substr("usd coin", 0, 3), result)`, "ETH-usd2.5", false},
+		{"string functions", `contains(replace(trim("  a-b "), "-", "+"), "a+b") && len(quote.prices) == 2`, true, false},
+		{"min and max", "max(results) - min(1, 0.5, 2)", "2.8", false},
+		{"pow", "pow(10, 18) * bid", "2400000000000000000", false},
+		{"negative pow", "pow(2, -2)", "0.25", false},
+		{"unknown variable", "missing + 1", nil, true},
+		{"division by zero", "bid / (bid - 2.4)", nil, true},
+		{"type mismatch", "bid && true", nil, true},
+		{"non-numeric string", `quote.symbol * 2`, nil, true},
+		{"non-boolean condition", "bid ? 1 : 2", nil, true},
+		{"number too large", "pow(large, 100)", nil, true},
+		{"number too large by multiplication", "large * large * large * large * large * large * large * large * large * large * large", nil, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInput(cltest.JSONFromString(t, data))
+			adapter := adapters.Expression{}
+			params, err := json.Marshal(map[string]string{"expression": test.expression})
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(params, &adapter))
+
+			result := adapter.Perform(input, nil)
+			if test.wantError {
+				assert.Error(t, result.Error())
+				assert.Equal(t, models.RunStatusErrored, result.Status())
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().Value())
+		})
+	}
+}
+
+func TestExpression_Perform_ParentResults(t *testing.T) {
+	t.Parallel()
+
+	// The results of several parents take the place of a results field
+	input := cltest.NewRunInputWithString(t, `{"result":"1","results":[100,200]}`).
+		WithParentResults(gjson.Parse(`["1.5",2.5,null]`).Array())
+	adapter := adapters.Expression{Expression: "results[0] + results[1] + (results[2] == null ? 1 : 0)"}
+
+	result := adapter.Perform(input, nil)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "5", result.Result().Value())
+}
+
+func TestExpression_Perform_Limits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		expression string
+		result     string
+		wantError  string
+	}{
+		{"deep nesting", strings.Repeat("-", 4000) + "1", "x", ""},
+		{"string too long", strings.Repeat("replace(", 5) + `"aaaaaaaaaa"` + strings.Repeat(`, "a", "aaaaaaaaaa")`, 5), "x", "string exceeds"},
+		{"number too large", "pow(2, 1000) * pow(2, 1000)", "x", "number exceeds"},
+		{"exponent too small", "result + 1", "1e-10000000", "number exponent exceeds"},
+		{"exponent too large", "result * 2", "1e10000000", "number exponent exceeds"},
+		{"exponent compared", "result == 1", "1e-10000000", "number exponent exceeds"},
+		{"literal too large", strings.Repeat("9", 400) + " + 1", "x", "number exceeds"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Expression{Expression: test.expression}
+			result := adapter.Perform(cltest.NewRunInputWithResult(test.result), nil)
+			if test.wantError == "" {
+				assert.NoError(t, result.Error())
+			} else {
+				require.Error(t, result.Error())
+				assert.Contains(t, result.Error().Error(), test.wantError)
+			}
+		})
+	}
+}
+
+func TestExpression_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		params    string
+		wantError bool
+	}{
+		{"valid", `{"expression":"result * 2"}`, false},
+		{"unbalanced parentheses", `{"expression":"(result * 2"}`, true},
+		{"trailing operator", `{"expression":"result *"}`, true},
+		{"unknown function", `{"expression":"exec(result)"}`, true},
+		{"unterminated string", `{"expression":"'abc"}`, true},
+		{"too long", `{"expression":"` + strings.Repeat("1+", 2100) + `1"}`, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			var adapter adapters.Expression
+			err := json.Unmarshal([]byte(test.params), &adapter)
+			cltest.AssertError(t, test.wantError, err)
+		})
+	}
+}
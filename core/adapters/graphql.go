@@ -0,0 +1,236 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	simplejson "github.com/bitly/go-simplejson"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// graphQLVariableTemplate matches the references to the input data in the
+// variables of a GraphQL query, capturing the path of the value.
+var graphQLVariableTemplate = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// GraphQL sends a query to a GraphQL API and returns the value at the path
+// of the data it responds with.
+//
+// String variables can reference the data of the input as {{path}}, such as
+// {{result}} or {{pair.base}}. A variable which is only a reference takes the
+// value at the path, of any type, while references within a longer string
+// are replaced by the value as text.
+type GraphQL struct {
+	URL           models.WebURL          `json:"url"`
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables"`
+	Headers       http.Header            `json:"headers"`
+	Path          JSONPath               `json:"path"`
+	CacheTTL      models.Duration        `json:"cacheTTL"`
+
+	AllowUnrestrictedNetworkAccess bool `json:"-"`
+}
+
+// TaskType returns the type of Adapter.
+func (gql *GraphQL) TaskType() models.TaskType {
+	return TaskTypeGraphQL
+}
+
+// graphQLRequest is the body of a GraphQL request sent over HTTP.
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of a GraphQL response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GraphQLError is returned when a GraphQL API responds with errors, and
+// whose message joins the messages of the errors.
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql: " + strings.Join(e.Messages, "; ")
+}
+
+// Perform sends the query, with its variables filled in from the input, and
+// returns the value at the path of the data in the response. The task fails
+// with the upstream error message if the response has any errors.
+//
+// For example, with the path "pair.price", the response
+// {"data": {"pair": {"price": "1.5"}}} returns "1.5".
+func (gql *GraphQL) Perform(input models.RunInput, store *store.Store) models.RunOutput {
+	if strings.TrimSpace(gql.Query) == "" {
+		return models.NewRunOutputError(errors.New("graphql query is required"))
+	}
+
+	variables, err := templateGraphQLVariables(gql.Variables, input.Data())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	body, err := json.Marshal(graphQLRequest{
+		Query:         gql.Query,
+		OperationName: gql.OperationName,
+		Variables:     variables,
+	})
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	request, err := http.NewRequest("POST", gql.URL.String(), bytes.NewReader(body))
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	setHeaders(request, gql.Headers, "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	key, err := responseCacheKey(request)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	httpConfig := defaultHTTPConfig(store)
+	httpConfig.allowUnrestrictedNetworkAccess = gql.AllowUnrestrictedNetworkAccess
+	responseBody, err := store.Responses.Fetch(gql.TaskType().String(), key, gql.CacheTTL.Duration(), func() ([]byte, bool, error) {
+		response, statusCode, err := withRetry(newHTTPClient(httpConfig), request, httpConfig)
+		if err != nil {
+			return nil, false, err
+		}
+		// Error responses which are not GraphQL responses, such as those of
+		// a proxy in front of the API, are reported by their status code.
+		if _, err := parseGraphQLResponse(response); err != nil {
+			if _, ok := err.(*GraphQLError); ok || statusCode < 400 {
+				return nil, false, err
+			}
+		}
+		if statusCode >= 400 {
+			return nil, false, &HTTPResponseError{statusCode, string(response)}
+		}
+		return response, true, nil
+	})
+	if err != nil {
+		return requestErrorOutput(store.Clock, err)
+	}
+
+	data, err := parseGraphQLResponse(responseBody)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return gql.result(data)
+}
+
+func (gql *GraphQL) result(data *simplejson.Json) models.RunOutput {
+	if expression, ok := gql.Path.Expression(); ok {
+		return performJSONPathExpression(data, expression)
+	}
+	if len(gql.Path) == 0 {
+		return models.NewRunOutputCompleteWithResult(data.Interface())
+	}
+	value, err := dig(data, gql.Path)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(value.Interface())
+}
+
+// parseGraphQLResponse returns the data of the response, or a GraphQLError
+// if it has errors.
+func parseGraphQLResponse(body []byte) (*simplejson.Json, error) {
+	var response graphQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrap(err, "graphql response is not valid JSON")
+	}
+	if len(response.Errors) > 0 {
+		messages := make([]string, len(response.Errors))
+		for i, e := range response.Errors {
+			messages[i] = e.Message
+		}
+		return nil, &GraphQLError{Messages: messages}
+	}
+	if len(response.Data) == 0 || string(response.Data) == "null" {
+		return nil, errors.New("graphql response has no data")
+	}
+	return simplejson.NewJson(response.Data)
+}
+
+// templateGraphQLVariables returns a copy of the variables with references
+// to the input data replaced by its values.
+func templateGraphQLVariables(variables map[string]interface{}, data models.JSON) (map[string]interface{}, error) {
+	if variables == nil {
+		return nil, nil
+	}
+	templated, err := templateGraphQLValue(variables, data)
+	if err != nil {
+		return nil, err
+	}
+	return templated.(map[string]interface{}), nil
+}
+
+func templateGraphQLValue(value interface{}, data models.JSON) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		templated := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			t, err := templateGraphQLValue(v, data)
+			if err != nil {
+				return nil, err
+			}
+			templated[k] = t
+		}
+		return templated, nil
+	case []interface{}:
+		templated := make([]interface{}, len(typed))
+		for i, v := range typed {
+			t, err := templateGraphQLValue(v, data)
+			if err != nil {
+				return nil, err
+			}
+			templated[i] = t
+		}
+		return templated, nil
+	case string:
+		return templateGraphQLString(typed, data)
+	default:
+		return value, nil
+	}
+}
+
+func templateGraphQLString(text string, data models.JSON) (interface{}, error) {
+	if match := graphQLVariableTemplate.FindStringSubmatch(text); match != nil && match[0] == text {
+		value := data.Get(match[1])
+		if !value.Exists() {
+			return nil, fmt.Errorf("graphql variable %s: no value at %s in the input", text, match[1])
+		}
+		if value.Type == gjson.String {
+			return value.String(), nil
+		}
+		// Keep numbers as they are in the input, rather than as floats
+		return json.RawMessage(value.Raw), nil
+	}
+
+	var err error
+	templated := graphQLVariableTemplate.ReplaceAllStringFunc(text, func(reference string) string {
+		path := graphQLVariableTemplate.FindStringSubmatch(reference)[1]
+		value := data.Get(path)
+		if !value.Exists() {
+			err = fmt.Errorf("graphql variable %s: no value at %s in the input", text, path)
+		}
+		return value.String()
+	})
+	return templated, err
+}
@@ -0,0 +1,116 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQL_Perform(t *testing.T) {
+	t.Parallel()
+
+	store := leanStore()
+	query := `query Price($base: String!, $limit: Int) { pair(base: $base) { price } }`
+
+	cases := []struct {
+		name      string
+		status    int
+		response  string
+		path      string
+		want      string
+		wantError string
+	}{
+		{"success", http.StatusOK, `{"data":{"pair":{"price":"1.5"}}}`, `"pair.price"`, "1.5", ""},
+		{"success with expression", http.StatusOK, `{"data":{"pair":{"price":2.5}}}`, `"$.pair.price"`, "2.5", ""},
+		{"success without path", http.StatusOK, `{"data":{"pair":{"price":"1.5"}}}`, `[]`, `{"pair":{"price":"1.5"}}`, ""},
+		{"missing path", http.StatusOK, `{"data":{"pair":null}}`, `"pair.price"`, "", "No value could be found for the key 'price'"},
+		{"errors", http.StatusOK, `{"data":null,"errors":[{"message":"unknown pair"},{"message":"rate limited"}]}`, `"pair.price"`, "", "graphql: unknown pair; rate limited"},
+		{"errors with partial data", http.StatusOK, `{"data":{"pair":{"price":"1.5"}},"errors":[{"message":"stale"}]}`, `"pair.price"`, "", "graphql: stale"},
+		{"errors with error status", http.StatusBadRequest, `{"errors":[{"message":"syntax error"}]}`, `"pair.price"`, "", "graphql: syntax error"},
+		{"error status", http.StatusBadRequest, `<html>bad request</html>`, `"pair.price"`, "", "<html>bad request</html>"},
+		{"no data", http.StatusOK, `{}`, `"pair.price"`, "", "graphql response has no data"},
+	}
+
+	for _, test := range cases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var received map[string]interface{}
+			var receivedBody string
+			mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "token", r.Header.Get("Authorization"))
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				receivedBody = string(body)
+				require.NoError(t, json.Unmarshal(body, &received))
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.response)
+			}))
+			defer mock.Close()
+
+			gql := adapters.GraphQL{AllowUnrestrictedNetworkAccess: true}
+			params := fmt.Sprintf(`{
+				"url": "%s",
+				"query": %q,
+				"variables": {"base": "{{pair.base}}", "limit": "{{limit}}", "label": "{{pair.base}}/USD"},
+				"headers": {"Authorization": ["token"]},
+				"path": %s
+			}`, mock.URL, query, test.path)
+			require.NoError(t, json.Unmarshal([]byte(params), &gql))
+
+			input := cltest.NewRunInput(cltest.JSONFromString(t, `{"pair":{"base":"ETH"},"limit":100000000000000000001}`))
+			result := gql.Perform(input, store)
+
+			assert.Equal(t, query, received["query"])
+			assert.Equal(t, map[string]interface{}{
+				"base":  "ETH",
+				"limit": 100000000000000000001.0,
+				"label": "ETH/USD",
+			}, received["variables"])
+			assert.Contains(t, receivedBody, `"limit":100000000000000000001`, "numbers should keep their precision")
+
+			if test.wantError != "" {
+				require.Error(t, result.Error())
+				assert.Equal(t, test.wantError, result.Error().Error())
+			} else {
+				require.NoError(t, result.Error())
+				assert.Equal(t, test.want, result.Result().String())
+			}
+		})
+	}
+}
+
+func TestGraphQL_Perform_MissingVariable(t *testing.T) {
+	t.Parallel()
+
+	gql := adapters.GraphQL{
+		URL:       cltest.WebURL(t, "https://example.com/graphql"),
+		Query:     `query { price }`,
+		Variables: map[string]interface{}{"base": "{{pair.base}}"},
+	}
+	result := gql.Perform(cltest.NewRunInputWithResult("1"), leanStore())
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "no value at pair.base")
+}
+
+func TestGraphQL_For(t *testing.T) {
+	t.Parallel()
+
+	task := models.TaskSpec{
+		Type:   adapters.TaskTypeGraphQL,
+		Params: cltest.JSONFromString(t, `{"url":"https://example.com/graphql","query":"{ price }","path":"price"}`),
+	}
+	adapter := adapters.FindNativeAdapterFor(task)
+	require.IsType(t, &adapters.GraphQL{}, adapter)
+}
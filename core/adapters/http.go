@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -20,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/avast/retry-go"
+	"github.com/pkg/errors"
 )
 
 // HTTPGet requires a URL which is used for a GET request when the adapter is called.
@@ -29,6 +29,7 @@ type HTTPGet struct {
 	Headers                        http.Header     `json:"headers"`
 	QueryParams                    QueryParameters `json:"queryParams"`
 	ExtendedPath                   ExtendedPath    `json:"extPath"`
+	CacheTTL                       models.Duration `json:"cacheTTL"`
 	AllowUnrestrictedNetworkAccess bool            `json:"-"`
 }
 
@@ -38,6 +39,7 @@ type HTTPRequestConfig struct {
 	maxAttempts                    uint
 	sizeLimit                      int64
 	allowUnrestrictedNetworkAccess bool
+	hostLimiter                    *store.HostLimiter
 }
 
 // TaskType returns the type of Adapter.
@@ -54,7 +56,7 @@ func (hga *HTTPGet) Perform(input models.RunInput, store *store.Store) models.Ru
 	}
 	httpConfig := defaultHTTPConfig(store)
 	httpConfig.allowUnrestrictedNetworkAccess = hga.AllowUnrestrictedNetworkAccess
-	return sendRequest(input, request, httpConfig)
+	return sendRequest(store, hga.TaskType(), hga.CacheTTL, request, httpConfig)
 }
 
 // GetURL retrieves the GET field if set otherwise returns the URL field
@@ -85,6 +87,7 @@ type HTTPPost struct {
 	QueryParams                    QueryParameters `json:"queryParams"`
 	Body                           *string         `json:"body,omitempty"`
 	ExtendedPath                   ExtendedPath    `json:"extPath"`
+	CacheTTL                       models.Duration `json:"cacheTTL"`
 	AllowUnrestrictedNetworkAccess bool            `json:"-"`
 }
 
@@ -102,7 +105,7 @@ func (hpa *HTTPPost) Perform(input models.RunInput, store *store.Store) models.R
 	}
 	httpConfig := defaultHTTPConfig(store)
 	httpConfig.allowUnrestrictedNetworkAccess = hpa.AllowUnrestrictedNetworkAccess
-	return sendRequest(input, request, httpConfig)
+	return sendRequest(store, hpa.TaskType(), hpa.CacheTTL, request, httpConfig)
 }
 
 // GetURL retrieves the POST field if set otherwise returns the URL field
@@ -161,32 +164,83 @@ func setHeaders(request *http.Request, headers http.Header, contentType string)
 	}
 }
 
-func sendRequest(input models.RunInput, request *http.Request, config HTTPRequestConfig) models.RunOutput {
+func newHTTPClient(config HTTPRequestConfig) *http.Client {
 	tr := &http.Transport{
 		DisableCompression: true,
 	}
 	if !config.allowUnrestrictedNetworkAccess {
 		tr.DialContext = restrictedDialContext
 	}
-	client := &http.Client{Transport: tr}
+	return &http.Client{Transport: tr}
+}
 
-	bytes, statusCode, err := withRetry(client, request, config)
+// sendRequest sends the request, or answers it from the response cache if
+// the task set a cache TTL and an identical request was answered within it.
+func sendRequest(
+	store *store.Store,
+	adapter models.TaskType,
+	ttl models.Duration,
+	request *http.Request,
+	config HTTPRequestConfig,
+) models.RunOutput {
+	key, err := responseCacheKey(request)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
 
-	responseBody := string(bytes)
+	bytes, err := store.Responses.Fetch(adapter.String(), key, ttl.Duration(), func() ([]byte, bool, error) {
+		bytes, statusCode, err := withRetry(newHTTPClient(config), request, config)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// This is either a client error caused on our end or a server error that persists even after retrying.
+		// Either way, there is no way for us to complete the run with a result.
+		if statusCode >= 400 {
+			return nil, false, &HTTPResponseError{statusCode, string(bytes)}
+		}
+		return bytes, true, nil
+	})
+	if err != nil {
+		return requestErrorOutput(store.Clock, err)
+	}
+
+	return models.NewRunOutputCompleteWithResult(string(bytes))
+}
 
-	// This is either a client error caused on our end or a server error that persists even after retrying.
-	// Either way, there is no way for us to complete the run with a result.
-	if statusCode >= 400 {
-		return models.NewRunOutputError(errors.New(responseBody))
+// requestErrorOutput returns the output of a failed request, which is
+// pending if the request was over the limits of its host, so that the run
+// is retried once it is within them rather than failing.
+func requestErrorOutput(clock utils.Nower, err error) models.RunOutput {
+	if limited, ok := errors.Cause(err).(*store.HostLimitedError); ok {
+		return models.NewRunOutputPendingRetry(err, clock.Now().Add(limited.Wait))
 	}
+	return models.NewRunOutputError(err)
+}
 
-	return models.NewRunOutputCompleteWithResult(responseBody)
+// hostLimited returns true if err shows that a request was not sent
+// because it was over the limits of its host.
+func hostLimited(err error) bool {
+	_, ok := errors.Cause(err).(*store.HostLimitedError)
+	return ok
 }
 
-// withRetry executes the http request in a retry. Timeout is controlled with a context
+func responseCacheKey(request *http.Request) (string, error) {
+	var body []byte
+	if request.GetBody != nil {
+		reader, err := request.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+		if body, err = ioutil.ReadAll(reader); err != nil {
+			return "", err
+		}
+	}
+	return store.ResponseCacheKey(request.Method, request.URL.String(), request.Header, body), nil
+}
+
+// withRetry executes the http request in a retry, if it is within the limits of its host. Timeout is controlled with a context
 // Retry occurs if the request timeout, or there is any kind of connection or transport-layer error
 // Retry also occurs on remote server 5xx errors
 func withRetry(
@@ -194,6 +248,12 @@ func withRetry(
 	originalRequest *http.Request,
 	config HTTPRequestConfig,
 ) (responseBody []byte, statusCode int, err error) {
+	release, err := config.hostLimiter.Acquire(originalRequest.URL)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
 	err = retry.Do(
 		func() error {
 			ctx, cancel := context.WithTimeout(context.Background(), config.timeout)
@@ -258,6 +318,27 @@ func (e *RemoteServerError) Error() string {
 	return fmt.Sprintf("remote server error: %v\nResponse body: %v", e.statusCode, string(e.responseBody))
 }
 
+// StatusCode returns the status code of the response.
+func (e *RemoteServerError) StatusCode() int {
+	return e.statusCode
+}
+
+// HTTPResponseError is returned when a request is answered with an error
+// status code that was not retried, and whose message is the response body.
+type HTTPResponseError struct {
+	statusCode int
+	message    string
+}
+
+func (e *HTTPResponseError) Error() string {
+	return e.message
+}
+
+// StatusCode returns the status code of the response.
+func (e *HTTPResponseError) StatusCode() int {
+	return e.statusCode
+}
+
 // maxBytesReader is inspired by
 // https://github.com/gin-contrib/size/blob/master/size.go
 type maxBytesReader struct {
@@ -400,5 +481,6 @@ func defaultHTTPConfig(store *store.Store) HTTPRequestConfig {
 		store.Config.DefaultMaxHTTPAttempts(),
 		store.Config.DefaultHTTPLimit(),
 		false,
+		store.HostLimiter,
 	}
 }
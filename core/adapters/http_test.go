@@ -2,14 +2,19 @@ package adapters_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,6 +108,65 @@ func TestHTTPGet_Perform(t *testing.T) {
 	}
 }
 
+func TestHTTP_PerformCached(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, "response %d", n)
+	}))
+	defer mock.Close()
+
+	s := leanStore()
+	s.Responses = store.NewResponseCache(1024, 1024, utils.Clock{})
+	ttl := models.MustMakeDuration(time.Minute)
+	body := `{"price":"100"}`
+
+	tests := []struct {
+		name    string
+		adapter adapters.BaseAdapter
+		want    string
+	}{
+		{"uncached", &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), AllowUnrestrictedNetworkAccess: true}, "response 1"},
+		{"uncached again", &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), AllowUnrestrictedNetworkAccess: true}, "response 2"},
+		{"miss", &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), CacheTTL: ttl, AllowUnrestrictedNetworkAccess: true}, "response 3"},
+		{"hit", &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), CacheTTL: ttl, AllowUnrestrictedNetworkAccess: true}, "response 3"},
+		{"other headers", &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), Headers: http.Header{"X-Key": {"1"}}, CacheTTL: ttl, AllowUnrestrictedNetworkAccess: true}, "response 4"},
+		{"post miss", &adapters.HTTPPost{URL: cltest.WebURL(t, mock.URL), Body: &body, CacheTTL: ttl, AllowUnrestrictedNetworkAccess: true}, "response 5"},
+		{"post hit", &adapters.HTTPPost{URL: cltest.WebURL(t, mock.URL), Body: &body, CacheTTL: ttl, AllowUnrestrictedNetworkAccess: true}, "response 5"},
+	}
+
+	for _, test := range tests {
+		result := test.adapter.Perform(cltest.NewRunInputWithResult("inputValue"), s)
+		require.NoError(t, result.Error(), test.name)
+		assert.Equal(t, test.want, result.Result().String(), test.name)
+	}
+}
+
+func TestHTTP_PerformHostLimited(t *testing.T) {
+	t.Parallel()
+
+	mock, cleanup := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", "results!")
+	defer cleanup()
+
+	s := leanStore()
+	s.Clock = utils.Clock{}
+	s.HostLimiter = store.NewHostLimiter(orm.HTTPHostLimit{RequestsPerSecond: 0.1}, nil)
+	hga := adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), AllowUnrestrictedNetworkAccess: true}
+
+	result := hga.Perform(cltest.NewRunInputWithResult("inputValue"), s)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "results!", result.Result().String())
+
+	result = hga.Perform(cltest.NewRunInputWithResult("inputValue"), s)
+	require.Error(t, result.Error())
+	assert.Equal(t, models.RunStatusPendingSleep, result.Status(), "should pend rather than fail")
+	retryAt, pending := result.RetryAt()
+	require.True(t, pending)
+	assert.True(t, retryAt.After(time.Now()))
+}
+
 func TestHTTP_TooLarge(t *testing.T) {
 	cfg := orm.NewConfig()
 	cfg.Set("DEFAULT_HTTP_LIMIT", "1")
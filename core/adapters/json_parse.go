@@ -15,7 +15,7 @@ import (
 )
 
 // JSONParse holds a path to the desired field in a JSON object,
-// made up of an array of strings.
+// made up of an array of strings, or a JSONPath expression starting with $.
 type JSONParse struct {
 	Path JSONPath `json:"path"`
 }
@@ -36,7 +36,9 @@ func (jpa *JSONParse) TaskType() models.TaskType {
 //     ]
 //   }
 //
-// Then ["0","last"] would be the path, and "1111" would be the returned value
+// Then ["0","last"] would be the path, and "1111" would be the returned value.
+// The same value would be returned by the expression "$.data[0].last", while
+// "$.data[*].last" would return ["1111", "2222"].
 func (jpa *JSONParse) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
 	var val string
 	var err error
@@ -57,6 +59,10 @@ func (jpa *JSONParse) Perform(input models.RunInput, _ *store.Store) models.RunO
 		return models.NewRunOutputError(err)
 	}
 
+	if expression, ok := jpa.Path.Expression(); ok {
+		return performJSONPathExpression(js, expression)
+	}
+
 	last, err := dig(js, jpa.Path)
 	if err != nil {
 		return moldErrorOutput(js, jpa.Path, input)
@@ -65,6 +71,18 @@ func (jpa *JSONParse) Perform(input models.RunInput, _ *store.Store) models.RunO
 	return models.NewRunOutputCompleteWithResult(last.Interface())
 }
 
+func performJSONPathExpression(js *simplejson.Json, expression string) models.RunOutput {
+	parsed, err := parseJSONPathExpression(expression)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	result, err := parsed.evaluate(js.Interface())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(result)
+}
+
 func dig(js *simplejson.Json, path []string) (*simplejson.Json, error) {
 	var ok bool
 	for _, k := range path[:] {
@@ -132,15 +150,32 @@ func isArray(js *simplejson.Json, key string) bool {
 	return true
 }
 
-// JSONPath is a path to a value in a JSON object
+// JSONPath is a path to a value in a JSON object, either as its keys, or as
+// a single JSONPath expression starting with $.
 type JSONPath []string
 
-// UnmarshalJSON implements the Unmarshaler interface
+// Expression returns the JSONPath expression of the path, if it is one.
+func (jp JSONPath) Expression() (string, bool) {
+	if len(jp) == 1 && isJSONPathExpression(jp[0]) {
+		return jp[0], true
+	}
+	return "", false
+}
+
+// UnmarshalJSON implements the Unmarshaler interface. A string which is $ or
+// starts with $. or $[ is kept whole as a JSONPath expression, while other
+// strings are split into keys at their dots.
 func (jp *JSONPath) UnmarshalJSON(b []byte) error {
 	strs := []string{}
 	var err error
 	if utils.IsQuoted(b) {
-		strs = strings.Split(string(utils.RemoveQuotes(b)), ".")
+		path := string(utils.RemoveQuotes(b))
+		if isJSONPathExpression(path) {
+			strs = []string{path}
+			_, err = parseJSONPathExpression(path)
+		} else {
+			strs = strings.Split(path, ".")
+		}
 	} else {
 		err = json.Unmarshal(b, &strs)
 	}
@@ -145,6 +145,67 @@ func TestJsonParse_Perform_WithPreParsedJSON(t *testing.T) {
 	assert.NoError(t, result.Error())
 }
 
+func TestJsonParse_Perform_JSONPathExpression(t *testing.T) {
+	t.Parallel()
+
+	prices := `{"data":[
+		{"symbol":"BTC","price":9431.12,"volume":"1200"},
+		{"symbol":"ETH","price":231.57,"volume":"8800"},
+		{"symbol":"LINK","price":4.2,"volume":"430000","tags":["defi"]}
+	]}`
+
+	tests := []struct {
+		name            string
+		path            string
+		wantData        string
+		wantResultError bool
+	}{
+		{"root", `$`, `{"result":{"a":1}}`, false},
+		{"child", `$.data[1].symbol`, `{"result":"ETH"}`, false},
+		{"quoted child", `$['data'][0]["symbol"]`, `{"result":"BTC"}`, false},
+		{"negative index", `$.data[-1].price`, `{"result":4.2}`, false},
+		{"missing index", `$.data[5].price`, ``, true},
+		{"missing child", `$.nope`, ``, true},
+		{"filter on string", `$.data[?(@.symbol=='ETH')].price`, `{"result":[231.57]}`, false},
+		{"filter on number", `$.data[?(@.price > 100)].symbol`, `{"result":["BTC","ETH"]}`, false},
+		{"filter with and", `$.data[?(@.price > 100 && @.symbol != "BTC")].symbol`, `{"result":["ETH"]}`, false},
+		{"filter with or", `$.data[?(@.symbol == 'LINK' || @.price < 0)].volume`, `{"result":["430000"]}`, false},
+		{"filter on existence", `$.data[?(@.tags)].symbol`, `{"result":["LINK"]}`, false},
+		{"filter on negation", `$.data[?(!@.tags)].symbol`, `{"result":["BTC","ETH"]}`, false},
+		{"filter without matches", `$.data[?(@.price < 0)].symbol`, `{"result":[]}`, false},
+		{"wildcard", `$.data[*].symbol`, `{"result":["BTC","ETH","LINK"]}`, false},
+		{"slice", `$.data[:2].symbol`, `{"result":["BTC","ETH"]}`, false},
+		{"recursive descent", `$..tags[0]`, `{"result":["defi"]}`, false},
+		{"length of array", `$.data.length()`, `{"result":3}`, false},
+		{"length of matches", `$.data[?(@.price < 1000)].length()`, `{"result":2}`, false},
+		{"length of missing", `$.nope.length()`, ``, true},
+		{"length without matches", `$.data[?(@.price < 0)].length()`, `{"result":0}`, false},
+		{"length of number", `$.data[0].price.length()`, ``, true},
+		{"invalid", `$.data[?(@.price >)]`, ``, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			result := prices
+			if test.path == `$` {
+				result = `{"a":1}`
+			}
+			input := cltest.NewRunInputWithResult(result)
+			adapter := adapters.JSONParse{Path: []string{test.path}}
+			output := adapter.Perform(input, nil)
+
+			if test.wantResultError {
+				assert.Error(t, output.Error())
+				assert.Equal(t, models.RunStatusErrored, output.Status())
+			} else {
+				assert.NoError(t, output.Error())
+				assert.Equal(t, test.wantData, output.Data().String())
+			}
+		})
+	}
+}
+
 func TestJSON_UnmarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -158,6 +219,10 @@ func TestJSON_UnmarshalJSON(t *testing.T) {
 		{"string", `{"path":"first"}`, []string{"first"}, false},
 		{"dot delimited", `{"path":"1.b"}`, []string{"1", "b"}, false},
 		{"dot delimited empty string", `{"path":"1...b"}`, []string{"1", "", "", "b"}, false},
+		{"expression", `{"path":"$.data[?(@.symbol=='ETH')].price"}`, []string{"$.data[?(@.symbol=='ETH')].price"}, false},
+		{"dot delimited expression", `{"path":"$.data.price"}`, []string{"$.data.price"}, false},
+		{"root", `{"path":"$"}`, []string{"$"}, false},
+		{"invalid expression errors", `{"path":"$.data[?(@.symbol=="}`, []string{}, true},
 		{"unclosed array errors", `{"path":["1"}`, []string{}, true},
 		{"unclosed string errors", `{"path":"1.2}`, []string{}, true},
 	}
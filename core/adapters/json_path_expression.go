@@ -0,0 +1,847 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// isJSONPathExpression returns true if the given path is a JSONPath
+// expression, such as `$.data[?(@.symbol=='ETH')].price`, rather than a
+// key.
+func isJSONPathExpression(path string) bool {
+	return path == "$" || strings.HasPrefix(path, "$.") || strings.HasPrefix(path, "$[")
+}
+
+// jsonPathExpression is a parsed JSONPath expression, made up of steps
+// selecting values from those selected by the previous step, and
+// optionally ending with a call to length().
+//
+// Expressions are the JSONPath queries of RFC 9535, with its name ('name',
+// .name), wildcard (*, .*), index ([0], [-1]), slice ([1:5:2]) and filter
+// selectors, unions of selectors ([0, 'name']) and descendant segments
+// (..name, ..[0]). Filters may combine comparisons and existence tests of
+// paths relative to the current value @ with !, && and ||, such as
+// [?@.price > 10 && @.symbol == 'ETH'] or [?(@.tags)]. Unlike RFC 9535:
+//
+//   - Filters can not call functions, such as count() or match(), nor use
+//     paths from the root $.
+//   - Blank space is only allowed within brackets.
+//   - Object members are selected in the order of their sorted names, which
+//     is one of the orders RFC 9535 allows.
+//   - The values selected by a singular query, made up only of names and
+//     indexes, such as $.data[0].price, are returned as the one value
+//     selected, and are an error if none is. The values selected by other
+//     queries are returned as an array, which may be empty.
+//   - An expression may end with .length(), returning the length of the
+//     array, object or string selected by a singular query, or the number of
+//     values selected by other queries.
+type jsonPathExpression struct {
+	expression string
+	steps      []jsonPathStep
+	length     bool
+}
+
+type jsonPathStep struct {
+	recursive bool
+	selector  jsonPathSelector
+}
+
+type jsonPathSelector interface {
+	selectFrom(value interface{}) []interface{}
+	definite() bool
+}
+
+// parseJSONPathExpression parses the given expression, which must start
+// with the root $.
+func parseJSONPathExpression(expression string) (*jsonPathExpression, error) {
+	if !isJSONPathExpression(expression) {
+		return nil, fmt.Errorf("JSONPath expression %q must start with $", expression)
+	}
+	p := &jsonPathParser{input: expression, pos: 1}
+	steps, length, err := p.parseSteps(false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression %q: %v", expression, err)
+	}
+	return &jsonPathExpression{expression: expression, steps: steps, length: length}, nil
+}
+
+// definite returns true if the expression selects at most one value.
+func (e *jsonPathExpression) definite() bool {
+	return stepsAreDefinite(e.steps)
+}
+
+func stepsAreDefinite(steps []jsonPathStep) bool {
+	for _, step := range steps {
+		if step.recursive || !step.selector.definite() {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate returns the values selected by the expression from the given
+// decoded JSON document. A definite expression returns the value it
+// selects, and errors if there is none, while other expressions always
+// return an array of the values they select, which may be empty.
+//
+// length() returns the length of the array, object or string selected by a
+// definite expression, or the number of values selected otherwise.
+func (e *jsonPathExpression) evaluate(document interface{}) (interface{}, error) {
+	values := selectSteps(e.steps, document)
+
+	if !e.definite() {
+		if e.length {
+			return len(values), nil
+		}
+		if values == nil {
+			return []interface{}{}, nil
+		}
+		return values, nil
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("JSONPath expression %q selected no value", e.expression)
+	}
+	if e.length {
+		switch v := values[0].(type) {
+		case []interface{}:
+			return len(v), nil
+		case map[string]interface{}:
+			return len(v), nil
+		case string:
+			return len(v), nil
+		default:
+			return nil, fmt.Errorf("length() of %T", v)
+		}
+	}
+
+	return values[0], nil
+}
+
+func selectSteps(steps []jsonPathStep, document interface{}) []interface{} {
+	values := []interface{}{document}
+	for _, step := range steps {
+		var selected []interface{}
+		for _, value := range values {
+			if step.recursive {
+				for _, descendant := range descendants(value) {
+					selected = append(selected, step.selector.selectFrom(descendant)...)
+				}
+			} else {
+				selected = append(selected, step.selector.selectFrom(value)...)
+			}
+		}
+		values = selected
+	}
+	return values
+}
+
+// descendants returns the given value followed by all values nested in it,
+// in document order, with object keys sorted.
+func descendants(value interface{}) []interface{} {
+	all := []interface{}{value}
+	for _, child := range children(value) {
+		all = append(all, descendants(child)...)
+	}
+	return all
+}
+
+func children(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = v[key]
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+type childSelector struct{ name string }
+
+func (s childSelector) selectFrom(value interface{}) []interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		if child, ok := object[s.name]; ok {
+			return []interface{}{child}
+		}
+	}
+	return nil
+}
+
+func (s childSelector) definite() bool { return true }
+
+type indexSelector struct{ index int }
+
+func (s indexSelector) selectFrom(value interface{}) []interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	index := s.index
+	if index < 0 {
+		index += len(array)
+	}
+	if index < 0 || index >= len(array) {
+		return nil
+	}
+	return []interface{}{array[index]}
+}
+
+func (s indexSelector) definite() bool { return true }
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(value interface{}) []interface{} {
+	return children(value)
+}
+
+func (wildcardSelector) definite() bool { return false }
+
+type sliceSelector struct{ start, end, step *int }
+
+// selectFrom selects the elements of a slice of an array as RFC 9535
+// defines it, going backwards for negative steps.
+func (s sliceSelector) selectFrom(value interface{}) []interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	length := len(array)
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return nil
+	}
+	bound := func(index *int, defaultIndex, min, max int) int {
+		if index == nil {
+			return defaultIndex
+		}
+		i := *index
+		if i < 0 {
+			i += length
+		}
+		if i < min {
+			return min
+		}
+		if i > max {
+			return max
+		}
+		return i
+	}
+
+	var selected []interface{}
+	if step > 0 {
+		start, end := bound(s.start, 0, 0, length), bound(s.end, length, 0, length)
+		for i := start; i < end; i += step {
+			selected = append(selected, array[i])
+		}
+	} else {
+		start, end := bound(s.start, length-1, -1, length-1), bound(s.end, -1, -1, length-1)
+		for i := start; i > end; i += step {
+			selected = append(selected, array[i])
+		}
+	}
+	return selected
+}
+
+func (s sliceSelector) definite() bool { return false }
+
+// unionSelector selects the values selected by each of its selectors, in
+// turn.
+type unionSelector []jsonPathSelector
+
+func (s unionSelector) selectFrom(value interface{}) []interface{} {
+	var selected []interface{}
+	for _, selector := range s {
+		selected = append(selected, selector.selectFrom(value)...)
+	}
+	return selected
+}
+
+func (s unionSelector) definite() bool { return false }
+
+type filterSelector struct{ filter jsonPathFilter }
+
+func (s filterSelector) selectFrom(value interface{}) []interface{} {
+	var selected []interface{}
+	for _, child := range children(value) {
+		if s.filter.matches(child) {
+			selected = append(selected, child)
+		}
+	}
+	return selected
+}
+
+func (s filterSelector) definite() bool { return false }
+
+// jsonPathFilter is a boolean expression on the current value @.
+type jsonPathFilter interface {
+	matches(current interface{}) bool
+}
+
+type orFilter struct{ left, right jsonPathFilter }
+
+func (f orFilter) matches(current interface{}) bool {
+	return f.left.matches(current) || f.right.matches(current)
+}
+
+type andFilter struct{ left, right jsonPathFilter }
+
+func (f andFilter) matches(current interface{}) bool {
+	return f.left.matches(current) && f.right.matches(current)
+}
+
+type notFilter struct{ filter jsonPathFilter }
+
+func (f notFilter) matches(current interface{}) bool {
+	return !f.filter.matches(current)
+}
+
+// existsFilter matches if the relative path selects any value.
+type existsFilter struct{ relative []jsonPathStep }
+
+func (f existsFilter) matches(current interface{}) bool {
+	return len(selectSteps(f.relative, current)) > 0
+}
+
+// comparisonFilter compares two values as RFC 9535 does: a path selecting
+// no value only equals another selecting none, and values are only ordered
+// when both are numbers or both are strings.
+type comparisonFilter struct {
+	left, right jsonPathOperand
+	operator    string
+}
+
+func (f comparisonFilter) matches(current interface{}) bool {
+	left, leftOK := f.left.value(current)
+	right, rightOK := f.right.value(current)
+	equal := leftOK == rightOK && (!leftOK || jsonEqual(left, right))
+	less := func(a, b interface{}) bool {
+		cmp, ok := jsonCompare(a, b)
+		return leftOK && rightOK && ok && cmp < 0
+	}
+
+	switch f.operator {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	case "<":
+		return less(left, right)
+	case "<=":
+		return less(left, right) || equal
+	case ">":
+		return less(right, left)
+	case ">=":
+		return less(right, left) || equal
+	}
+	return false
+}
+
+// jsonPathOperand is either a literal, or a path relative to the current
+// value @, which must be definite to be compared.
+type jsonPathOperand struct {
+	literal  interface{}
+	relative []jsonPathStep
+	isPath   bool
+}
+
+// comparable returns true if the operand is a literal or a singular path.
+func (o jsonPathOperand) comparable() bool {
+	return !o.isPath || stepsAreDefinite(o.relative)
+}
+
+func (o jsonPathOperand) value(current interface{}) (interface{}, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	values := selectSteps(o.relative, current)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+func jsonNumber(value interface{}) (*big.Float, bool) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return nil, false
+	}
+	f, ok := new(big.Float).SetString(s)
+	return f, ok
+}
+
+// jsonEqual returns true if the values are equal, comparing numbers by
+// value and arrays and objects by their elements and members.
+func jsonEqual(left, right interface{}) bool {
+	if l, ok := jsonNumber(left); ok {
+		r, ok := jsonNumber(right)
+		return ok && l.Cmp(r) == 0
+	}
+	switch l := left.(type) {
+	case []interface{}:
+		r, ok := right.([]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !jsonEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		r, ok := right.(map[string]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for key, value := range l {
+			if other, ok := r[key]; !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case string, bool, nil:
+		return left == right
+	}
+	return false
+}
+
+// jsonCompare compares two numbers or two strings.
+func jsonCompare(left, right interface{}) (int, bool) {
+	if l, ok := jsonNumber(left); ok {
+		if r, ok := jsonNumber(right); ok {
+			return l.Cmp(r), true
+		}
+		return 0, false
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), true
+		}
+	}
+	return 0, false
+}
+
+type jsonPathParser struct {
+	input string
+	pos   int
+}
+
+func (p *jsonPathParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *jsonPathParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.input[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for !p.done() && strings.IndexByte(" \t\n\r", p.peek()) != -1 {
+		p.pos++
+	}
+}
+
+// parseSteps parses steps until the end of the input, or until the end of
+// a relative path inside a filter.
+func (p *jsonPathParser) parseSteps(relative bool) ([]jsonPathStep, bool, error) {
+	var steps []jsonPathStep
+	for !p.done() {
+		switch {
+		case p.consume(".."):
+			selector, err := p.parseDotSelector()
+			if err != nil {
+				return nil, false, err
+			}
+			if selector == nil {
+				var err error
+				if selector, err = p.parseBracketSelector(); err != nil {
+					return nil, false, err
+				}
+			}
+			steps = append(steps, jsonPathStep{recursive: true, selector: selector})
+		case !relative && p.consume(".length()"):
+			if !p.done() {
+				return nil, false, fmt.Errorf("length() must end the expression, at %d", p.pos)
+			}
+			return steps, true, nil
+		case p.consume("."):
+			selector, err := p.parseDotSelector()
+			if err != nil {
+				return nil, false, err
+			}
+			if selector == nil {
+				return nil, false, fmt.Errorf("expected a name at %d", p.pos)
+			}
+			steps = append(steps, jsonPathStep{selector: selector})
+		case p.peek() == '[':
+			selector, err := p.parseBracketSelector()
+			if err != nil {
+				return nil, false, err
+			}
+			steps = append(steps, jsonPathStep{selector: selector})
+		case relative:
+			return steps, false, nil
+		default:
+			return nil, false, fmt.Errorf("unexpected %q at %d", p.peek(), p.pos)
+		}
+	}
+	return steps, false, nil
+}
+
+// parseDotSelector parses the name or wildcard following a dot, returning
+// nil if a bracket follows instead.
+func (p *jsonPathParser) parseDotSelector() (jsonPathSelector, error) {
+	if p.consume("*") {
+		return wildcardSelector{}, nil
+	}
+	start := p.pos
+	if !p.done() && isJSONPathNameFirst(p.peek()) {
+		p.pos++
+		for !p.done() && (isJSONPathNameFirst(p.peek()) || isDigit(p.peek())) {
+			p.pos++
+		}
+	}
+	if p.pos == start {
+		if p.peek() == '[' {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("expected a name at %d", p.pos)
+	}
+	return childSelector{name: p.input[start:p.pos]}, nil
+}
+
+// isJSONPathNameFirst returns true if the byte can start a name following a
+// dot: a letter, _, or part of a non-ASCII character.
+func isJSONPathNameFirst(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || b >= 0x80
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// parseBracketSelector parses one or more selectors separated by commas
+// within brackets.
+func (p *jsonPathParser) parseBracketSelector() (jsonPathSelector, error) {
+	if !p.consume("[") {
+		return nil, fmt.Errorf("expected [ at %d", p.pos)
+	}
+
+	var union unionSelector
+	for {
+		p.skipSpaces()
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		union = append(union, selector)
+
+		p.skipSpaces()
+		if p.consume("]") {
+			break
+		}
+		if !p.consume(",") {
+			return nil, fmt.Errorf("expected ] at %d", p.pos)
+		}
+	}
+	if len(union) == 1 {
+		return union[0], nil
+	}
+	return union, nil
+}
+
+func (p *jsonPathParser) parseSelector() (jsonPathSelector, error) {
+	switch {
+	case p.consume("*"):
+		return wildcardSelector{}, nil
+	case p.consume("?"):
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{filter: filter}, nil
+	case p.peek() == '\'' || p.peek() == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return childSelector{name: name}, nil
+	}
+
+	start, err := p.parseOptionalInt()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.consume(":") {
+		if start == nil {
+			return nil, fmt.Errorf("expected a selector at %d", p.pos)
+		}
+		return indexSelector{index: *start}, nil
+	}
+	p.skipSpaces()
+	end, err := p.parseOptionalInt()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	var step *int
+	if p.consume(":") {
+		p.skipSpaces()
+		if step, err = p.parseOptionalInt(); err != nil {
+			return nil, err
+		}
+	}
+	return sliceSelector{start: start, end: end, step: step}, nil
+}
+
+// parseOptionalInt parses an integer without leading zeros, if there is
+// one.
+func (p *jsonPathParser) parseOptionalInt() (*int, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for !p.done() && isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, nil
+	}
+	text := p.input[start:p.pos]
+	if p.pos == digits || (p.input[digits] == '0' && text != "0") {
+		return nil, fmt.Errorf("invalid integer %q at %d", text, start)
+	}
+	i, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer %q at %d", text, start)
+	}
+	return &i, nil
+}
+
+// parseString parses a string in single or double quotes, with the escape
+// sequences of JSON strings. Only the quote the string is in can be
+// escaped.
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var sb strings.Builder
+	for !p.done() {
+		b := p.peek()
+		p.pos++
+		switch {
+		case b == quote:
+			return sb.String(), nil
+		case b < 0x20:
+			return "", fmt.Errorf("control character in string at %d", p.pos-1)
+		case b != '\\':
+			sb.WriteByte(b)
+		case p.done():
+		case p.consume("u"):
+			r, err := p.parseUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		default:
+			escaped, ok := jsonPathEscapes[p.peek()]
+			if !ok && p.peek() != quote {
+				return "", fmt.Errorf("invalid escape sequence at %d", p.pos-1)
+			}
+			if !ok {
+				escaped = quote
+			}
+			sb.WriteByte(escaped)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string at %d", p.pos)
+}
+
+var jsonPathEscapes = map[byte]byte{
+	'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', '/': '/', '\\': '\\',
+}
+
+// parseUnicodeEscape parses the hex digits of a \u escape sequence, and of
+// the one following it for a surrogate pair.
+func (p *jsonPathParser) parseUnicodeEscape() (rune, error) {
+	hex := func() (rune, error) {
+		if p.pos+4 > len(p.input) {
+			return 0, fmt.Errorf("invalid unicode escape sequence at %d", p.pos)
+		}
+		n, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid unicode escape sequence at %d", p.pos)
+		}
+		p.pos += 4
+		return rune(n), nil
+	}
+
+	r, err := hex()
+	if err != nil || !utf16.IsSurrogate(r) {
+		return r, err
+	}
+	if r < 0xdc00 && p.consume("\\u") {
+		low, err := hex()
+		if err != nil {
+			return 0, err
+		}
+		if pair := utf16.DecodeRune(r, low); pair != '\uFFFD' {
+			return pair, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid surrogate pair at %d", p.pos)
+}
+
+func (p *jsonPathParser) parseOr() (jsonPathFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left: left, right: right}
+	}
+}
+
+func (p *jsonPathParser) parseAnd() (jsonPathFilter, error) {
+	left, err := p.parseBasicFilter()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseBasicFilter()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left: left, right: right}
+	}
+}
+
+var jsonPathOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseBasicFilter parses a comparison, or an existence test or filter in
+// parentheses, either of which may be negated by !.
+func (p *jsonPathParser) parseBasicFilter() (jsonPathFilter, error) {
+	p.skipSpaces()
+	negated := p.consume("!")
+	p.skipSpaces()
+	not := func(filter jsonPathFilter) jsonPathFilter {
+		if negated {
+			return notFilter{filter: filter}
+		}
+		return filter
+	}
+
+	if p.consume("(") {
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected ) at %d", p.pos)
+		}
+		return not(filter), nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, operator := range jsonPathOperators {
+		if !negated && p.consume(operator) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if !left.comparable() || !right.comparable() {
+				return nil, fmt.Errorf("compared paths must be singular, at %d", p.pos)
+			}
+			return comparisonFilter{left: left, right: right, operator: operator}, nil
+		}
+	}
+	if !left.isPath {
+		return nil, fmt.Errorf("expected a comparison at %d", p.pos)
+	}
+	return not(existsFilter{relative: left.relative}), nil
+}
+
+func (p *jsonPathParser) parseOperand() (jsonPathOperand, error) {
+	p.skipSpaces()
+	switch {
+	case p.consume("@"):
+		steps, _, err := p.parseSteps(true)
+		if err != nil {
+			return jsonPathOperand{}, err
+		}
+		return jsonPathOperand{relative: steps, isPath: true}, nil
+	case p.peek() == '\'' || p.peek() == '"':
+		s, err := p.parseString()
+		return jsonPathOperand{literal: s}, err
+	case p.consume("true"):
+		return jsonPathOperand{literal: true}, nil
+	case p.consume("false"):
+		return jsonPathOperand{literal: false}, nil
+	case p.consume("null"):
+		return jsonPathOperand{literal: nil}, nil
+	}
+
+	start := p.pos
+	for !p.done() && strings.IndexByte("-+.0123456789eE", p.peek()) != -1 {
+		p.pos++
+	}
+	if p.pos == start {
+		return jsonPathOperand{}, fmt.Errorf("expected a value at %d", p.pos)
+	}
+	// Number literals are written as in JSON
+	number := json.Number(p.input[start:p.pos])
+	if !json.Valid([]byte(number)) {
+		return jsonPathOperand{}, fmt.Errorf("invalid number %q", number)
+	}
+	return jsonPathOperand{literal: number}, nil
+}
@@ -0,0 +1,265 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// performJSONPath returns the result of the JSONPath expression on the
+// document, or its error.
+func performJSONPath(t *testing.T, document, path string) (string, error) {
+	t.Helper()
+	adapter := adapters.JSONParse{Path: adapters.JSONPath{path}}
+	output := adapter.Perform(cltest.NewRunInputWithResult(document), nil)
+	if output.HasError() {
+		assert.Equal(t, models.RunStatusErrored, output.Status())
+		return "", output.Error()
+	}
+	return output.Result().Raw, nil
+}
+
+// The examples of RFC 9535, with objects iterated in the order of their
+// sorted names, and the results of singular queries returned as the value
+// selected or an error when there is none.
+func TestJSONPathExpression_RFC9535Examples(t *testing.T) {
+	t.Parallel()
+
+	// The example document of the introduction
+	bookstore := `{"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	}}`
+	book := []string{
+		`{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95}`,
+		`{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99}`,
+		`{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99}`,
+		`{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}`,
+	}
+	names := `{"o": {"j j": {"k.k": 3}}, "'": {"@": 2}}`
+	wildcards := `{"o": {"j": 1, "k": 2}, "a": [5, 3]}`
+	letters := `["a", "b", "c", "d", "e", "f", "g"]`
+	filters := `{
+		"a": [3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}],
+		"o": {"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}},
+		"e": "f"
+	}`
+	descendants := `{"o": {"j": 1, "k": 2}, "a": [5, 3, [{"j": 4}, {"k": 6}]]}`
+	nulls := `{"a": null, "b": [null], "c": [{}], "null": 1}`
+
+	tests := []struct {
+		name     string
+		document string
+		path     string
+		want     string
+	}{
+		// Introduction
+		{"authors of all books", bookstore, `$.store.book[*].author`, `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`},
+		{"all authors", bookstore, `$..author`, `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`},
+		{"all things in store", bookstore, `$.store.*`, `[{"color": "red", "price": 399}, [` + book[0] + `,` + book[1] + `,` + book[2] + `,` + book[3] + `]]`},
+		{"prices of everything in store", bookstore, `$.store..price`, `[399, 8.95, 12.99, 8.99, 22.99]`},
+		{"third book", bookstore, `$..book[2]`, `[` + book[2] + `]`},
+		{"third book's author", bookstore, `$..book[2].author`, `["Herman Melville"]`},
+		{"empty result", bookstore, `$..book[2].publisher`, `[]`},
+		{"last book", bookstore, `$..book[-1]`, `[` + book[3] + `]`},
+		{"first two books by union", bookstore, `$..book[0,1]`, `[` + book[0] + `,` + book[1] + `]`},
+		{"first two books by slice", bookstore, `$..book[:2]`, `[` + book[0] + `,` + book[1] + `]`},
+		{"books with isbn", bookstore, `$..book[?@.isbn]`, `[` + book[2] + `,` + book[3] + `]`},
+		{"books cheaper than 10", bookstore, `$..book[?@.price<10]`, `[` + book[0] + `,` + book[2] + `]`},
+		{"all member values and array elements", bookstore, `$..*.length()`, `27`},
+
+		// Name selector
+		{"name with space", names, `$.o['j j']`, `{"k.k": 3}`},
+		{"names with space and dot", names, `$.o['j j']['k.k']`, `3`},
+		{"double quoted names", names, `$.o["j j"]["k.k"]`, `3`},
+		{"names of quotes", names, `$["'"]["@"]`, `2`},
+
+		// Wildcard selector
+		{"wildcard of root", wildcards, `$[*]`, `[[5, 3], {"j": 1, "k": 2}]`},
+		{"wildcard of object", wildcards, `$.o[*]`, `[1, 2]`},
+		{"union of wildcards", wildcards, `$.o[*, *]`, `[1, 2, 1, 2]`},
+		{"wildcard of array", wildcards, `$.a[*]`, `[5, 3]`},
+
+		// Index selector
+		{"index", `["a", "b"]`, `$[1]`, `"b"`},
+		{"negative index", `["a", "b"]`, `$[-2]`, `"a"`},
+
+		// Array slice selector
+		{"slice with default step", letters, `$[1:3]`, `["b", "c"]`},
+		{"slice with no end", letters, `$[5:]`, `["f", "g"]`},
+		{"slice with step 2", letters, `$[1:5:2]`, `["b", "d"]`},
+		{"slice with negative step", letters, `$[5:1:-2]`, `["f", "d"]`},
+		{"slice in reverse order", letters, `$[::-1]`, `["g", "f", "e", "d", "c", "b", "a"]`},
+
+		// Filter selector
+		{"member value comparison", filters, `$.a[?@.b == 'kilo']`, `[{"b": "kilo"}]`},
+		{"parenthesized filter", filters, `$.a[?(@.b == 'kilo')]`, `[{"b": "kilo"}]`},
+		{"array value comparison", filters, `$.a[?@>3.5]`, `[5, 4, 6]`},
+		{"array value existence", filters, `$.a[?@.b]`, `[{"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]`},
+		{"existence of non-singular queries", filters, `$[?@.*]`, `[[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}], {"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}}]`},
+		{"nested filters", filters, `$[?@[?@.b]]`, `[[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]]`},
+		{"non-deterministic ordering", filters, `$.o[?@<3, ?@<3]`, `[1, 2, 1, 2]`},
+		{"array value logical or", filters, `$.a[?@<2 || @.b == "k"]`, `[1, {"b": "k"}]`},
+		{"object value logical and", filters, `$.o[?@>1 && @<4]`, `[2, 3]`},
+		{"object value logical or", filters, `$.o[?@.u || @.x]`, `[{"u": 6}]`},
+		{"comparison of the current value", filters, `$.a[?@ == @]`, `[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]`},
+
+		// Descendant segment
+		{"object values", descendants, `$..j`, `[4, 1]`},
+		{"array values", descendants, `$..[0]`, `[5, {"j": 4}]`},
+		{"all values", descendants, `$..[*]`, `[[5, 3, [{"j": 4}, {"k": 6}]], {"j": 1, "k": 2}, 5, 3, [{"j": 4}, {"k": 6}], {"j": 4}, {"k": 6}, 4, 6, 1, 2]`},
+		{"all values by dot", descendants, `$..*`, `[[5, 3, [{"j": 4}, {"k": 6}]], {"j": 1, "k": 2}, 5, 3, [{"j": 4}, {"k": 6}], {"j": 4}, {"k": 6}, 4, 6, 1, 2]`},
+		{"input value is visited", descendants, `$..o`, `[{"j": 1, "k": 2}]`},
+		{"non-deterministic ordering of descendants", descendants, `$.o..[*, *]`, `[1, 2, 1, 2]`},
+		{"multiple segments", descendants, `$.a..[0, 1]`, `[5, 3, {"j": 4}, {"k": 6}]`},
+
+		// Semantics of null
+		{"object value", nulls, `$.a`, `null`},
+		{"null used as array", nulls, `$.a[0]`, ``},
+		{"null used as object", nulls, `$.a.d`, ``},
+		{"array value", nulls, `$.b[0]`, `null`},
+		{"array value by wildcard", nulls, `$.b[*]`, `[null]`},
+		{"existence", nulls, `$.b[?@]`, `[null]`},
+		{"comparison", nulls, `$.b[?@==null]`, `[null]`},
+		{"comparison with missing value", nulls, `$.c[?@.d==null]`, `[]`},
+		{"null string", nulls, `$.null`, `1`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			result, err := performJSONPath(t, test.document, test.path)
+			if test.want == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, result)
+		})
+	}
+}
+
+// The comparison examples of RFC 9535, with paths from the root $ relative
+// to the current value @ instead, in a filter selecting the document from an
+// array when the comparison is true.
+func TestJSONPathExpression_RFC9535Comparisons(t *testing.T) {
+	t.Parallel()
+
+	document := `[{"obj": {"x": "y"}, "arr": [2, 3]}]`
+	tests := []struct {
+		comparison string
+		want       bool
+	}{
+		{`@.absent1 == @.absent2`, true},
+		{`@.absent1 <= @.absent2`, true},
+		{`@.absent == 'g'`, false},
+		{`@.absent1 != @.absent2`, false},
+		{`@.absent != 'g'`, true},
+		{`1 <= 2`, true},
+		{`1 > 2`, false},
+		{`13 == '13'`, false},
+		{`'a' <= 'b'`, true},
+		{`'a' > 'b'`, false},
+		{`@.obj == @.arr`, false},
+		{`@.obj != @.arr`, true},
+		{`@.obj == @.obj`, true},
+		{`@.obj != @.obj`, false},
+		{`@.arr == @.arr`, true},
+		{`@.arr != @.arr`, false},
+		{`@.obj == 17`, false},
+		{`@.obj != 17`, true},
+		{`@.obj <= @.arr`, false},
+		{`@.obj < @.arr`, false},
+		{`@.obj <= @.obj`, true},
+		{`@.arr <= @.arr`, true},
+		{`1 <= @.arr`, false},
+		{`1 >= @.arr`, false},
+		{`1 > @.arr`, false},
+		{`1 < @.arr`, false},
+		{`true <= true`, true},
+		{`true > true`, false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.comparison, func(t *testing.T) {
+			result, err := performJSONPath(t, document, `$[?`+test.comparison+`]`)
+			require.NoError(t, err)
+			if test.want {
+				assert.JSONEq(t, document, result)
+			} else {
+				assert.JSONEq(t, `[]`, result)
+			}
+		})
+	}
+}
+
+func TestJSONPathExpression_Strings(t *testing.T) {
+	t.Parallel()
+
+	document := `{"☺": 1, "a\nb": 2, "'\"": 3, "𝄞": 4}`
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"unicode escape", `$['\u263a']`, `1`},
+		{"escape sequence", `$["a\nb"]`, `2`},
+		{"escaped single quote", `$['\'"']`, `3`},
+		{"escaped double quote", `$["'\""]`, `3`},
+		{"surrogate pair", `$["\uD834\uDD1E"]`, `4`},
+		{"non-ASCII name", `$.☺`, `1`},
+		{"escaped double quote in single quotes", `$['\"']`, ``},
+		{"invalid escape", `$['\a']`, ``},
+		{"lone surrogate", `$['\uD834']`, ``},
+		{"control character", "$['a\nb']", ``},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			result, err := performJSONPath(t, document, test.path)
+			if test.want == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, result)
+		})
+	}
+}
+
+// Expressions which are not valid RFC 9535 queries, or use what is not
+// supported of it.
+func TestJSONPathExpression_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		`$.1a`,
+		`$.a-b`,
+		`$[01]`,
+		`$[-0]`,
+		`$[?@.a == 01]`,
+		`$[?@.* == 1]`,
+		`$[?!@.a == 1]`,
+		`$[?1]`,
+		`$[?count(@.*) > 1]`,
+		`$[?@.a == $.b]`,
+		`$ .a`,
+	}
+
+	for _, path := range tests {
+		_, err := performJSONPath(t, `{"a": [1]}`, path)
+		assert.Error(t, err, path)
+	}
+}
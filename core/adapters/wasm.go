@@ -4,14 +4,24 @@ package adapters
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
 )
 
-// Wasm represents a wasm binary encoded as base64 or wasm encoded as text (a lisp like language).
+// Wasm runs a WebAssembly module registered with the node, by the name
+// given as its module parameter.
+//
+// The module is given the data of its input as JSON, and must return JSON
+// with either the result of the task as "result", or an error message as
+// "error". See the services/wasm package for the full ABI.
 type Wasm struct {
-	WasmT string `json:"wasmt"`
+	Module models.TaskType `json:"module"`
 }
 
 // TaskType returns the type of Adapter.
@@ -19,8 +29,61 @@ func (wasm *Wasm) TaskType() models.TaskType {
 	return TaskTypeWasm
 }
 
-// Perform ships the wasm representation to the SGX enclave where it is evaluated.
-func (wasm *Wasm) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
-	err := fmt.Errorf("Wasm is not supported without SGX")
-	return models.NewRunOutputError(err)
+// Perform runs the module on the data of the input, and returns its result.
+func (wasm *Wasm) Perform(input models.RunInput, store *store.Store) models.RunOutput {
+	module, err := store.FindWasmModule(wasm.Module)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return models.NewRunOutputError(fmt.Errorf("wasm module %s not found", wasm.Module))
+	} else if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	data, err := input.Data().MarshalJSON()
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	host := &wasmHost{module: module.Name, httpConfig: defaultHTTPConfig(store)}
+	output, err := store.WasmRuntime.Run(module.Wasm, data, host)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	result, err := models.ParseJSON(output)
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrapf(err, "wasm module %s returned invalid output", wasm.Module))
+	}
+	if message := result.Get("error"); message.Exists() {
+		return models.NewRunOutputError(errors.New(message.String()))
+	}
+	if !result.Get("result").Exists() {
+		return models.NewRunOutputError(fmt.Errorf("wasm module %s returned neither a result nor an error", wasm.Module))
+	}
+	return models.NewRunOutputCompleteWithResult(result.Get("result").Value())
+}
+
+// wasmHost offers the node's logger and HTTP client to a running module.
+type wasmHost struct {
+	module     models.TaskType
+	httpConfig HTTPRequestConfig
+}
+
+func (h *wasmHost) Log(message string) {
+	logger.Infow(fmt.Sprintf("wasm module %s: %s", h.module, message), "module", h.module)
+}
+
+// HTTPGet fetches the URL with the restrictions of the httpget adapter, so
+// that modules cannot reach the node's private network.
+func (h *wasmHost) HTTPGet(url string) ([]byte, error) {
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, statusCode, err := withRetry(newHTTPClient(h.httpConfig), request, h.httpConfig)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode >= 400 {
+		return nil, &HTTPResponseError{statusCode, string(body)}
+	}
+	return body, nil
 }
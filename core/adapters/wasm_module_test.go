@@ -0,0 +1,64 @@
+// +build !sgx_enclave
+
+package adapters_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoWasmModule returns its input as its output.
+const echoWasmModule = "AGFzbQEAAAABEQNgAX8Bf2ACf38BfmACf38AAwMCAAEFAwEAAQYHAX8BQYAICwcfAwZtZW1vcnkCAAhhbGxvY2F0ZQAAB3BlcmZvcm0AAQoaAgsAIwAjACAAaiQACwwAIACtQiCGIAGthAs="
+
+func TestWasm_Perform_RegisteredModule(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	binary, err := base64.StdEncoding.DecodeString(echoWasmModule)
+	require.NoError(t, err)
+	hash, err := store.WasmRuntime.Compile(binary)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateWasmModule(&models.WasmModule{
+		Name: models.MustNewTaskType("echo"),
+		Hash: hash,
+		Wasm: binary,
+	}))
+
+	tests := []struct {
+		name      string
+		module    string
+		input     string
+		want      interface{}
+		wantError string
+	}{
+		{"result", "echo", `{"result":"3.14"}`, "3.14", ""},
+		{"error", "echo", `{"error":"price unavailable"}`, nil, "price unavailable"},
+		{"no result", "echo", `{"value":1}`, nil, "neither a result nor an error"},
+		{"unknown module", "missing", `{"result":1}`, nil, "wasm module missing not found"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Wasm{Module: models.MustNewTaskType(test.module)}
+			input := cltest.NewRunInput(cltest.JSONFromString(t, test.input))
+			result := adapter.Perform(input, store)
+			if test.wantError != "" {
+				require.Error(t, result.Error())
+				assert.Contains(t, result.Error().Error(), test.wantError)
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().Value())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// WebSocket subscribes to a websocket and returns the first message pushed
+// over it which matches the filter, for providers which only push their
+// prices. Connections are pooled across runs, so that runs of jobs
+// subscribing to the same feed share a connection.
+type WebSocket struct {
+	URL     models.WebURL `json:"url"`
+	Headers http.Header   `json:"headers"`
+	// Subscribe is sent once connected. A JSON string is sent as its text,
+	// while other values are sent as JSON.
+	Subscribe json.RawMessage `json:"subscribe"`
+	// Filter holds the values which the fields at its paths must have in a
+	// message for it to be returned, such as {"type": "ticker"}.
+	Filter  map[string]interface{} `json:"filter"`
+	Timeout models.Duration        `json:"timeout"`
+
+	AllowUnrestrictedNetworkAccess bool `json:"-"`
+}
+
+// TaskType returns the type of Adapter.
+func (ws *WebSocket) TaskType() models.TaskType {
+	return TaskTypeWebSocket
+}
+
+// Perform waits for the next message matching the filter, within the
+// timeout or the default HTTP timeout, and returns it as the result.
+func (ws *WebSocket) Perform(input models.RunInput, store *store.Store) models.RunOutput {
+	request, err := ws.request(store.Config.DefaultHTTPLimit())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	timeout := ws.Timeout.Duration()
+	if timeout <= 0 {
+		timeout = store.Config.DefaultHTTPTimeout().Duration()
+	}
+	message, err := store.WebSockets.Receive(request, ws.matches, timeout)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(string(message))
+}
+
+func (ws *WebSocket) request(readLimit int64) (store.WebSocketRequest, error) {
+	request := store.WebSocketRequest{
+		URL:       ws.URL.String(),
+		Header:    ws.Headers,
+		ReadLimit: readLimit,
+	}
+	if request.URL == "" {
+		return request, errors.New("websocket url is required")
+	}
+	if !ws.AllowUnrestrictedNetworkAccess {
+		request.DialContext = restrictedDialContext
+	}
+
+	if len(ws.Subscribe) > 0 && string(ws.Subscribe) != "null" {
+		if utils.IsQuoted(ws.Subscribe) {
+			var text string
+			if err := json.Unmarshal(ws.Subscribe, &text); err != nil {
+				return request, errors.Wrap(err, "invalid websocket subscribe message")
+			}
+			request.Subscribe = []byte(text)
+		} else {
+			request.Subscribe = ws.Subscribe
+		}
+	}
+	return request, nil
+}
+
+// matches returns true if the message has the values of the filter at its
+// paths. Every message matches an empty filter.
+func (ws *WebSocket) matches(message []byte) bool {
+	if len(ws.Filter) == 0 {
+		return true
+	}
+	if !gjson.ValidBytes(message) {
+		return false
+	}
+	for path, want := range ws.Filter {
+		got := gjson.GetBytes(message, path)
+		if !got.Exists() || !reflect.DeepEqual(got.Value(), want) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocket_Perform(t *testing.T) {
+	t.Parallel()
+
+	upgrader := websocket.Upgrader{}
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, subscribe, err := conn.ReadMessage()
+		require.NoError(t, err)
+		if string(subscribe) != "subscribe ETH-USD" && string(subscribe) != `{"op":"subscribe","pair":"ETH-USD"}` {
+			t.Errorf("unexpected subscribe message %s", subscribe)
+			return
+		}
+		for _, message := range []string{
+			`{"type":"subscribed"}`,
+			`not json`,
+			`{"type":"ticker","pair":"BTC-USD","price":"9000"}`,
+			`{"type":"ticker","pair":"ETH-USD","price":"230.5","seq":7}`,
+		} {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(message)))
+		}
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer mock.Close()
+	url := "ws" + strings.TrimPrefix(mock.URL, "http")
+
+	tests := []struct {
+		name      string
+		subscribe string
+		filter    string
+		want      string
+	}{
+		{"text subscribe", `"subscribe ETH-USD"`, `{"pair":"ETH-USD"}`, `{"type":"ticker","pair":"ETH-USD","price":"230.5","seq":7}`},
+		{"json subscribe", `{"op":"subscribe","pair":"ETH-USD"}`, `{"type":"ticker","seq":7}`, `{"type":"ticker","pair":"ETH-USD","price":"230.5","seq":7}`},
+		{"no filter", `"subscribe ETH-USD"`, `{}`, `{"type":"subscribed"}`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ws := adapters.WebSocket{AllowUnrestrictedNetworkAccess: true}
+			params := fmt.Sprintf(`{"url":%q,"headers":{"X-Api-Key":["key"]},"subscribe":%s,"filter":%s,"timeout":"5s"}`,
+				url, test.subscribe, test.filter)
+			require.NoError(t, json.Unmarshal([]byte(params), &ws))
+
+			result := ws.Perform(cltest.NewRunInputWithResult("input"), leanStore())
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestWebSocket_Perform_Timeout(t *testing.T) {
+	t.Parallel()
+
+	upgrader := websocket.Upgrader{}
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer mock.Close()
+
+	ws := adapters.WebSocket{
+		URL:                            cltest.WebURL(t, "ws"+strings.TrimPrefix(mock.URL, "http")),
+		Timeout:                        models.MustMakeDuration(100 * time.Millisecond),
+		AllowUnrestrictedNetworkAccess: true,
+	}
+	result := ws.Perform(cltest.NewRunInputWithResult("input"), leanStore())
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "timeout")
+}
+
+func TestWebSocket_Perform_RestrictedNetwork(t *testing.T) {
+	t.Parallel()
+
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer mock.Close()
+
+	ws := adapters.WebSocket{URL: cltest.WebURL(t, "ws"+strings.TrimPrefix(mock.URL, "http"))}
+	result := ws.Perform(cltest.NewRunInputWithResult("input"), leanStore())
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "disallowed IP")
+}
@@ -146,6 +146,59 @@ func NewApp(client *Client) *cli.App {
 			},
 		},
 
+		{
+			Name:  "secrets",
+			Usage: "Commands for the secrets referenced in task params as {{secret:NAME}}",
+			Subcommands: []cli.Command{
+				{
+					Name:   "create",
+					Usage:  "Encrypt and save a new secret",
+					Action: client.CreateSecret,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "value",
+							Usage: "value of the secret",
+						},
+						cli.StringFlag{
+							Name:  "file, f",
+							Usage: "text file holding the value of the secret",
+						},
+					},
+				},
+				{
+					Name:   "update",
+					Usage:  "Replace the value of a secret",
+					Action: client.UpdateSecret,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "value",
+							Usage: "new value of the secret",
+						},
+						cli.StringFlag{
+							Name:  "file, f",
+							Usage: "text file holding the new value of the secret",
+						},
+					},
+				},
+				{
+					Name:   "destroy",
+					Usage:  "Remove a secret",
+					Action: client.RemoveSecret,
+				},
+				{
+					Name:   "list",
+					Usage:  "List the names of all secrets",
+					Action: client.IndexSecrets,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+					},
+				},
+			},
+		},
+
 		{
 			Name:  "config",
 			Usage: "Commands for the node's configuration",
@@ -180,6 +233,11 @@ func NewApp(client *Client) *cli.App {
 			Name:  "jobs",
 			Usage: "Commands for managing Jobs",
 			Subcommands: []cli.Command{
+				{
+					Name:   "approveround",
+					Usage:  "Approve an answer held by the flux monitor's outlier guard, given the Job ID and the round event ID",
+					Action: client.ApproveFluxRound,
+				},
 				{
 					Name:   "archive",
 					Usage:  "Archive a Job and all its associated Runs",
@@ -201,10 +259,55 @@ func NewApp(client *Client) *cli.App {
 						},
 					},
 				},
+				{
+					Name:   "rounds",
+					Usage:  "List the flux monitor's decisions about the rounds of a Job, newest first",
+					Action: client.IndexFluxRounds,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+						cli.StringFlag{
+							Name:  "aggregator",
+							Usage: "only list the decisions about the rounds of this aggregator",
+						},
+						cli.UintFlag{
+							Name:  "round",
+							Usage: "only list the decisions about this round",
+						},
+					},
+				},
 				{
 					Name:   "show",
 					Usage:  "Show a specific Job's details",
 					Action: client.ShowJobSpec,
+					Flags: []cli.Flag{
+						cli.UintFlag{
+							Name:  "diff",
+							Usage: "show the differences between this version of the Job and its latest version",
+						},
+						cli.UintFlag{
+							Name:  "to",
+							Usage: "compare the version given by --diff with this version instead of the latest",
+						},
+					},
+				},
+				{
+					Name:   "simulate",
+					Usage:  "Perform the tasks of a Job Specification JSON without creating the Job or sending any transactions",
+					Action: client.SimulateJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "input",
+							Usage: "the JSON input of the simulated run",
+						},
+					},
+				},
+				{
+					Name:   "update",
+					Usage:  "Create a new version of a Job from a Job Specification JSON, keeping its ID",
+					Action: client.UpdateJobSpec,
 				},
 			},
 		},
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -144,6 +145,9 @@ func (cli *Client) ShowJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
 		return cli.errorOut(errors.New("Must pass the job id to be shown"))
 	}
+	if c.IsSet("diff") {
+		return cli.diffJobSpecVersions(c)
+	}
 	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
@@ -153,11 +157,84 @@ func (cli *Client) ShowJobSpec(c *clipkg.Context) error {
 	return cli.renderAPIResponse(resp, &job)
 }
 
+// diffJobSpecVersions shows the differences between the version of a job
+// given by --diff and the version given by --to, or its latest version.
+func (cli *Client) diffJobSpecVersions(c *clipkg.Context) error {
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First() + "/versions")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var versions []models.JobSpecVersion
+	if err = cli.deserializeAPIResponse(resp, &versions, &jsonapi.Links{}); err != nil {
+		return cli.errorOut(err)
+	}
+	if len(versions) == 0 {
+		return cli.errorOut(errors.New("job has no recorded versions"))
+	}
+
+	findVersion := func(version uint32) (models.JobSpecVersion, error) {
+		for _, v := range versions {
+			if v.Version == version {
+				return v, nil
+			}
+		}
+		return models.JobSpecVersion{}, fmt.Errorf("job has no version %d", version)
+	}
+	from, err := findVersion(uint32(c.Uint("diff")))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	to := versions[len(versions)-1]
+	if c.IsSet("to") {
+		if to, err = findVersion(uint32(c.Uint("to"))); err != nil {
+			return cli.errorOut(err)
+		}
+	}
+
+	diff := presenters.NewJobSpecDiff(from, to)
+	return cli.errorOut(cli.Render(&diff))
+}
+
 // IndexJobSpecs returns all job specs.
 func (cli *Client) IndexJobSpecs(c *clipkg.Context) error {
 	return cli.getPage("/v2/specs", c.Int("page"), &[]models.JobSpec{})
 }
 
+// IndexFluxRounds lists the decisions of the flux monitor about the rounds
+// of a Job, newest first.
+func (cli *Client) IndexFluxRounds(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id to be shown"))
+	}
+	query := url.Values{}
+	if aggregator := c.String("aggregator"); aggregator != "" {
+		query.Set("aggregator", aggregator)
+	}
+	if round := c.Uint("round"); round > 0 {
+		query.Set("roundId", strconv.FormatUint(uint64(round), 10))
+	}
+	uri := "/v2/specs/" + c.Args().First() + "/flux_rounds?" + query.Encode()
+	return cli.getPage(uri, c.Int("page"), &[]models.FluxMonitorRoundEvent{})
+}
+
+// ApproveFluxRound approves an answer held by the outlier guard of the flux
+// monitor.
+func (cli *Client) ApproveFluxRound(c *clipkg.Context) error {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the job id and the round event id to approve"))
+	}
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().Get(0)+"/flux_rounds/"+c.Args().Get(1)+"/approve", nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var event models.FluxMonitorRoundEvent
+	return cli.renderAPIResponse(resp, &event)
+}
+
 // CreateJobSpec creates a JobSpec based on JSON input
 func (cli *Client) CreateJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
@@ -179,6 +256,66 @@ func (cli *Client) CreateJobSpec(c *clipkg.Context) error {
 	return cli.renderAPIResponse(resp, &js)
 }
 
+// UpdateJobSpec saves the JobSpec given as JSON input as a new version of
+// an existing job.
+func (cli *Client) UpdateJobSpec(c *clipkg.Context) error {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the job id and JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Patch("/v2/specs/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// SimulateJobSpec performs the tasks of the JobSpec given as JSON input with
+// the input given by --input, without creating the job or sending any
+// transactions.
+func (cli *Client) SimulateJobSpec(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	spec, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	input := json.RawMessage("{}")
+	if c.IsSet("input") {
+		if !gjson.Valid(c.String("input")) {
+			return cli.errorOut(errors.New("--input must be valid JSON"))
+		}
+		input = json.RawMessage(c.String("input"))
+	}
+
+	request, err := json.Marshal(map[string]json.RawMessage{
+		"spec":  json.RawMessage(spec.Bytes()),
+		"input": input,
+	})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/specs/simulate", bytes.NewBuffer(request))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var simulation presenters.JobSimulation
+	return cli.renderAPIResponse(resp, &simulation)
+}
+
 // ArchiveJobSpec soft deletes a job and its associated runs.
 func (cli *Client) ArchiveJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
@@ -299,6 +436,87 @@ func (cli *Client) RemoveBridge(c *clipkg.Context) error {
 	return cli.renderAPIResponse(resp, &bridge)
 }
 
+// CreateSecret encrypts and saves a new secret, read from the value or
+// file flags.
+func (cli *Client) CreateSecret(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the secret to be created"))
+	}
+	buf, err := secretRequestBuffer(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	resp, err := cli.HTTP.Post("/v2/secrets", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+	var secret models.Secret
+	return cli.renderAPIResponse(resp, &secret)
+}
+
+// UpdateSecret replaces the value of a secret with the one read from the
+// value or file flags.
+func (cli *Client) UpdateSecret(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the secret to be updated"))
+	}
+	buf, err := secretRequestBuffer(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	resp, err := cli.HTTP.Patch("/v2/secrets/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+	var secret models.Secret
+	return cli.renderAPIResponse(resp, &secret)
+}
+
+// IndexSecrets lists the names of all secrets.
+func (cli *Client) IndexSecrets(c *clipkg.Context) error {
+	return cli.getPage("/v2/secrets", c.Int("page"), &[]models.Secret{})
+}
+
+// RemoveSecret removes a specific secret by name.
+func (cli *Client) RemoveSecret(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the secret to be removed"))
+	}
+	resp, err := cli.HTTP.Delete("/v2/secrets/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+	var secret models.Secret
+	return cli.renderAPIResponse(resp, &secret)
+}
+
+func secretRequestBuffer(c *clipkg.Context) (*bytes.Buffer, error) {
+	value := c.String("value")
+	if file := c.String("file"); file != "" {
+		if value != "" {
+			return nil, errors.New("Must pass either a value or a file, not both")
+		}
+		dat, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		value = strings.TrimSpace(string(dat))
+	}
+	if value == "" {
+		return nil, errors.New("Must pass the value of the secret with --value or --file")
+	}
+
+	request := models.SecretRequest{Name: c.Args().First(), Value: value}
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(b), nil
+}
+
 // RemoteLogin creates a cookie session to run remote commands.
 func (cli *Client) RemoteLogin(c *clipkg.Context) error {
 	sessionRequest, err := cli.buildSessionRequest(c.String("file"))
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"math/big"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -63,6 +64,72 @@ func TestClient_IndexJobSpecs(t *testing.T) {
 	assert.Equal(t, j1.ID, jobs[0].ID)
 }
 
+func TestClient_IndexFluxRounds(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	for round := uint32(1); round <= 2; round++ {
+		require.NoError(t, app.Store.CreateFluxMonitorRoundEvent(&models.FluxMonitorRoundEvent{
+			JobSpecID: j.ID,
+			RoundID:   round,
+			Trigger:   models.FluxMonitorTriggerPoll,
+			Outcome:   models.FluxMonitorOutcomeBelowThreshold,
+			CreatedAt: time.Now(),
+		}))
+	}
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Uint("round", 2, "")
+	set.Parse([]string{j.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.IndexFluxRounds(c))
+	require.Len(t, r.Renders, 1)
+	rounds := *r.Renders[0].(*[]models.FluxMonitorRoundEvent)
+	require.Len(t, rounds, 1)
+	assert.Equal(t, uint32(2), rounds[0].RoundID)
+}
+
+func TestClient_ApproveFluxRound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	held := models.FluxMonitorRoundEvent{
+		JobSpecID: j.ID,
+		RoundID:   2,
+		Trigger:   models.FluxMonitorTriggerPoll,
+		Outcome:   models.FluxMonitorOutcomeHeld,
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, app.Store.CreateFluxMonitorRoundEvent(&held))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{j.ID.String(), strconv.FormatInt(held.ID, 10)})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ApproveFluxRound(c))
+	require.Len(t, r.Renders, 1)
+	approved := r.Renders[0].(*models.FluxMonitorRoundEvent)
+	assert.True(t, approved.ApprovedAt.Valid)
+
+	set = flag.NewFlagSet("test", 0)
+	set.Parse([]string{j.ID.String()})
+	c = cli.NewContext(nil, set, nil)
+	assert.Error(t, client.ApproveFluxRound(c))
+}
+
 func TestClient_ShowJobRun_Exists(t *testing.T) {
 	t.Parallel()
 
@@ -165,6 +232,38 @@ func TestClient_ShowJobSpec_NotFound(t *testing.T) {
 	assert.Empty(t, r.Renders)
 }
 
+func TestClient_UpdateJobSpec_ShowDiff(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{job.ID.String(), `{"tasks":[{"type":"multiply","params":{"times":100}}]}`})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.UpdateJobSpec(c))
+	require.Len(t, r.Renders, 1)
+	assert.Equal(t, uint32(2), r.Renders[0].(*presenters.JobSpec).Version)
+
+	set = flag.NewFlagSet("test", 0)
+	set.Uint("diff", 0, "")
+	set.Parse([]string{"--diff", "1", job.ID.String()})
+	c = cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ShowJobSpec(c))
+	require.Len(t, r.Renders, 2)
+
+	diff := r.Renders[1].(*presenters.JobSpecDiff)
+	assert.Equal(t, uint32(1), diff.From)
+	assert.Equal(t, uint32(2), diff.To)
+	assert.Contains(t, diff.Changes, presenters.JobSpecChange{Path: "tasks.0.type", From: `"noop"`, To: `"multiply"`})
+}
+
 var EndAt = time.Now().AddDate(0, 10, 0).Round(time.Second).UTC()
 
 func TestClient_CreateServiceAgreement(t *testing.T) {
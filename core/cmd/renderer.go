@@ -14,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/web"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
 )
 
 // Renderer implements the Render method.
@@ -51,6 +52,10 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderJobs(*typed)
 	case *presenters.JobSpec:
 		return rt.renderJob(*typed)
+	case *presenters.JobSpecDiff:
+		return rt.renderJobSpecDiff(*typed)
+	case *presenters.JobSimulation:
+		return rt.renderJobSimulation(*typed)
 	case *[]presenters.JobRun:
 		return rt.renderJobRuns(*typed)
 	case *presenters.JobRun:
@@ -61,6 +66,14 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderBridgeAuthentication(*typed)
 	case *[]models.BridgeType:
 		return rt.renderBridges(*typed)
+	case *models.Secret:
+		return rt.renderSecrets([]models.Secret{*typed})
+	case *[]models.Secret:
+		return rt.renderSecrets(*typed)
+	case *[]models.FluxMonitorRoundEvent:
+		return rt.renderFluxRounds(*typed)
+	case *models.FluxMonitorRoundEvent:
+		return rt.renderFluxRounds([]models.FluxMonitorRoundEvent{*typed})
 	case *[]presenters.AccountBalance:
 		return rt.renderAccountBalances(*typed)
 	case *presenters.ServiceAgreement:
@@ -173,6 +186,58 @@ func (rt RendererTable) renderBridges(bridges []models.BridgeType) error {
 	return nil
 }
 
+func (rt RendererTable) renderSecrets(secrets []models.Secret) error {
+	table := rt.newTable([]string{"Name", "Reference", "Created At", "Updated At"})
+	for _, secret := range secrets {
+		table.Append([]string{
+			secret.Name,
+			models.SecretReference(secret.Name),
+			utils.ISO8601UTC(secret.CreatedAt),
+			utils.ISO8601UTC(secret.UpdatedAt),
+		})
+	}
+
+	render("Secrets", table)
+	return nil
+}
+
+func (rt RendererTable) renderFluxRounds(events []models.FluxMonitorRoundEvent) error {
+	table := rt.newTable([]string{"ID", "Created At", "Aggregator", "Round", "Trigger", "Outcome", "Reason", "Latest Answer", "Polled Answer", "Run ID", "Approved At"})
+	for _, event := range events {
+		runID := ""
+		if event.JobRunID != nil {
+			runID = event.JobRunID.String()
+		}
+		approvedAt := ""
+		if event.ApprovedAt.Valid {
+			approvedAt = utils.ISO8601UTC(event.ApprovedAt.Time)
+		}
+		table.Append([]string{
+			strconv.FormatInt(event.ID, 10),
+			utils.ISO8601UTC(event.CreatedAt),
+			event.Aggregator.Hex(),
+			strconv.FormatUint(uint64(event.RoundID), 10),
+			string(event.Trigger),
+			string(event.Outcome),
+			event.Reason,
+			nullDecimalString(event.LatestAnswer),
+			nullDecimalString(event.PolledAnswer),
+			runID,
+			approvedAt,
+		})
+	}
+
+	render("Flux Monitor Rounds", table)
+	return nil
+}
+
+func nullDecimalString(d decimal.NullDecimal) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Decimal.String()
+}
+
 func (rt RendererTable) renderBridge(bridge models.BridgeType) error {
 	table := rt.newTable([]string{"Name", "URL", "Default Confirmations", "Outgoing Token"})
 	table.Append([]string{
@@ -186,13 +251,14 @@ func (rt RendererTable) renderBridge(bridge models.BridgeType) error {
 }
 
 func (rt RendererTable) renderBridgeAuthentication(bridge models.BridgeTypeAuthentication) error {
-	table := rt.newTable([]string{"Name", "URL", "Default Confirmations", "Incoming Token", "Outgoing Token"})
+	table := rt.newTable([]string{"Name", "URL", "Default Confirmations", "Incoming Token", "Outgoing Token", "Signing Secret"})
 	table.Append([]string{
 		bridge.Name.String(),
 		bridge.URL.String(),
 		strconv.FormatUint(uint64(bridge.Confirmations), 10),
 		bridge.IncomingToken,
 		bridge.OutgoingToken,
+		bridge.SigningSecret,
 	})
 	render("Bridge", table)
 	return nil
@@ -217,9 +283,10 @@ func (rt RendererTable) renderJobRun(run presenters.JobRun) error {
 }
 
 func (rt RendererTable) renderJobSingles(j presenters.JobSpec) error {
-	table := rt.newTable([]string{"ID", "Created At", "Start At", "End At", "Min Payment"})
+	table := rt.newTable([]string{"ID", "Version", "Created At", "Start At", "End At", "Min Payment"})
 	table.Append([]string{
 		j.ID.String(),
+		strconv.FormatUint(uint64(j.Version), 10),
 		j.FriendlyCreatedAt(),
 		j.FriendlyStartAt(),
 		j.FriendlyEndAt(),
@@ -258,6 +325,48 @@ func (rt RendererTable) renderJobTasks(j presenters.JobSpec) error {
 	return nil
 }
 
+func (rt RendererTable) renderJobSpecDiff(diff presenters.JobSpecDiff) error {
+	table := rt.newTable([]string{"Path", fmt.Sprintf("Version %d", diff.From), fmt.Sprintf("Version %d", diff.To)})
+	table.SetAutoWrapText(false)
+	for _, change := range diff.Changes {
+		table.Append([]string{change.Path, change.From, change.To})
+	}
+
+	render("Changes", table)
+	return nil
+}
+
+func (rt RendererTable) renderJobSimulation(simulation presenters.JobSimulation) error {
+	table := rt.newTable([]string{"Type", "Name", "Status", "Elapsed", "Result", "Error", "Retry At"})
+	table.SetAutoWrapText(false)
+	for _, t := range simulation.Tasks {
+		var retryAt string
+		if t.RetryAt != nil {
+			retryAt = utils.ISO8601UTC(*t.RetryAt)
+		}
+		table.Append([]string{
+			t.Type.String(),
+			t.Name,
+			string(t.Status),
+			t.Elapsed.String(),
+			t.Result.Get("result").String(),
+			t.Error,
+			retryAt,
+		})
+	}
+
+	render("Tasks", table)
+
+	table = rt.newTable([]string{"Status", "Result", "Error"})
+	table.Append([]string{
+		string(simulation.Status),
+		simulation.Result.String(),
+		simulation.Error,
+	})
+	render("Simulation", table)
+	return nil
+}
+
 func (rt RendererTable) renderJobRuns(runs []presenters.JobRun) error {
 	table := rt.newTable([]string{"ID", "Status", "Created", "Completed", "Result", "Error"})
 	for _, jr := range runs {
@@ -0,0 +1,122 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DynamicFeeTxType is the EIP-2718 type of EIP-1559 transactions.
+const DynamicFeeTxType byte = 0x02
+
+// DynamicFeeTx is an EIP-1559 transaction, which pays at most GasFeeCap per
+// unit of gas, of which at most GasTipCap goes to the miner and the rest is
+// the block's base fee.
+//
+// NOTE: The version of go-ethereum in use predates typed transactions, so
+// the EIP-2718 envelope of these transactions is encoded here.
+// See: https://eips.ethereum.org/EIPS/eip-1559
+type DynamicFeeTx struct {
+	ChainID   *big.Int
+	Nonce     uint64
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	Gas       uint64
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+}
+
+type accessTuple struct {
+	Address     common.Address
+	StorageKeys []common.Hash
+}
+
+func (tx *DynamicFeeTx) payload() []interface{} {
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		tx.GasTipCap,
+		tx.GasFeeCap,
+		tx.Gas,
+		tx.To,
+		value,
+		tx.Data,
+		[]accessTuple{},
+	}
+}
+
+// SigningHash returns the hash the sender signs to authorize the transaction.
+func (tx *DynamicFeeTx) SigningHash() (common.Hash, error) {
+	b, err := rlp.EncodeToBytes(tx.payload())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte{DynamicFeeTxType}, b), nil
+}
+
+// EncodeSigned returns the signed transaction, as sent with
+// eth_sendRawTransaction, and its hash, given the 65 byte [R || S || V]
+// signature of its SigningHash.
+func (tx *DynamicFeeTx) EncodeSigned(sig []byte) ([]byte, common.Hash, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, common.Hash{}, fmt.Errorf("wrong size for signature: got %d, want %d", len(sig), crypto.SignatureLength)
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	v := uint64(sig[64])
+
+	b, err := rlp.EncodeToBytes(append(tx.payload(), v, r, s))
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	raw := append([]byte{DynamicFeeTxType}, b...)
+	return raw, crypto.Keccak256Hash(raw), nil
+}
+
+// Signature returns the signature of raw, which must be the signed encoding
+// of this transaction, as returned by EncodeSigned.
+func (tx *DynamicFeeTx) Signature(raw []byte) ([]byte, error) {
+	if len(raw) == 0 || raw[0] != DynamicFeeTxType {
+		return nil, errors.New("not a dynamic fee transaction")
+	}
+	var fields []rlp.RawValue
+	if err := rlp.DecodeBytes(raw[1:], &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) != len(tx.payload())+3 {
+		return nil, fmt.Errorf("wrong number of fields in signed transaction: got %d, want %d", len(fields), len(tx.payload())+3)
+	}
+
+	var v uint64
+	var r, s big.Int
+	if err := rlp.DecodeBytes(fields[len(fields)-3], &v); err != nil {
+		return nil, err
+	}
+	if err := rlp.DecodeBytes(fields[len(fields)-2], &r); err != nil {
+		return nil, err
+	}
+	if err := rlp.DecodeBytes(fields[len(fields)-1], &s); err != nil {
+		return nil, err
+	}
+	sig := append(common.LeftPadBytes(r.Bytes(), 32), common.LeftPadBytes(s.Bytes(), 32)...)
+	sig = append(sig, byte(v))
+
+	expected, _, err := tx.EncodeSigned(sig)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(expected, raw) {
+		return nil, errors.New("signed transaction does not match the transaction")
+	}
+	return sig, nil
+}
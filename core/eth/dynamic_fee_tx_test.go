@@ -0,0 +1,109 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicFeeTx_EncodeSigned(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tx := &eth.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(2000000000),
+		GasFeeCap: big.NewInt(100000000000),
+		Gas:       500000,
+		To:        common.HexToAddress("0x356a04bce728ba4c62a30294a55e6a8600a320b3"),
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	hash, err := tx.SigningHash()
+	require.NoError(t, err)
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+
+	raw, txHash, err := tx.EncodeSigned(sig)
+	require.NoError(t, err)
+	assert.Equal(t, eth.DynamicFeeTxType, raw[0])
+	assert.Equal(t, crypto.Keccak256Hash(raw), txHash)
+
+	var decoded struct {
+		ChainID    *big.Int
+		Nonce      uint64
+		GasTipCap  *big.Int
+		GasFeeCap  *big.Int
+		Gas        uint64
+		To         common.Address
+		Value      *big.Int
+		Data       []byte
+		AccessList []struct {
+			Address     common.Address
+			StorageKeys []common.Hash
+		}
+		V, R, S *big.Int
+	}
+	require.NoError(t, rlp.DecodeBytes(raw[1:], &decoded))
+	assert.Equal(t, tx.Nonce, decoded.Nonce)
+	assert.Equal(t, tx.GasTipCap, decoded.GasTipCap)
+	assert.Equal(t, tx.GasFeeCap, decoded.GasFeeCap)
+	assert.Equal(t, tx.To, decoded.To)
+	assert.Equal(t, tx.Data, decoded.Data)
+	assert.Len(t, decoded.AccessList, 0)
+
+	recoveredSig := append(common.LeftPadBytes(decoded.R.Bytes(), 32), common.LeftPadBytes(decoded.S.Bytes(), 32)...)
+	recoveredSig = append(recoveredSig, byte(decoded.V.Uint64()))
+	pub, err := crypto.SigToPub(hash.Bytes(), recoveredSig)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	_, _, err = tx.EncodeSigned(sig[:64])
+	assert.Error(t, err)
+}
+
+func TestDynamicFeeTx_Signature(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tx := &eth.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(2000000000),
+		GasFeeCap: big.NewInt(100000000000),
+		Gas:       500000,
+		To:        common.HexToAddress("0x356a04bce728ba4c62a30294a55e6a8600a320b3"),
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	hash, err := tx.SigningHash()
+	require.NoError(t, err)
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+	raw, _, err := tx.EncodeSigned(sig)
+	require.NoError(t, err)
+
+	actual, err := tx.Signature(raw)
+	require.NoError(t, err)
+	assert.Equal(t, sig, actual)
+
+	other := *tx
+	other.Nonce = 8
+	_, err = other.Signature(raw)
+	assert.Error(t, err)
+
+	_, err = tx.Signature(raw[1:])
+	assert.Error(t, err)
+}
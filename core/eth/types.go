@@ -120,6 +120,8 @@ type logMarshaling struct {
 
 type Transaction struct {
 	GasPrice hexutil.Uint64 `json:"gasPrice"`
+	// MaxPriorityFeePerGas is only set for EIP-1559 transactions
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // Block represents a full block
@@ -127,6 +129,29 @@ type Transaction struct {
 type Block struct {
 	Number       hexutil.Uint64 `json:"number"`
 	Transactions []Transaction  `json:"transactions"`
+	// BaseFeePerGas is only set on chains supporting EIP-1559
+	BaseFeePerGas *hexutil.Big `json:"baseFeePerGas,omitempty"`
+}
+
+// TipCaps returns the tip paid to the miner per unit of gas by each
+// transaction in the block, or nothing if the block has no base fee.
+func (b Block) TipCaps() []*big.Int {
+	if b.BaseFeePerGas == nil {
+		return nil
+	}
+	baseFee := b.BaseFeePerGas.ToInt()
+	tips := make([]*big.Int, 0, len(b.Transactions))
+	for _, tx := range b.Transactions {
+		tip := new(big.Int).Sub(new(big.Int).SetUint64(uint64(tx.GasPrice)), baseFee)
+		if tx.MaxPriorityFeePerGas != nil && tx.MaxPriorityFeePerGas.ToInt().Cmp(tip) < 0 {
+			tip = new(big.Int).Set(tx.MaxPriorityFeePerGas.ToInt())
+		}
+		if tip.Sign() < 0 {
+			continue
+		}
+		tips = append(tips, tip)
+	}
+	return tips
 }
 
 var emptyHash = common.Hash{}
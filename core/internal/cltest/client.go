@@ -279,6 +279,8 @@ func (c *SimulatedBackendClient) GetBlockByNumber(hex string) (block eth.Block,
 		return eth.Block{}, errors.Wrapf(err, "while retrieving block %d",
 			blockNumber)
 	}
+	// The simulated backend predates EIP-1559, so its blocks have no base fee
+	// and its transactions no priority fee.
 	var txs []eth.Transaction
 	for _, tx := range b.Transactions() {
 		txs = append(txs, eth.Transaction{
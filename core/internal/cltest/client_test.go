@@ -37,9 +37,9 @@ func TestGetTxReceiptGetsAllFields(t *testing.T) {
 }
 
 func TestGetBlockByNumberGetsAllFields(t *testing.T) {
-	fields := []string{"GasPrice"}
+	fields := []string{"GasPrice", "MaxPriorityFeePerGas"}
 	assertFieldsMatch(t, fields, &eth.Transaction{}, "GetBlockByNumber")
-	ofields := []string{"Number", "Transactions"}
+	ofields := []string{"Number", "Transactions", "BaseFeePerGas"}
 	assertFieldsMatch(t, ofields, &eth.Block{}, "GetBlockByNumber")
 }
 
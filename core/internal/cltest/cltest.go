@@ -275,6 +275,7 @@ func NewApplicationWithConfigAndKey(t testing.TB, tc *TestConfig, flags ...strin
 
 	app, cleanup := NewApplicationWithConfig(t, tc, flags...)
 	app.Store.KeyStore.Unlock(Password)
+	require.NoError(t, app.Store.SecretStore.Unlock(Password))
 
 	return app, cleanup
 }
@@ -192,6 +192,20 @@ func (_m *Application) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) er
 	return r0
 }
 
+// ResumeAllPendingSleep provides a mock function with given fields:
+func (_m *Application) ResumeAllPendingSleep() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumeAllPendingConnection provides a mock function with given fields:
 func (_m *Application) ResumeAllPendingConnection() error {
 	ret := _m.Called()
@@ -262,6 +276,20 @@ func (_m *Application) Stop() error {
 	return r0
 }
 
+// UpdateJob provides a mock function with given fields: job
+func (_m *Application) UpdateJob(job models.JobSpec) error {
+	ret := _m.Called(job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.JobSpec) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
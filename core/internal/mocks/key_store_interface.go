@@ -9,6 +9,8 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 
+	eth "github.com/smartcontractkit/chainlink/core/eth"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/smartcontractkit/chainlink/core/store/models"
@@ -130,6 +132,38 @@ func (_m *KeyStoreInterface) NewAccount(passphrase string) (accounts.Account, er
 	return r0, r1
 }
 
+// SignDynamicFeeTx provides a mock function with given fields: account, tx
+func (_m *KeyStoreInterface) SignDynamicFeeTx(account accounts.Account, tx *eth.DynamicFeeTx) ([]byte, common.Hash, error) {
+	ret := _m.Called(account, tx)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(accounts.Account, *eth.DynamicFeeTx) []byte); ok {
+		r0 = rf(account, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 common.Hash
+	if rf, ok := ret.Get(1).(func(accounts.Account, *eth.DynamicFeeTx) common.Hash); ok {
+		r1 = rf(account, tx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(common.Hash)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(accounts.Account, *eth.DynamicFeeTx) error); ok {
+		r2 = rf(account, tx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SignHash provides a mock function with given fields: hash
 func (_m *KeyStoreInterface) SignHash(hash common.Hash) (models.Signature, error) {
 	ret := _m.Called(hash)
@@ -125,6 +125,20 @@ func (_m *RunManager) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) err
 	return r0
 }
 
+// ResumeAllPendingSleep provides a mock function with given fields:
+func (_m *RunManager) ResumeAllPendingSleep() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePendingBridge provides a mock function with given fields: runID, input
 func (_m *RunManager) ResumePendingBridge(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
@@ -79,6 +79,29 @@ func (_m *TxManager) Call(result interface{}, method string, args ...interface{}
 	return r0
 }
 
+// CancelTx provides a mock function with given fields: hash, gasPriceWei
+func (_m *TxManager) CancelTx(hash common.Hash, gasPriceWei *big.Int) (*models.Tx, error) {
+	ret := _m.Called(hash, gasPriceWei)
+
+	var r0 *models.Tx
+	if rf, ok := ret.Get(0).(func(common.Hash, *big.Int) *models.Tx); ok {
+		r0 = rf(hash, gasPriceWei)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, *big.Int) error); ok {
+		r1 = rf(hash, gasPriceWei)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckAttempt provides a mock function with given fields: txAttempt, blockHeight
 func (_m *TxManager) CheckAttempt(txAttempt *models.TxAttempt, blockHeight uint64) (*eth.TxReceipt, store.AttemptState, error) {
 	ret := _m.Called(txAttempt, blockHeight)
@@ -480,6 +503,29 @@ func (_m *TxManager) Register(_a0 []accounts.Account) {
 	_m.Called(_a0)
 }
 
+// ReplaceTx provides a mock function with given fields: hash, rtr
+func (_m *TxManager) ReplaceTx(hash common.Hash, rtr models.ReplaceTxRequest) (*models.Tx, error) {
+	ret := _m.Called(hash, rtr)
+
+	var r0 *models.Tx
+	if rf, ok := ret.Get(0).(func(common.Hash, models.ReplaceTxRequest) *models.Tx); ok {
+		r0 = rf(hash, rtr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, models.ReplaceTxRequest) error); ok {
+		r1 = rf(hash, rtr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendRawTx provides a mock function with given fields: bytes
 func (_m *TxManager) SendRawTx(bytes []byte) (common.Hash, error) {
 	ret := _m.Called(bytes)
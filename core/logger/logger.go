@@ -54,12 +54,13 @@ func (l *Logger) Write(b []byte) (int, error) {
 	return len(b), nil
 }
 
-// SetLogger sets the internal logger to the given input.
+// SetLogger sets the internal logger to the given input, redacting the
+// secrets of the Redactor from its entries.
 func SetLogger(zl *zap.Logger) {
 	if logger != nil {
 		defer logger.Sync()
 	}
-	logger = &Logger{zl.Sugar()}
+	logger = &Logger{zl.WithOptions(zap.WrapCore(newRedactingCore)).Sugar()}
 }
 
 // CreateProductionLogger returns a log config for the passed directory
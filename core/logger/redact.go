@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Redactor replaces secrets in text.
+type Redactor interface {
+	Redact(string) string
+}
+
+var (
+	redactor      Redactor
+	redactorMutex sync.RWMutex
+)
+
+// SetRedactor sets the redactor applied to the messages and fields of
+// every log entry, so that secrets never reach the logs.
+func SetRedactor(r Redactor) {
+	redactorMutex.Lock()
+	defer redactorMutex.Unlock()
+	redactor = r
+}
+
+// redacting returns true if a redactor is set.
+func redacting() bool {
+	redactorMutex.RLock()
+	defer redactorMutex.RUnlock()
+	return redactor != nil
+}
+
+func redact(s string) string {
+	redactorMutex.RLock()
+	defer redactorMutex.RUnlock()
+	if redactor == nil {
+		return s
+	}
+	return redactor.Redact(s)
+}
+
+// redactingCore redacts the entries written to the core it wraps.
+type redactingCore struct {
+	zapcore.Core
+}
+
+func newRedactingCore(core zapcore.Core) zapcore.Core {
+	return redactingCore{core}
+}
+
+func (c redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactingCore{c.Core.With(redactFields(fields))}
+}
+
+func (c redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields returns the fields with secrets redacted from them. Since
+// fields which are not strings are encoded to be redacted, they are left as
+// they are when no redactor is set.
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	if !redacting() {
+		return fields
+	}
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = redactField(field)
+	}
+	return redacted
+}
+
+// redactField returns the field with secrets redacted from its text. Fields
+// which are not strings are only replaced by their redacted text when it
+// contains a secret.
+func redactField(field zapcore.Field) zapcore.Field {
+	var text string
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = redact(field.String)
+		return field
+	case zapcore.ErrorType:
+		text = field.Interface.(error).Error()
+	case zapcore.StringerType:
+		text = field.Interface.(fmt.Stringer).String()
+	case zapcore.ReflectType:
+		b, err := json.Marshal(field.Interface)
+		if err != nil {
+			return field
+		}
+		text = string(b)
+	default:
+		return field
+	}
+
+	if redacted := redact(text); redacted != text {
+		return zapcore.Field{Key: field.Key, Type: zapcore.StringType, String: redacted}
+	}
+	return field
+}
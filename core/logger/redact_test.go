@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type replacer struct {
+	*strings.Replacer
+}
+
+func (r replacer) Redact(s string) string {
+	return r.Replace(s)
+}
+
+func TestRedactingCore(t *testing.T) {
+	original := GetLogger()
+	core, logs := observer.New(zap.DebugLevel)
+	SetLogger(zap.New(core))
+	SetRedactor(replacer{strings.NewReplacer("hunter2", "[REDACTED]")})
+	defer func() {
+		SetRedactor(nil)
+		logger = original
+	}()
+
+	Infow("requesting https://example.com?key=hunter2",
+		"url", "https://example.com?key=hunter2",
+		"error", errors.New("GET https://example.com?key=hunter2 failed"),
+		"params", map[string]string{"key": "hunter2"},
+		"attempt", 1,
+	)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "requesting https://example.com?key=[REDACTED]", entries[0].Message)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "https://example.com?key=[REDACTED]", fields["url"])
+	assert.Equal(t, "GET https://example.com?key=[REDACTED] failed", fields["error"])
+	assert.Equal(t, `{"key":"[REDACTED]"}`, fields["params"])
+	assert.Equal(t, int64(1), fields["attempt"])
+
+	SetRedactor(nil)
+	params := map[string]string{"key": "hunter2"}
+	Infow("hunter2", "params", params)
+	assert.Equal(t, "hunter2", logs.AllUntimed()[1].Message)
+	assert.Equal(t, params, logs.AllUntimed()[1].ContextMap()["params"], "fields should not be encoded without a redactor")
+}
+
+// BenchmarkRedactFields measures the cost of redacting the fields of a log
+// entry, which encodes those which are not strings.
+func BenchmarkRedactFields(b *testing.B) {
+	fields := []zapcore.Field{
+		zap.String("url", "https://example.com?key=hunter2"),
+		zap.Error(errors.New("GET https://example.com?key=hunter2 failed")),
+		zap.Any("params", map[string]interface{}{"get": "https://example.com", "path": []string{"last", "price"}, "times": 100}),
+	}
+	for _, r := range []struct {
+		name     string
+		redactor Redactor
+	}{
+		{"no redactor", nil},
+		{"redactor", replacer{strings.NewReplacer("hunter2", "[REDACTED]")}},
+	} {
+		b.Run(r.name, func(b *testing.B) {
+			SetRedactor(r.redactor)
+			defer SetRedactor(nil)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				redactFields(fields)
+			}
+		})
+	}
+}
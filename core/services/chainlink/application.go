@@ -5,6 +5,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/gracefulpanic"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -42,6 +43,7 @@ type Application interface {
 	GetStatsPusher() synchronization.StatsPusher
 	WakeSessionReaper()
 	AddJob(job models.JobSpec) error
+	UpdateJob(job models.JobSpec) error
 	ArchiveJob(*models.ID) error
 	AddServiceAgreement(*models.ServiceAgreement) error
 	NewBox() packr.Box
@@ -64,6 +66,7 @@ type ChainlinkApplication struct {
 	Store                    *strpkg.Store
 	SessionReaper            services.SleeperTask
 	pendingConnectionResumer *pendingConnectionResumer
+	pendingSleepResumer      *pendingSleepResumer
 	shutdownOnce             sync.Once
 	shutdownSignal           gracefulpanic.Signal
 }
@@ -101,6 +104,7 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 		SessionReaper:            services.NewStoreReaper(store),
 		Exiter:                   os.Exit,
 		pendingConnectionResumer: pendingConnectionResumer,
+		pendingSleepResumer:      newPendingSleepResumer(runManager, config.TaskRetryPollInterval().Duration()),
 		shutdownSignal:           shutdownSignal,
 	}
 
@@ -144,6 +148,7 @@ func (app *ChainlinkApplication) Start() error {
 		app.StatsPusher.Start(),
 		app.RunQueue.Start(),
 		app.RunManager.ResumeAllInProgress(),
+		app.pendingSleepResumer.Start(),
 		app.FluxMonitor.Start(),
 
 		// HeadTracker deliberately started after
@@ -168,6 +173,7 @@ func (app *ChainlinkApplication) Stop() error {
 		merr = multierr.Append(merr, app.HeadTracker.Stop())
 		app.JobSubscriber.Stop()
 		app.FluxMonitor.Stop()
+		app.pendingSleepResumer.Stop()
 		app.RunQueue.Stop()
 		app.StatsPusher.Close()
 		merr = multierr.Append(merr, app.SessionReaper.Stop())
@@ -209,6 +215,29 @@ func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
 	return nil
 }
 
+// UpdateJob saves the job as a new version of the existing job with the same
+// ID. Runs created from then on use the new version, while runs in progress
+// finish with the version they were created with.
+func (app *ChainlinkApplication) UpdateJob(job models.JobSpec) error {
+	if err := app.Store.UpdateJob(&job); err != nil {
+		return err
+	}
+
+	// The scheduler, log subscriptions and flux monitor each keep their own
+	// copy of the job, which is replaced by the new version
+	app.Scheduler.RemoveJob(job.ID)
+	app.Scheduler.AddJob(job)
+	if job.IsLogInitiated() {
+		_ = app.JobSubscriber.RemoveJob(job.ID)
+		logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	}
+	if len(job.InitiatorsFor(models.InitiatorFluxMonitor)) > 0 {
+		app.FluxMonitor.RemoveJob(job.ID)
+		logger.ErrorIf(app.FluxMonitor.AddJob(job))
+	}
+	return nil
+}
+
 // ArchiveJob silences the job from the system, preventing future job runs.
 func (app *ChainlinkApplication) ArchiveJob(ID *models.ID) error {
 	_ = app.JobSubscriber.RemoveJob(ID)
@@ -254,3 +283,44 @@ func (p *pendingConnectionResumer) Connect(head *models.Head) error {
 
 func (p *pendingConnectionResumer) Disconnect()                   {}
 func (p *pendingConnectionResumer) OnNewLongestChain(models.Head) {}
+
+// pendingSleepResumer periodically requeues runs whose tasks are due to be
+// retried, so that no worker is kept waiting for a backoff to elapse.
+type pendingSleepResumer struct {
+	runManager services.RunManager
+	interval   time.Duration
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newPendingSleepResumer(runManager services.RunManager, interval time.Duration) *pendingSleepResumer {
+	return &pendingSleepResumer{runManager: runManager, interval: interval}
+}
+
+func (p *pendingSleepResumer) Start() error {
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				logger.ErrorIf(p.runManager.ResumeAllPendingSleep())
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *pendingSleepResumer) Stop() {
+	if p.done != nil {
+		close(p.done)
+		p.wg.Wait()
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/guregu/null"
@@ -31,12 +35,14 @@ type httpFetcher struct {
 	client      *http.Client
 	url         *url.URL
 	requestData string
+	hostLimiter *store.HostLimiter
 }
 
 func newHTTPFetcher(
 	timeout models.Duration,
 	requestData string,
 	url *url.URL,
+	hostLimiter *store.HostLimiter,
 ) Fetcher {
 	client := &http.Client{Timeout: timeout.Duration(), Transport: http.DefaultTransport}
 	client.Transport = promhttp.InstrumentRoundTripperDuration(promFMResponseTime, client.Transport)
@@ -46,6 +52,7 @@ func newHTTPFetcher(
 		client:      client,
 		url:         url,
 		requestData: requestData,
+		hostLimiter: hostLimiter,
 	}
 }
 
@@ -54,6 +61,11 @@ func (p *httpFetcher) Fetch() (decimal.Decimal, error) {
 	if err != nil {
 		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s, cannot add request ID", p.url.String()))
 	}
+	release, err := p.hostLimiter.Acquire(p.url)
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s", p.url.String()))
+	}
+	defer release()
 	r, err := p.client.Post(p.url.String(), "application/json", strings.NewReader(request))
 	if err != nil {
 		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s with payload '%s'", p.url.String(), p.requestData))
@@ -117,6 +129,57 @@ func (pr adapterResponse) Result() *decimal.Decimal {
 	return pr.Data.Result
 }
 
+// pipelineFetcher retrieves a price by performing the tasks of a feed
+// pipeline in memory, without saving a run. The price is the result of the
+// last task. A task which would be retried fails the fetch rather than
+// holding up the poll.
+type pipelineFetcher struct {
+	store *store.Store
+	job   models.JobSpec
+}
+
+func newPipelineFetcher(store *store.Store, pipeline models.FeedPipeline) Fetcher {
+	return &pipelineFetcher{store: store, job: pipeline.JobSpec()}
+}
+
+func (p *pipelineFetcher) Fetch() (decimal.Decimal, error) {
+	run, err := services.ExecutePipeline(p.store, p.job, models.JSON{})
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s", p))
+	}
+
+	if run.GetStatus().Errored() {
+		return decimal.Decimal{}, errors.Wrap(errors.New(run.Result.ErrorMessage.String), fmt.Sprintf("%s returned error", p))
+	} else if !run.GetStatus().Completed() {
+		return decimal.Decimal{}, fmt.Errorf("unable to fetch price from %s, run is %s", p, run.GetStatus())
+	}
+
+	result := run.Result.Data.Get("result")
+	if !result.Exists() {
+		return decimal.Decimal{}, errors.Wrap(errors.New("no result returned"), fmt.Sprintf("unable to fetch price from %s", p))
+	}
+	price, err := decimal.NewFromString(result.String())
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to decode price from %s", p))
+	}
+
+	resultFloat, _ := price.Float64()
+	promFMIndividualReportedValue.WithLabelValues(p.String()).Set(resultFloat)
+	logger.Debugw(
+		fmt.Sprintf("fetched price %v from %s", price, p),
+		"price", price,
+	)
+	return price, nil
+}
+
+func (p *pipelineFetcher) String() string {
+	types := make([]string, len(p.job.Tasks))
+	for i, task := range p.job.Tasks {
+		types[i] = task.Type.String()
+	}
+	return fmt.Sprintf("pipeline price fetcher: %s", strings.Join(types, ","))
+}
+
 // medianFetcher fetches from all fetchers, and returns the median value, or
 // average if even number of results.
 type medianFetcher struct {
@@ -124,18 +187,15 @@ type medianFetcher struct {
 }
 
 // newMedianFetcherFromURLs creates a median fetcher that retrieves a price
-// from all passed URLs using httpFetcher, and returns the median.
+// from all passed URLs using httpFetcher, within the limits of their hosts,
+// and returns the median.
 func newMedianFetcherFromURLs(
 	timeout models.Duration,
 	requestData string,
 	priceURLs []*url.URL,
+	hostLimiter *store.HostLimiter,
 ) (Fetcher, error) {
-	fetchers := []Fetcher{}
-	for _, url := range priceURLs {
-		ps := newHTTPFetcher(timeout, requestData, url)
-		fetchers = append(fetchers, ps)
-	}
-
+	fetchers := newHTTPFetchers(timeout, requestData, priceURLs, hostLimiter)
 	medianFetcher, err := newMedianFetcher(fetchers...)
 	if err != nil {
 		return nil, err
@@ -144,6 +204,20 @@ func newMedianFetcherFromURLs(
 	return medianFetcher, nil
 }
 
+func newHTTPFetchers(
+	timeout models.Duration,
+	requestData string,
+	priceURLs []*url.URL,
+	hostLimiter *store.HostLimiter,
+) []Fetcher {
+	fetchers := []Fetcher{}
+	for _, url := range priceURLs {
+		ps := newHTTPFetcher(timeout, requestData, url, hostLimiter)
+		fetchers = append(fetchers, ps)
+	}
+	return fetchers
+}
+
 func newMedianFetcher(fetchers ...Fetcher) (Fetcher, error) {
 	if len(fetchers) == 0 {
 		return nil, errors.New("must pass in at least one price fetcher to newMedianFetcher")
@@ -207,3 +281,36 @@ func (m *medianFetcher) String() string {
 	}
 	return fmt.Sprintf("median fetcher: %s", strings.Join(fetcherDescriptions, ","))
 }
+
+// sharedFetcher shares the answers of a fetcher between the checkers of the
+// aggregators fed by a job, so that its feeds are polled once for all of
+// them. Fetches wait for the one in flight, and reuse its answer, or error,
+// until it is older than maxAge.
+type sharedFetcher struct {
+	fetcher   Fetcher
+	maxAge    time.Duration
+	answer    decimal.Decimal
+	err       error
+	fetchedAt time.Time
+	mutex     sync.Mutex
+}
+
+func newSharedFetcher(fetcher Fetcher, maxAge time.Duration) Fetcher {
+	return &sharedFetcher{fetcher: fetcher, maxAge: maxAge}
+}
+
+func (s *sharedFetcher) Fetch() (decimal.Decimal, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < s.maxAge {
+		return s.answer, s.err
+	}
+	s.answer, s.err = s.fetcher.Fetch()
+	s.fetchedAt = time.Now()
+	return s.answer, s.err
+}
+
+func (s *sharedFetcher) String() string {
+	return fmt.Sprintf("shared %s", s.fetcher)
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -69,7 +71,7 @@ func TestNewMedianFetcherFromURLs_Happy(t *testing.T) {
 				urls = append(urls, newURL)
 			}
 
-			medianFetcher, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls)
+			medianFetcher, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, nil)
 			require.NoError(t, err)
 
 			medianPrice, err := medianFetcher.Fetch()
@@ -84,7 +86,7 @@ func TestNewMedianFetcherFromURLs_EmptyError(t *testing.T) {
 	defer s1.Close()
 	var urls []*url.URL
 
-	_, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls)
+	_, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, nil)
 	require.Error(t, err)
 }
 
@@ -95,7 +97,7 @@ func TestHTTPFetcher_Happy(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(s1.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, btcUSDPairing, feedURL)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, btcUSDPairing, feedURL, nil)
 	price, err := fetcher.Fetch()
 	require.NoError(t, err)
 	assert.Equal(t, decimal.NewFromInt(9700), price)
@@ -117,7 +119,7 @@ func TestHTTPFetcher_ErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, nil)
 	price, err := fetcher.Fetch()
 	assert.Error(t, err)
 	assert.Equal(t, decimal.NewFromInt(0).String(), price.String())
@@ -137,7 +139,7 @@ func TestHTTPFetcher_OnlyErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, nil)
 	price, err := fetcher.Fetch()
 	assert.Error(t, err)
 	assert.Equal(t, decimal.NewFromInt(0).String(), price.String())
@@ -156,7 +158,7 @@ func TestHTTPFetcher_NoResultNorErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, nil)
 	price, err := fetcher.Fetch()
 	assert.Error(t, err)
 	assert.True(t, decimal.NewFromInt(0).Equal(price))
@@ -300,6 +302,49 @@ func TestHTTPFetcher_AddsArbitraryRequestID(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(s1.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, nil)
 	fetcher.Fetch()
 }
+
+type countingFetcher struct {
+	Fetcher
+	fetches int32
+}
+
+func (c *countingFetcher) Fetch() (decimal.Decimal, error) {
+	atomic.AddInt32(&c.fetches, 1)
+	return c.Fetcher.Fetch()
+}
+
+func TestSharedFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	counter := &countingFetcher{Fetcher: newFixedPricedFetcher(decimal.NewFromInt(100))}
+	fetcher := newSharedFetcher(counter, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			price, err := fetcher.Fetch()
+			assert.NoError(t, err)
+			assert.True(t, decimal.NewFromInt(100).Equal(price))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&counter.fetches))
+}
+
+func TestSharedFetcher_Fetch_Expired(t *testing.T) {
+	t.Parallel()
+
+	counter := &countingFetcher{Fetcher: newErroringPricedFetcher()}
+	fetcher := newSharedFetcher(counter, 0)
+
+	_, err := fetcher.Fetch()
+	assert.Error(t, err)
+	_, err = fetcher.Fetch()
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&counter.fetches))
+}
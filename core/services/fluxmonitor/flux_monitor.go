@@ -263,33 +263,85 @@ func (f pollingDeviationCheckerFactory) New(
 	if err != nil {
 		return nil, err
 	}
-
-	fetcher, err := newMedianFetcherFromURLs(
-		timeout,
-		initr.RequestData.String(),
-		urls)
+	pipelines, err := ExtractFeedPipelines(initr.Feeds)
 	if err != nil {
 		return nil, err
 	}
 
-	f.logBroadcaster.AddDependents(1)
-	fluxAggregator, err := contracts.NewFluxAggregator(initr.Address, f.store.TxManager, f.logBroadcaster)
+	fetchers := newHTTPFetchers(timeout, initr.RequestData.String(), urls, f.store.HostLimiter)
+	for _, pipeline := range pipelines {
+		fetchers = append(fetchers, newPipelineFetcher(f.store, pipeline))
+	}
+	fetcher, err := newMedianFetcher(fetchers...)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewPollingDeviationChecker(
-		f.store,
-		fluxAggregator,
-		initr,
-		minJobPayment,
-		runManager,
-		fetcher,
-		func() { f.logBroadcaster.DependentReady() },
-	)
+	addresses := initr.FluxAggregators()
+	if len(addresses) > 1 {
+		// The checkers of the aggregators poll at the same time, so they
+		// share the answers of one poll of the feeds
+		fetcher = newSharedFetcher(fetcher, timeout.Duration()/2)
+	}
+
+	fluxAggregators := make([]contracts.FluxAggregator, len(addresses))
+	for i, address := range addresses {
+		fluxAggregators[i], err = contracts.NewFluxAggregator(address, f.store.TxManager, f.logBroadcaster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Each aggregator has its own checker, which tracks its rounds and checks
+	// its eligibility and funding independently
+	var checkers aggregatorCheckers
+	for i, address := range addresses {
+		aggregatorInitr := initr
+		aggregatorInitr.Address = address
+		checker, err := NewPollingDeviationChecker(
+			f.store,
+			fluxAggregators[i],
+			aggregatorInitr,
+			minJobPayment,
+			runManager,
+			fetcher,
+			func() { f.logBroadcaster.DependentReady() },
+		)
+		if err != nil {
+			return nil, err
+		}
+		checkers = append(checkers, checker)
+	}
+	// The log broadcaster waits for the checkers only once all of them were
+	// created, so that one failing to be created does not hold it back
+	f.logBroadcaster.AddDependents(len(checkers))
+
+	if len(checkers) == 1 {
+		return checkers[0], nil
+	}
+	return checkers, nil
 }
 
-// ExtractFeedURLs extracts a list of url.URLs from the feeds parameter of the initiator params
+// aggregatorCheckers are the checkers of the aggregators fed by an initiator,
+// which are started and stopped together.
+type aggregatorCheckers []*PollingDeviationChecker
+
+// Start starts the checkers of all aggregators.
+func (a aggregatorCheckers) Start() {
+	for _, checker := range a {
+		checker.Start()
+	}
+}
+
+// Stop stops the checkers of all aggregators.
+func (a aggregatorCheckers) Stop() {
+	for _, checker := range a {
+		checker.Stop()
+	}
+}
+
+// ExtractFeedURLs extracts a list of url.URLs from the feeds parameter of the
+// initiator params, skipping its feed pipelines
 func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	var feedsData []interface{}
 	var urls []*url.URL
@@ -307,7 +359,10 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 		case string: // feed url - ex: "http://example.com"
 			bridgeURL, err = url.ParseRequestURI(feed)
 		case map[string]interface{}: // named feed - ex: {"bridge": "bridgeName"}
-			bridgeName := feed["bridge"].(string)
+			if models.IsFeedPipeline(feed) {
+				continue
+			}
+			bridgeName, _ := feed["bridge"].(string)
 			bridgeURL, err = GetBridgeURLFromName(bridgeName, orm) // XXX: currently an n query
 		default:
 			err = errors.New("unable to extract feed URLs from json")
@@ -322,6 +377,29 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	return urls, nil
 }
 
+// ExtractFeedPipelines extracts the feed pipelines from the feeds parameter of
+// the initiator params - ex: {"tasks": [{"type": "httpget", ...}]}
+func ExtractFeedPipelines(feeds models.Feeds) ([]models.FeedPipeline, error) {
+	var feedsData []interface{}
+	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
+		return nil, err
+	}
+
+	var pipelines []models.FeedPipeline
+	for _, entry := range feedsData {
+		feed, ok := entry.(map[string]interface{})
+		if !ok || !models.IsFeedPipeline(feed) {
+			continue
+		}
+		pipeline, err := models.NewFeedPipeline(feed)
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, nil
+}
+
 // GetBridgeURLFromName looks up a bridge in the DB by name, then extracts the url
 func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 	task := models.TaskType(name)
@@ -347,19 +425,22 @@ type PollingDeviationChecker struct {
 	runManager     RunManager
 	fetcher        Fetcher
 
-	initr         models.Initiator
-	minJobPayment *assets.Link
-	requestData   models.JSON
-	precision     int32
+	initr             models.Initiator
+	thresholdSchedule models.ParsedThresholdSchedule
+	minJobPayment     *assets.Link
+	requestData       models.JSON
+	precision         int32
 
-	connected     *abool.AtomicBool
-	backlog       *utils.BoundedPriorityQueue
-	chProcessLogs chan struct{}
-	pollTicker    <-chan time.Time
-	idleTimer     <-chan time.Time
-	roundTimer    <-chan time.Time
+	connected      *abool.AtomicBool
+	backlog        *utils.BoundedPriorityQueue
+	chProcessLogs  chan struct{}
+	pollTicker     <-chan time.Time
+	idleTimer      <-chan time.Time
+	roundTimer     <-chan time.Time
+	heartbeatTimer <-chan time.Time
 
 	mostRecentSubmittedRoundID uint32
+	historyPrunedAt            time.Time
 
 	readyForLogs func()
 	chStop       chan struct{}
@@ -376,20 +457,25 @@ func NewPollingDeviationChecker(
 	fetcher Fetcher,
 	readyForLogs func(),
 ) (*PollingDeviationChecker, error) {
+	thresholdSchedule, err := initr.ThresholdSchedule.Parse()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid threshold schedule")
+	}
 	return &PollingDeviationChecker{
-		readyForLogs:   readyForLogs,
-		store:          store,
-		fluxAggregator: fluxAggregator,
-		initr:          initr,
-		minJobPayment:  minJobPayment,
-		requestData:    initr.RequestData,
-		precision:      initr.Precision,
-		runManager:     runManager,
-		fetcher:        fetcher,
-		pollTicker:     nil,
-		idleTimer:      nil,
-		roundTimer:     nil,
-		connected:      abool.New(),
+		readyForLogs:      readyForLogs,
+		store:             store,
+		fluxAggregator:    fluxAggregator,
+		initr:             initr,
+		thresholdSchedule: thresholdSchedule,
+		minJobPayment:     minJobPayment,
+		requestData:       initr.RequestData,
+		precision:         initr.Precision,
+		runManager:        runManager,
+		fetcher:           fetcher,
+		pollTicker:        nil,
+		idleTimer:         nil,
+		roundTimer:        nil,
+		connected:         abool.New(),
 		backlog: utils.NewBoundedPriorityQueue(map[uint]uint{
 			// We want reconnecting nodes to be able to submit to a round
 			// that hasn't hit maxAnswers yet, as well as the newest round.
@@ -486,10 +572,7 @@ func (p *PollingDeviationChecker) consume() {
 
 	if !p.initr.PollTimer.Disabled {
 		// Try to do an initial poll
-		p.pollIfEligible(DeviationThresholds{
-			Rel: float64(p.initr.Threshold),
-			Abs: float64(p.initr.AbsoluteThreshold),
-		})
+		p.pollIfEligible(models.FluxMonitorTriggerPoll, p.thresholds(time.Now()))
 
 		ticker := time.NewTicker(p.initr.PollTimer.Period.Duration())
 		defer ticker.Stop()
@@ -498,6 +581,7 @@ func (p *PollingDeviationChecker) consume() {
 	if !p.initr.IdleTimer.Disabled {
 		p.idleTimer = time.After(p.initr.IdleTimer.Duration.Duration())
 	}
+	p.resetHeartbeatTimer()
 
 	for {
 		select {
@@ -513,10 +597,7 @@ func (p *PollingDeviationChecker) consume() {
 				"idleDuration", p.initr.IdleTimer.Duration,
 				"contract", p.initr.Address.Hex(),
 			)
-			p.pollIfEligible(DeviationThresholds{
-				Rel: float64(p.initr.Threshold),
-				Abs: float64(p.initr.AbsoluteThreshold),
-			})
+			p.pollIfEligible(models.FluxMonitorTriggerPoll, p.thresholds(time.Now()))
 
 		case <-p.idleTimer:
 			logger.Debugw("Idle ticker fired",
@@ -524,7 +605,7 @@ func (p *PollingDeviationChecker) consume() {
 				"idleDuration", p.initr.IdleTimer.Duration,
 				"contract", p.initr.Address.Hex(),
 			)
-			p.pollIfEligible(DeviationThresholds{Rel: 0, Abs: 0})
+			p.pollIfEligible(models.FluxMonitorTriggerIdle, DeviationThresholds{Rel: 0, Abs: 0})
 
 		case <-p.roundTimer:
 			logger.Debugw("Round timeout ticker fired",
@@ -532,12 +613,45 @@ func (p *PollingDeviationChecker) consume() {
 				"idleDuration", p.initr.IdleTimer.Duration,
 				"contract", p.initr.Address.Hex(),
 			)
-			p.pollIfEligible(DeviationThresholds{
-				Rel: float64(p.initr.Threshold),
-				Abs: float64(p.initr.AbsoluteThreshold),
-			})
+			p.pollIfEligible(models.FluxMonitorTriggerRoundTimeout, p.thresholds(time.Now()))
+
+		case <-p.heartbeatTimer:
+			logger.Debugw("Heartbeat timer fired",
+				"heartbeat", p.initr.Heartbeat,
+				"contract", p.initr.Address.Hex(),
+			)
+			if !p.pollIfEligible(models.FluxMonitorTriggerHeartbeat, DeviationThresholds{Rel: 0, Abs: 0}) {
+				// Try again on the next heartbeat, rather than polling on
+				// every tick while we are not eligible to submit
+				p.resetHeartbeatTimer()
+			}
+		}
+	}
+}
+
+// thresholds returns the deviation thresholds of the window of the threshold
+// schedule containing the time, or those of the initiator outside of them.
+func (p *PollingDeviationChecker) thresholds(t time.Time) DeviationThresholds {
+	if window := p.thresholdSchedule.Window(t); window != nil {
+		return DeviationThresholds{
+			Rel: float64(window.Threshold),
+			Abs: float64(window.AbsoluteThreshold),
 		}
 	}
+	return DeviationThresholds{
+		Rel: float64(p.initr.Threshold),
+		Abs: float64(p.initr.AbsoluteThreshold),
+	}
+}
+
+// resetHeartbeatTimer restarts the heartbeat period, if the job has one. It
+// is reset whenever the node submits an answer, so that the heartbeat only
+// forces a submission after a period without any.
+func (p *PollingDeviationChecker) resetHeartbeatTimer() {
+	if p.initr.Heartbeat.IsInstant() {
+		return
+	}
+	p.heartbeatTimer = time.After(p.initr.Heartbeat.Duration())
 }
 
 func (p *PollingDeviationChecker) processLogs() {
@@ -640,6 +754,12 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log contracts.LogNewRound
 			// This indicates that we tried to start a round at the same time as another
 			// node, and their transaction was mined first.  We should not resubmit.
 			logger.Debugw("Ignoring new round request: started round simultaneously with another node", p.loggerFieldsForNewRound(log)...)
+			p.recordRoundEvent(models.FluxMonitorRoundEvent{
+				RoundID: logRoundID,
+				Trigger: models.FluxMonitorTriggerNewRound,
+				Outcome: models.FluxMonitorOutcomeSkipped,
+				Reason:  "already submitted to this round",
+			})
 			return
 		}
 	}
@@ -659,15 +779,25 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log contracts.LogNewRound
 		return
 	}
 
+	event := models.FluxMonitorRoundEvent{
+		RoundID: logRoundID,
+		Trigger: models.FluxMonitorTriggerNewRound,
+	}
+
 	// Ignore rounds we're not eligible for, or for which we won't be paid
 	roundState, err := p.roundState(logRoundID)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("Ignoring new round request: error fetching eligibility from contract: %v", err), p.loggerFieldsForNewRound(log)...)
+		p.recordRoundError(event, "unable to fetch round state", err)
 		return
 	}
+	event.LatestAnswer = p.answerDecimal(roundState.LatestAnswer)
 	err = p.checkEligibilityAndAggregatorFunding(roundState)
 	if err != nil {
 		logger.Infow(fmt.Sprintf("Ignoring new round request: %v", err), p.loggerFieldsForNewRound(log)...)
+		event.Outcome = models.FluxMonitorOutcomeSkipped
+		event.Reason = err.Error()
+		p.recordRoundEvent(event)
 		return
 	}
 
@@ -676,14 +806,26 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log contracts.LogNewRound
 	polledAnswer, err := p.fetcher.Fetch()
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to fetch median price: %v", err), p.loggerFieldsForNewRound(log)...)
+		p.recordRoundError(event, "unable to fetch answer", err)
+		return
+	}
+	event.PolledAnswer = decimal.NullDecimal{Decimal: polledAnswer, Valid: true}
+
+	approval, ok := p.guardAnswer(event)
+	if !ok {
 		return
 	}
 
-	err = p.createJobRun(polledAnswer, logRoundID)
+	jobRunID, err := p.createJobRun(polledAnswer, logRoundID)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to create job run: %v", err), p.loggerFieldsForNewRound(log)...)
+		p.recordRoundError(event, "unable to create job run", err)
 		return
 	}
+	event.Outcome = models.FluxMonitorOutcomeSubmitted
+	event.JobRunID = jobRunID
+	p.useApproval(&event, approval)
+	p.recordRoundEvent(event)
 }
 
 var (
@@ -731,16 +873,21 @@ type DeviationThresholds struct {
 	Abs float64 // Absolute change required, i.e. |new-old| >= Abs
 }
 
-func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds) (createdJobRun bool) {
+func (p *PollingDeviationChecker) pollIfEligible(trigger models.FluxMonitorTrigger, thresholds DeviationThresholds) (createdJobRun bool) {
 	loggerFields := []interface{}{
 		"jobID", p.initr.JobSpecID,
 		"address", p.initr.InitiatorParams.Address,
 		"threshold", thresholds.Rel,
 		"absoluteThreshold", thresholds.Abs,
+		"trigger", trigger,
 	}
+	event := models.FluxMonitorRoundEvent{Trigger: trigger}
 
 	if !p.connected.IsSet() {
 		logger.Warnw("not connected to Ethereum node, skipping poll", loggerFields...)
+		event.Outcome = models.FluxMonitorOutcomeSkipped
+		event.Reason = "not connected to Ethereum node"
+		p.recordRoundEvent(event)
 		return false
 	}
 
@@ -756,22 +903,30 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 	roundState, err := p.roundState(0)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to determine eligibility to submit from FluxAggregator contract: %v", err), loggerFields...)
+		p.recordRoundError(event, "unable to fetch round state", err)
 		return false
 	}
 	loggerFields = append(loggerFields, "reportableRound", roundState.ReportableRoundID)
+	event.RoundID = roundState.ReportableRoundID
+	event.LatestAnswer = p.answerDecimal(roundState.LatestAnswer)
 
 	// Don't submit if we're not eligible, or won't get paid
 	err = p.checkEligibilityAndAggregatorFunding(roundState)
 	if err != nil {
 		logger.Infow(fmt.Sprintf("skipping poll: %v", err), loggerFields...)
+		event.Outcome = models.FluxMonitorOutcomeSkipped
+		event.Reason = err.Error()
+		p.recordRoundEvent(event)
 		return false
 	}
 
 	polledAnswer, err := p.fetcher.Fetch()
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("can't fetch answer: %v", err), loggerFields...)
+		p.recordRoundError(event, "unable to fetch answer", err)
 		return false
 	}
+	event.PolledAnswer = decimal.NullDecimal{Decimal: polledAnswer, Valid: true}
 
 	jobSpecID := p.initr.JobSpecID.String()
 	latestAnswer := decimal.NewFromBigInt(roundState.LatestAnswer, -p.precision)
@@ -783,6 +938,13 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 	)
 	if roundState.ReportableRoundID > 1 && !OutsideDeviation(latestAnswer, polledAnswer, thresholds) {
 		logger.Debugw("deviation < threshold, not submitting", loggerFields...)
+		event.Outcome = models.FluxMonitorOutcomeBelowThreshold
+		p.recordRoundEvent(event)
+		return false
+	}
+
+	approval, ok := p.guardAnswer(event)
+	if !ok {
 		return false
 	}
 
@@ -792,11 +954,16 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 		logger.Infow("starting first round", loggerFields...)
 	}
 
-	err = p.createJobRun(polledAnswer, roundState.ReportableRoundID)
+	jobRunID, err := p.createJobRun(polledAnswer, roundState.ReportableRoundID)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("can't create job run: %v", err), loggerFields...)
+		p.recordRoundError(event, "unable to create job run", err)
 		return false
 	}
+	event.Outcome = models.FluxMonitorOutcomeSubmitted
+	event.JobRunID = jobRunID
+	p.useApproval(&event, approval)
+	p.recordRoundEvent(event)
 
 	promSetDecimal(promFMReportedValue.WithLabelValues(jobSpecID), polledAnswer)
 	promSetUint32(promFMReportedRound.WithLabelValues(jobSpecID), roundState.ReportableRoundID)
@@ -875,10 +1042,10 @@ type jobRunRequest struct {
 	DataPrefix       string          `json:"dataPrefix"`
 }
 
-func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, roundID uint32) error {
+func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, roundID uint32) (*models.ID, error) {
 	methodID, err := p.fluxAggregator.GetMethodID("submit")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	roundIDData := utils.EVMWordUint64(uint64(roundID))
@@ -890,18 +1057,19 @@ func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, rou
 		DataPrefix:       hexutil.Encode(roundIDData),
 	})
 	if err != nil {
-		return errors.Wrapf(err, "unable to encode Job Run request in JSON")
+		return nil, errors.Wrapf(err, "unable to encode Job Run request in JSON")
 	}
 	runData, err := models.ParseJSON(payload)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("unable to start chainlink run with payload %s", payload))
+		return nil, errors.Wrap(err, fmt.Sprintf("unable to start chainlink run with payload %s", payload))
 	}
 	runRequest := models.NewRunRequest(runData)
 
-	_, err = p.runManager.Create(p.initr.JobSpecID, &p.initr, nil, runRequest)
+	run, err := p.runManager.Create(p.initr.JobSpecID, &p.initr, nil, runRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	p.resetHeartbeatTimer()
 
 	p.mostRecentSubmittedRoundID = roundID
 
@@ -912,10 +1080,133 @@ func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, rou
 			"roundID", roundID,
 			"jobID", p.initr.JobSpecID.String(),
 		)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return run.ID, nil
+}
+
+// roundHistoryPruneInterval is how often the decisions of a checker older
+// than FLUX_MONITOR_ROUND_HISTORY are removed.
+const roundHistoryPruneInterval = time.Hour
+
+// recordRoundEvent saves a decision of the checker, so that the round
+// history of the job explains why rounds were or were not answered.
+func (p *PollingDeviationChecker) recordRoundEvent(event models.FluxMonitorRoundEvent) {
+	event.JobSpecID = p.initr.JobSpecID
+	event.Aggregator = p.initr.Address
+	event.CreatedAt = time.Now()
+	if err := p.store.CreateFluxMonitorRoundEvent(&event); err != nil {
+		logger.Errorw(fmt.Sprintf("error recording flux monitor round history: %v", err), p.loggerFields("roundID", event.RoundID)...)
+	}
+
+	retention := p.store.Config.FluxMonitorRoundHistory().Duration()
+	if retention == 0 || time.Since(p.historyPrunedAt) < roundHistoryPruneInterval {
+		return
+	}
+	p.historyPrunedAt = time.Now()
+	if err := p.store.DeleteFluxMonitorRoundEventsBefore(p.initr.JobSpecID, time.Now().Add(-retention)); err != nil {
+		logger.Errorw(fmt.Sprintf("error pruning flux monitor round history: %v", err), p.loggerFields()...)
+	}
+}
+
+func (p *PollingDeviationChecker) recordRoundError(event models.FluxMonitorRoundEvent, reason string, err error) {
+	event.Outcome = models.FluxMonitorOutcomeErrored
+	event.Reason = fmt.Sprintf("%s: %v", reason, err)
+	p.recordRoundEvent(event)
+}
+
+// guardAnswer returns false, after recording why, if the outlier guard of the
+// job refuses to submit the polled answer of the event. Answers outside of
+// the band are skipped, while answers deviating from the latest answer by
+// more than the circuit breaker are held, unless an operator approved a held
+// answer within the circuit breaker of it. That approval is returned, so that
+// it is only used up once the answer is submitted.
+func (p *PollingDeviationChecker) guardAnswer(event models.FluxMonitorRoundEvent) (*models.FluxMonitorRoundEvent, bool) {
+	guard := p.initr.OutlierGuard
+	answer := event.PolledAnswer.Decimal
+	loggerFields := p.loggerFields("roundID", event.RoundID, "polledAnswer", answer)
+
+	if guard.MinAnswer != nil && answer.LessThan(*guard.MinAnswer) {
+		event.Reason = fmt.Sprintf("answer %s is below minAnswer %s", answer, guard.MinAnswer)
+	} else if guard.MaxAnswer != nil && answer.GreaterThan(*guard.MaxAnswer) {
+		event.Reason = fmt.Sprintf("answer %s is above maxAnswer %s", answer, guard.MaxAnswer)
+	}
+	if event.Reason != "" {
+		logger.Errorw(fmt.Sprintf("Outlier guard refused to submit: %s", event.Reason), loggerFields...)
+		event.Outcome = models.FluxMonitorOutcomeSkipped
+		p.recordRoundEvent(event)
+		return nil, false
+	}
+
+	latestAnswer := event.LatestAnswer.Decimal
+	if guard.CircuitBreaker == 0 || !event.LatestAnswer.Valid || latestAnswer.IsZero() {
+		return nil, true
+	}
+	circuitBreaker := decimal.NewFromFloat(float64(guard.CircuitBreaker))
+	jump := percentDeviation(latestAnswer, answer)
+	if !jump.GreaterThan(circuitBreaker) {
+		return nil, true
+	}
+
+	approvedSince := time.Now().Add(-p.approvalMaxAge())
+	approvals, err := p.store.FluxMonitorApprovals(p.initr.JobSpecID, p.initr.Address, approvedSince)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("error loading approvals of held answers: %v", err), loggerFields...)
+	}
+	for i := range approvals {
+		approved := approvals[i].PolledAnswer
+		if approved.Valid && !approved.Decimal.IsZero() &&
+			!percentDeviation(approved.Decimal, answer).GreaterThan(circuitBreaker) {
+			return &approvals[i], true
+		}
+	}
+
+	event.Outcome = models.FluxMonitorOutcomeHeld
+	event.Reason = fmt.Sprintf(
+		"answer %s deviates by %s%% from the latest answer %s, above the circuit breaker of %s%%",
+		answer, jump.StringFixed(2), latestAnswer, circuitBreaker)
+	logger.Errorw(fmt.Sprintf("Outlier guard held answer until an operator approves it: %s", event.Reason), loggerFields...)
+	promFMHeldAnswers.WithLabelValues(p.initr.JobSpecID.String()).Inc()
+	p.recordRoundEvent(event)
+	return nil, false
+}
+
+// approvalMaxAge is how long an approval of a held answer can let an answer
+// through the outlier guard: two of the longest period the feeds are checked
+// at, so that the checks following the approval can use it, but no answer long
+// after them.
+func (p *PollingDeviationChecker) approvalMaxAge() time.Duration {
+	period := p.initr.PollTimer.Period.Duration()
+	if !p.initr.IdleTimer.Disabled && (p.initr.PollTimer.Disabled || p.initr.IdleTimer.Duration.Duration() > period) {
+		period = p.initr.IdleTimer.Duration.Duration()
+	}
+	return 2 * period
+}
+
+// useApproval records that the approval of a held answer, if any, let the
+// answer of the event through the outlier guard.
+func (p *PollingDeviationChecker) useApproval(event *models.FluxMonitorRoundEvent, approval *models.FluxMonitorRoundEvent) {
+	if approval == nil {
+		return
+	}
+	event.Reason = fmt.Sprintf("approved by an operator, see held round event %d", approval.ID)
+	if err := p.store.UseFluxMonitorApproval(approval.ID); err != nil {
+		logger.Errorw(fmt.Sprintf("error using approval of held answer: %v", err), p.loggerFields("roundID", event.RoundID)...)
+	}
+}
+
+// percentDeviation returns the deviation of the answer from a nonzero
+// previous answer, in percent.
+func percentDeviation(from, to decimal.Decimal) decimal.Decimal {
+	return to.Sub(from).Div(from).Abs().Mul(decimal.NewFromInt(100))
+}
+
+func (p *PollingDeviationChecker) answerDecimal(answer *big.Int) decimal.NullDecimal {
+	if answer == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: decimal.NewFromBigInt(answer, -p.precision), Valid: true}
 }
 
 func (p *PollingDeviationChecker) loggerFields(added ...interface{}) []interface{} {
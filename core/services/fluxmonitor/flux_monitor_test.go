@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -146,6 +149,57 @@ func TestConcreteFluxMonitor_AddJobRemoveJob(t *testing.T) {
 	})
 }
 
+func TestPollingDeviationCheckerFactory_MultipleAggregators(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.Aggregators = models.AddressCollection{cltest.NewAddress(), cltest.NewAddress()}
+	rm := new(mocks.RunManager)
+	logBroadcaster := ethsvc.NewLogBroadcaster(store)
+
+	checker, err := fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster).New(initr, nil, rm, store.ORM, store.Config.DefaultHTTPTimeout())
+	require.NoError(t, err)
+	checkers := fluxmonitor.ExportedAggregatorCheckers(checker)
+	require.Len(t, checkers, 3)
+
+	for i, address := range initr.FluxAggregators() {
+		assert.Equal(t, address, checkers[i].ExportedInitiator().Address)
+		assert.Equal(t, initr.ID, checkers[i].ExportedInitiator().ID)
+		assert.Same(t, checkers[0].ExportedFetcher(), checkers[i].ExportedFetcher(), "aggregators should share the answers of one poll")
+	}
+	assert.NotSame(t, checkers[0].ExportedFluxAggregator(), checkers[1].ExportedFluxAggregator())
+
+	initr.Aggregators = nil
+	checker, err = fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster).New(initr, nil, rm, store.ORM, store.Config.DefaultHTTPTimeout())
+	require.NoError(t, err)
+	require.IsType(t, &fluxmonitor.PollingDeviationChecker{}, checker)
+}
+
+func TestPollingDeviationCheckerFactory_InvalidInitiator(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.Aggregators = models.AddressCollection{cltest.NewAddress()}
+	initr.ThresholdSchedule = models.ThresholdSchedule{
+		{Days: []string{"sat"}, Timezone: "Nowhere/Invalid", Threshold: 2},
+	}
+
+	// No dependents are added for checkers which failed to be created, which
+	// the log broadcaster would wait for forever
+	logBroadcaster := new(mocks.LogBroadcaster)
+	_, err := fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster).New(initr, nil, new(mocks.RunManager), store.ORM, store.Config.DefaultHTTPTimeout())
+	require.Error(t, err)
+	logBroadcaster.AssertNotCalled(t, "AddDependents", mock.Anything)
+}
+
 func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -534,6 +588,83 @@ func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 	}
 }
 
+func TestPollingDeviationChecker_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	nodeAddr := ensureAccount(t, store)
+
+	fetcher := new(mocks.Fetcher)
+	rm := new(mocks.RunManager)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.PollTimer.Disabled = true
+	initr.IdleTimer.Disabled = true
+	initr.Heartbeat = models.MustMakeDuration(100 * time.Millisecond)
+
+	const answer = 100
+	answerBigInt := big.NewInt(answer * int64(math.Pow10(int(initr.InitiatorParams.Precision))))
+	minPayment := store.Config.MinimumContractPayment().ToInt()
+
+	fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(true, ethsvc.UnsubscribeFunc(func() {}), nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	fluxAggregator.On("RoundState", nodeAddr, uint32(0)).Return(contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      answerBigInt,
+		AvailableFunds:    big.NewInt(1).Mul(big.NewInt(10000), minPayment),
+		PaymentAmount:     minPayment,
+		OracleCount:       oracleCount,
+	}, nil)
+	// The answer has not deviated, but is submitted on every heartbeat
+	fetcher.On("Fetch").Return(decimal.NewFromInt(answer), nil)
+
+	submissions := make(chan struct{}, 10)
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Run(func(mock.Arguments) {
+		submissions <- struct{}{}
+	})
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, nil, rm, fetcher, func() {})
+	require.NoError(t, err)
+
+	checker.OnConnect()
+	checker.Start()
+	require.Len(t, submissions, 0, "should not submit before the first heartbeat")
+	require.Eventually(t, func() bool { return len(submissions) >= 2 }, 3*time.Second, 10*time.Millisecond)
+	checker.Stop()
+
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_ThresholdSchedule(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.Threshold = 0.5
+	initr.AbsoluteThreshold = 0.01
+	initr.ThresholdSchedule = models.ThresholdSchedule{
+		{Days: []string{"sat", "sun"}, Threshold: 2, AbsoluteThreshold: 1},
+	}
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, new(mocks.FluxAggregator), initr, nil, new(mocks.RunManager), new(mocks.Fetcher), func() {})
+	require.NoError(t, err)
+
+	saturday := time.Date(2020, 6, 27, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, fluxmonitor.DeviationThresholds{Rel: 2, Abs: 1}, checker.ExportedThresholds(saturday))
+	monday := time.Date(2020, 6, 29, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, fluxmonitor.DeviationThresholds{Rel: 0.5, Abs: float64(float32(0.01))}, checker.ExportedThresholds(monday))
+}
+
 func TestPollingDeviationChecker_RoundTimeoutCausesPoll_timesOutAtZero(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -933,6 +1064,11 @@ func TestExtractFeedURLs(t *testing.T) {
 			`["https://lambda.staging.devnet.tools/bnc/call", {"bridge": "testbridge"}]`,
 			[]string{"https://lambda.staging.devnet.tools/bnc/call", "https://testing.com/bridges"},
 		},
+		{
+			"pipeline",
+			`["https://lambda.staging.devnet.tools/bnc/call", {"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}]}]`,
+			[]string{"https://lambda.staging.devnet.tools/bnc/call"},
+		},
 		{
 			"empty",
 			`[]`,
@@ -956,6 +1092,262 @@ func TestExtractFeedURLs(t *testing.T) {
 	}
 }
 
+func TestExtractFeedPipelines(t *testing.T) {
+	t.Parallel()
+
+	feeds := cltest.JSONFromString(t, `[
+		"https://lambda.staging.devnet.tools/bnc/call",
+		{"bridge": "testbridge"},
+		{"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}, {"type": "jsonparse", "params": {"path": ["last"]}}]}
+	]`)
+	pipelines, err := fluxmonitor.ExtractFeedPipelines(feeds)
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	require.Len(t, pipelines[0].Tasks, 2)
+	assert.Equal(t, models.MustNewTaskType("httpget"), pipelines[0].Tasks[0].Type)
+	assert.Equal(t, models.MustNewTaskType("jsonparse"), pipelines[0].Tasks[1].Type)
+
+	_, err = fluxmonitor.ExtractFeedPipelines(cltest.JSONFromString(t, `[{"tasks": []}]`))
+	assert.Error(t, err)
+}
+
+func TestPipelineFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": {"price": "1.5"}}`)
+	defer assertCalled()
+
+	feeds := cltest.JSONFromString(t, fmt.Sprintf(`[{"tasks": [
+		{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}},
+		{"type": "jsonparse", "params": {"path": ["last", "price"]}},
+		{"type": "multiply", "params": {"times": 100}}
+	]}]`, mock.URL))
+	pipelines, err := fluxmonitor.ExtractFeedPipelines(feeds)
+	require.NoError(t, err)
+
+	price, err := fluxmonitor.ExportedNewPipelineFetcher(store, pipelines[0]).Fetch()
+	require.NoError(t, err)
+	assert.True(t, price.Equal(decimal.NewFromInt(150)), "got %s", price)
+
+	// Runs of feed pipelines are not saved
+	count, err := store.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestPipelineFetcher_Fetch_Error(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": {}}`)
+	defer assertCalled()
+
+	feeds := cltest.JSONFromString(t, fmt.Sprintf(`[{"tasks": [
+		{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}},
+		{"type": "jsonparse", "params": {"path": ["first", "price"]}}
+	]}]`, mock.URL))
+	pipelines, err := fluxmonitor.ExtractFeedPipelines(feeds)
+	require.NoError(t, err)
+
+	_, err = fluxmonitor.ExportedNewPipelineFetcher(store, pipelines[0]).Fetch()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pipeline price fetcher: httpgetwithunrestrictednetworkaccess,jsonparse returned error")
+}
+
+func TestPipelineFetcher_Fetch_RetryFailsFast(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	feeds := cltest.JSONFromString(t, fmt.Sprintf(`[{"tasks": [
+		{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}, "retry": {"maxAttempts": 3, "initialBackoff": "1h"}}
+	]}]`, server.URL))
+	pipelines, err := fluxmonitor.ExtractFeedPipelines(feeds)
+	require.NoError(t, err)
+
+	// The fetch fails rather than waiting for the task to be retried
+	_, err = fluxmonitor.ExportedNewPipelineFetcher(store, pipelines[0]).Fetch()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returned error")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestPollingDeviationChecker_RecordsRoundHistory(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	initr := job.Initiators[0]
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	minPayment := store.Config.MinimumContractPayment().ToInt()
+	latestAnswer := 100 * int64(math.Pow10(int(initr.InitiatorParams.Precision)))
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(latestAnswer),
+		AvailableFunds:    big.NewInt(1).Mul(big.NewInt(10000), minPayment),
+		PaymentAmount:     minPayment,
+		OracleCount:       oracleCount,
+	}
+	fluxAggregator.On("RoundState", nodeAddr, uint32(0)).Return(roundState, nil).Once()
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil).Once()
+
+	ineligible := roundState
+	ineligible.ReportableRoundID = 3
+	ineligible.EligibleToSubmit = false
+	fluxAggregator.On("RoundState", nodeAddr, uint32(0)).Return(ineligible, nil).Once()
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, nil, rm, fetcher, func() {})
+	require.NoError(t, err)
+
+	checker.ExportedPollIfEligible(0.1, 200)
+	checker.OnConnect()
+	checker.ExportedPollIfEligible(0.1, 200)
+	checker.ExportedPollIfEligible(0.1, 200)
+
+	events, count, err := store.FluxMonitorRoundEvents(job.ID, utils.ZeroAddress, 0, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	for _, event := range events {
+		assert.Equal(t, job.ID, event.JobSpecID)
+		assert.Equal(t, initr.Address, event.Aggregator)
+		assert.Equal(t, models.FluxMonitorTriggerPoll, event.Trigger)
+	}
+
+	// Newest first
+	assert.Equal(t, models.FluxMonitorOutcomeSkipped, events[0].Outcome)
+	assert.Equal(t, uint32(3), events[0].RoundID)
+	assert.Equal(t, fluxmonitor.ErrNotEligible.Error(), events[0].Reason)
+
+	assert.Equal(t, models.FluxMonitorOutcomeBelowThreshold, events[1].Outcome)
+	assert.Equal(t, uint32(2), events[1].RoundID)
+	assert.True(t, events[1].LatestAnswer.Decimal.Equal(decimal.NewFromInt(100)))
+	assert.True(t, events[1].PolledAnswer.Decimal.Equal(decimal.NewFromInt(100)))
+
+	assert.Equal(t, models.FluxMonitorOutcomeSkipped, events[2].Outcome)
+	assert.Equal(t, "not connected to Ethereum node", events[2].Reason)
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_OutlierGuard(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	job.Initiators[0].OutlierGuard = models.OutlierGuardConfig{
+		MaxAnswer:      decimalPtr(decimal.NewFromInt(1000)),
+		CircuitBreaker: 50,
+	}
+	require.NoError(t, store.CreateJob(&job))
+	initr := job.Initiators[0]
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	minPayment := store.Config.MinimumContractPayment().ToInt()
+	fluxAggregator.On("RoundState", nodeAddr, uint32(0)).Return(contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(100 * int64(math.Pow10(int(initr.InitiatorParams.Precision)))),
+		AvailableFunds:    big.NewInt(1).Mul(big.NewInt(10000), minPayment),
+		PaymentAmount:     minPayment,
+		OracleCount:       oracleCount,
+	}, nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once()
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, nil, rm, fetcher, func() {})
+	require.NoError(t, err)
+	checker.OnConnect()
+
+	latestEvent := func() models.FluxMonitorRoundEvent {
+		events, _, err := store.FluxMonitorRoundEvents(job.ID, utils.ZeroAddress, 0, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		return events[0]
+	}
+
+	// Above maxAnswer
+	fetcher.On("Fetch").Return(decimal.NewFromInt(1001), nil).Once()
+	assert.False(t, checker.ExportedPollIfEligible(0.1, 0))
+	assert.Equal(t, models.FluxMonitorOutcomeSkipped, latestEvent().Outcome)
+	assert.Equal(t, "answer 1001 is above maxAnswer 1000", latestEvent().Reason)
+
+	// Jumps by more than the circuit breaker
+	fetcher.On("Fetch").Return(decimal.NewFromInt(200), nil).Once()
+	assert.False(t, checker.ExportedPollIfEligible(0.1, 0))
+	held := latestEvent()
+	assert.Equal(t, models.FluxMonitorOutcomeHeld, held.Outcome)
+	assert.Equal(t, "answer 200 deviates by 100.00% from the latest answer 100, above the circuit breaker of 50%", held.Reason)
+
+	// Submitted once approved
+	require.NoError(t, store.ApproveFluxMonitorRoundEvent(&held))
+	fetcher.On("Fetch").Return(decimal.NewFromInt(201), nil).Once()
+	assert.True(t, checker.ExportedPollIfEligible(0.1, 0))
+	submitted := latestEvent()
+	assert.Equal(t, models.FluxMonitorOutcomeSubmitted, submitted.Outcome)
+	assert.Equal(t, fmt.Sprintf("approved by an operator, see held round event %d", held.ID), submitted.Reason)
+
+	// Approvals are only used once
+	approvals, err := store.FluxMonitorApprovals(job.ID, initr.Address, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, approvals, 0)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(202), nil).Once()
+	assert.False(t, checker.ExportedPollIfEligible(0.1, 0))
+	held = latestEvent()
+	assert.Equal(t, models.FluxMonitorOutcomeHeld, held.Outcome)
+
+	// Approvals expire after two of the longest of the poll period and idle
+	// duration
+	require.NoError(t, store.ApproveFluxMonitorRoundEvent(&held))
+	require.NoError(t, store.GetRawDB().Model(&held).UpdateColumn("approved_at", time.Now().Add(-3*time.Minute)).Error)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(203), nil).Once()
+	assert.False(t, checker.ExportedPollIfEligible(0.1, 0))
+	assert.Equal(t, models.FluxMonitorOutcomeHeld, latestEvent().Outcome)
+
+	// Within the circuit breaker
+	fetcher.On("Fetch").Return(decimal.NewFromInt(150), nil).Once()
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once()
+	assert.True(t, checker.ExportedPollIfEligible(0.1, 0))
+	assert.Equal(t, models.FluxMonitorOutcomeSubmitted, latestEvent().Outcome)
+
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
 func TestPollingDeviationChecker_SufficientPayment(t *testing.T) {
 	t.Parallel()
 
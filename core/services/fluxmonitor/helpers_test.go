@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
@@ -24,8 +26,27 @@ func ExportedSetCheckerFactory(fm Service, fac DeviationCheckerFactory) {
 	impl.checkerFactory = fac
 }
 
+func ExportedNewCheckerFactory(store *store.Store, logBroadcaster eth.LogBroadcaster) DeviationCheckerFactory {
+	return pollingDeviationCheckerFactory{store: store, logBroadcaster: logBroadcaster}
+}
+
+func ExportedAggregatorCheckers(checker DeviationChecker) []*PollingDeviationChecker {
+	if checkers, ok := checker.(aggregatorCheckers); ok {
+		return checkers
+	}
+	return []*PollingDeviationChecker{checker.(*PollingDeviationChecker)}
+}
+
+func ExportedNewPipelineFetcher(store *store.Store, pipeline models.FeedPipeline) Fetcher {
+	return newPipelineFetcher(store, pipeline)
+}
+
 func (p *PollingDeviationChecker) ExportedPollIfEligible(threshold, absoluteThreshold float64) bool {
-	return p.pollIfEligible(DeviationThresholds{Rel: threshold, Abs: absoluteThreshold})
+	return p.pollIfEligible(models.FluxMonitorTriggerPoll, DeviationThresholds{Rel: threshold, Abs: absoluteThreshold})
+}
+
+func (p *PollingDeviationChecker) ExportedThresholds(t time.Time) DeviationThresholds {
+	return p.thresholds(t)
 }
 
 func (p *PollingDeviationChecker) ExportedRespondToNewRoundLog(log *contracts.LogNewRound) {
@@ -52,6 +73,14 @@ func (p *PollingDeviationChecker) ExportedFluxAggregator() contracts.FluxAggrega
 	return p.fluxAggregator
 }
 
+func (p *PollingDeviationChecker) ExportedInitiator() models.Initiator {
+	return p.initr
+}
+
+func (p *PollingDeviationChecker) ExportedFetcher() Fetcher {
+	return p.fetcher
+}
+
 func mustReadFile(t testing.TB, file string) string {
 	t.Helper()
 
@@ -127,5 +156,6 @@ func (fm *concreteFluxMonitor) CreateJob(t *testing.T,
 	checker, err := fm.checkerFactory.New(jobSpec.Initiators[0], nil, fm.runManager,
 		fm.store.ORM, models.MustMakeDuration(100*time.Second))
 	require.NoError(t, err, "could not create deviation checker")
-	return checker.(*PollingDeviationChecker).createJobRun(polledAnswer, uint32(nextRound.Uint64()))
+	_, err = checker.(*PollingDeviationChecker).createJobRun(polledAnswer, uint32(nextRound.Uint64()))
+	return err
 }
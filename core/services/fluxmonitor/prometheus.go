@@ -46,6 +46,13 @@ var (
 		},
 		[]string{"job_spec_id"},
 	)
+	promFMHeldAnswers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_held_answers",
+			Help: "Answers held by the outlier guard of the flux monitor until approved by an operator",
+		},
+		[]string{"job_spec_id"},
+	)
 	promFMResponseTime = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "flux_monitor_request_duration_seconds",
@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,10 +30,19 @@ var (
 	},
 		[]string{"percentile", "block_num"},
 	)
+
+	promGasUpdaterSetTipCap = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_updater_set_tip_cap",
+		Help: "Gas updater set tip of dynamic fee transactions (in Wei)",
+	},
+		[]string{"percentile"},
+	)
 )
 
 // GasUpdater listens for new heads and updates the base gas price dynamically
-// based on the configured percentile of gas prices in that block
+// based on the configured percentile of gas prices in that block. When the
+// node sends dynamic fee transactions, it also updates their default tip
+// based on the same percentile of the tips paid in those blocks.
 type GasUpdater interface {
 	store.HeadTrackable
 	RollingBlockHistory() []eth.Block
@@ -107,6 +117,9 @@ func (gu *gasUpdater) OnNewLongestChain(head models.Head) {
 				return
 			}
 			promGasUpdaterSetGasPrice.WithLabelValues(fmt.Sprintf("%v%%", gu.percentile), string(blockToFetch)).Set(float64(percentileGasPrice))
+			if gu.store.Config.EthTxType() == orm.EthTxTypeDynamicFee {
+				gu.updateTipCap()
+			}
 		} else {
 			logger.Debugw("GasUpdater: waiting for blocks", "inHistory", len(gu.rollingBlockHistory), "required", gu.rollingBlockHistorySize)
 		}
@@ -141,6 +154,38 @@ func (gu *gasUpdater) setPercentileGasPrice(gasPrice int64) error {
 	return gu.store.Config.SetEthGasPriceDefault(bigGasPrice)
 }
 
+// percentileTipCap returns the configured percentile of the tips paid in
+// the rolling block history, and false if none of its blocks has a base fee.
+func (gu *gasUpdater) percentileTipCap() (*big.Int, bool) {
+	tips := make([]*big.Int, 0)
+	for _, block := range gu.rollingBlockHistory {
+		tips = append(tips, block.TipCaps()...)
+	}
+	if len(tips) == 0 {
+		return nil, false
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return tips[((len(tips)-1)*gu.percentile)/100], true
+}
+
+func (gu *gasUpdater) updateTipCap() {
+	tipCap, ok := gu.percentileTipCap()
+	if !ok {
+		logger.Debugw("GasUpdater: no tips to estimate from, blocks have no base fee")
+		return
+	}
+	if tipCap.Cmp(gu.store.Config.EthGasFeeCapDefault()) > 0 {
+		logger.Errorf("GasUpdater: cannot set tip %s because it exceeds EthGasFeeCapDefault %s", tipCap.String(), gu.store.Config.EthGasFeeCapDefault().String())
+		return
+	}
+	logger.Debugw("GasUpdater: setting new default tip", "gasTipCapWei", tipCap)
+	if err := gu.store.Config.SetEthGasTipCapDefault(tipCap); err != nil {
+		logger.Error("GasUpdater error setting tip: ", err)
+		return
+	}
+	promGasUpdaterSetTipCap.WithLabelValues(fmt.Sprintf("%v%%", gu.percentile)).Set(float64(tipCap.Int64()))
+}
+
 func (gu *gasUpdater) RollingBlockHistory() []eth.Block {
 	return gu.rollingBlockHistory
 }
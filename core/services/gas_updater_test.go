@@ -9,6 +9,8 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -171,3 +173,35 @@ func TestGasUpdater_OnNewLongestChain_WillNotSetGasHigherThanEthMaxGasPriceWei(t
 
 	assert.Equal(t, big.NewInt(42), config.EthGasPriceDefault())
 }
+
+func TestGasUpdater_OnNewLongestChain_SetsGlobalTipCapForDynamicFeeTxs(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "0")
+	config.Set("GAS_UPDATER_TRANSACTION_PERCENTILE", "50")
+	config.Set("GAS_UPDATER_BLOCK_HISTORY_SIZE", "1")
+	config.Set("ETH_TX_TYPE", "dynamicfee")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 7)
+	config.Set("ETH_GAS_FEE_CAP_DEFAULT", 1000)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	config.SetRuntimeStore(store.ORM)
+	defer cleanup()
+	txm := new(mocks.TxManager)
+	store.TxManager = txm
+	gu := services.NewGasUpdater(store)
+
+	txm.On("GetBlockByNumber", "0x0").Return(cltest.BlockWithTransactions(90), nil)
+	head := cltest.Head(0)
+	gu.OnNewLongestChain(*head)
+	assert.Equal(t, big.NewInt(7), config.EthGasTipCapDefault())
+
+	block := cltest.BlockWithTransactions(110, 120, 130)
+	block.BaseFeePerGas = (*hexutil.Big)(big.NewInt(100))
+	txm.On("GetBlockByNumber", "0x1").Return(block, nil)
+	head = cltest.Head(1)
+	gu.OnNewLongestChain(*head)
+
+	assert.Equal(t, big.NewInt(120), config.EthGasPriceDefault())
+	assert.Equal(t, big.NewInt(20), config.EthGasTipCapDefault())
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
@@ -14,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tidwall/gjson"
 )
 
 var (
@@ -35,6 +37,14 @@ type RunExecutor interface {
 type runExecutor struct {
 	store       *store.Store
 	statsPusher synchronization.StatsPusher
+	// inMemory is set for runs which are not saved, such as simulated runs.
+	// Their Ethereum transactions are recorded instead of sent, and the time
+	// spent performing each task is kept in elapsed.
+	inMemory bool
+	elapsed  []time.Duration
+	// failFast errors tasks which would otherwise sleep until they are
+	// retried, for callers which cannot wait.
+	failFast bool
 }
 
 // NewRunExecutor initializes a RunExecutor.
@@ -45,70 +55,223 @@ func NewRunExecutor(store *store.Store, statsPusher synchronization.StatsPusher)
 	}
 }
 
-// Execute performs the work associate with a job run
+// Execute performs the work associate with a job run. Tasks whose parents
+// have all completed are performed concurrently, and their outputs are
+// applied to the run in the order the tasks appear in the job spec.
+//
+// Tasks that fail with an error their retry policy allows are put to sleep,
+// and the run is left pending until RunManager.ResumeAllPendingSleep
+// requeues it once their backoff has elapsed.
 func (re *runExecutor) Execute(runID *models.ID) error {
 	run, err := re.store.Unscoped().FindJobRun(runID)
 	if err != nil {
 		return errors.Wrapf(err, "error finding run %s", runID)
 	}
 
-	for taskIndex := range run.TaskRuns {
-		taskRun := &run.TaskRuns[taskIndex]
-		if !run.GetStatus().Runnable() {
-			logger.Debugw("Run execution blocked", run.ForLogger("task", taskRun.ID.String())...)
-			break
+	if run.GetStatus().PendingSleep() {
+		if retryAt, ok := run.RetryAt(); ok && retryAt.After(re.store.Clock.Now()) {
+			return nil
 		}
+		run.SetStatus(models.RunStatusInProgress)
+	}
 
-		if taskRun.Status.Completed() {
-			continue
+	if err := re.executeRunnable(&run); err != nil {
+		return err
+	}
+
+	if run.GetStatus().PendingSleep() {
+		retryAt, _ := run.RetryAt()
+		logger.Debugw("Run sleeping until next task retry", run.ForLogger("retryAt", retryAt)...)
+	} else if run.GetStatus().Finished() {
+		if run.GetStatus().Errored() {
+			logger.Warnw("Task failed", run.ForLogger()...)
+		} else {
+			logger.Debugw("All tasks complete for run", run.ForLogger()...)
 		}
+	}
+	return nil
+}
 
-		if meetsMinRequiredIncomingConfirmations(&run, taskRun, run.ObservedHeight) {
-			start := time.Now()
+// Simulation is the outcome of performing the tasks of a job without
+// persisting a run or sending any transactions.
+type Simulation struct {
+	Run models.JobRun
+	// Elapsed is the time spent performing each of the run's TaskRuns.
+	Elapsed []time.Duration
+}
 
-			// NOTE: adapters may define and return the new job run status in here
-			result := re.executeTask(&run, *taskRun)
+// SimulateJobRun performs the tasks of a job with the given input through
+// the same executor as real runs. The run is kept in memory, and EthTx and
+// EthTxABIEncode tasks return the transaction they would have sent instead
+// of sending it. Incoming confirmations are not waited for, and tasks which
+// would be retried after a backoff are left pending_sleep with the time of
+// their next attempt, rather than being waited for.
+func SimulateJobRun(store *store.Store, job models.JobSpec, input models.JSON) (Simulation, error) {
+	run := newInMemoryRun(store, job, input)
+	re := &runExecutor{store: store, inMemory: true, elapsed: make([]time.Duration, len(run.TaskRuns))}
+	if err := re.executeRunnable(run); err != nil {
+		return Simulation{}, err
+	}
+	return Simulation{Run: *run, Elapsed: re.elapsed}, nil
+}
 
-			taskRun.ApplyOutput(result)
-			run.ApplyOutput(result)
+// ExecutePipeline performs the tasks of a job with the given input in
+// memory, such as those of a flux monitor feed pipeline, and returns the
+// run. Like SimulateJobRun, Ethereum transactions are recorded instead of
+// sent, but tasks which would be retried after a backoff error the run
+// instead, so that callers are not held up.
+func ExecutePipeline(store *store.Store, job models.JobSpec, input models.JSON) (models.JobRun, error) {
+	run := newInMemoryRun(store, job, input)
+	re := &runExecutor{store: store, inMemory: true, failFast: true}
+	if err := re.executeRunnable(run); err != nil {
+		return models.JobRun{}, err
+	}
+	return *run, nil
+}
+
+func newInMemoryRun(store *store.Store, job models.JobSpec, input models.JSON) *models.JobRun {
+	initiator := models.Initiator{Type: models.InitiatorWeb}
+	if len(job.Initiators) > 0 {
+		initiator = job.Initiators[0]
+	}
+	run, _ := NewRun(&job, &initiator, nil, models.NewRunRequest(input), store.Config, store.ORM, store.Clock.Now())
+	return run
+}
 
-			elapsed := time.Since(start).Seconds()
+func (re *runExecutor) executeRunnable(run *models.JobRun) error {
+	for run.GetStatus().Runnable() {
+		indexes := run.RunnableTaskRunIndexes()
+		if len(indexes) == 0 {
+			break
+		}
 
-			logger.Debugw(fmt.Sprintf("Executed task %s", taskRun.TaskSpec.Type), run.ForLogger("task", taskRun.ID.String(), "elapsed", elapsed)...)
+		now := re.store.Clock.Now()
+		var executable []int
+		for _, index := range indexes {
+			taskRun := &run.TaskRuns[index]
+			if taskRun.Status.PendingSleep() && taskRun.RetryAt.Valid && taskRun.RetryAt.Time.After(now) {
+				run.SetStatus(models.RunStatusPendingSleep)
+				continue
+			}
+			if re.inMemory || meetsMinRequiredIncomingConfirmations(run, taskRun, run.ObservedHeight) {
+				executable = append(executable, index)
+				continue
+			}
 
-		} else {
 			logger.Debugw("Pausing run pending incoming confirmations",
 				run.ForLogger("required_height", taskRun.MinRequiredIncomingConfirmations)...,
 			)
 			taskRun.Status = models.RunStatusPendingIncomingConfirmations
 			run.SetStatus(models.RunStatusPendingIncomingConfirmations)
+		}
+
+		results := re.executeTasks(run, executable)
+		for i, index := range executable {
+			taskRun := &run.TaskRuns[index]
+			output := results[i].output
+			logger.Debugw(fmt.Sprintf("Executed task %s", taskRun.TaskSpec.Type), run.ForLogger("task", taskRun.ID.String(), "elapsed", results[i].elapsed)...)
+			if re.elapsed != nil {
+				re.elapsed[index] += time.Duration(results[i].elapsed * float64(time.Second))
+			}
+
+			if retryAt, ok := output.RetryAt(); ok && re.failFast {
+				output = models.NewRunOutputError(output.Error())
+			} else if ok {
+				logger.Infow(fmt.Sprintf("Task %s postponed until %s", taskRun.TaskSpec.Type, retryAt),
+					run.ForLogger("task", taskRun.ID.String(), "error", output.Error())...)
+				taskRun.Postpone(output.Error(), retryAt)
+				if run.GetStatus().Runnable() {
+					run.SetStatus(models.RunStatusPendingSleep)
+				}
+				continue
+			}
 
+			retry := taskRun.TaskSpec.Retry
+			if output.HasError() && !re.failFast && retry.ShouldRetry(taskRun.Retries, output.Error()) {
+				backoff := retry.Backoff(taskRun.Retries)
+				logger.Infow(fmt.Sprintf("Task %s failed, retrying in %s", taskRun.TaskSpec.Type, backoff),
+					run.ForLogger("task", taskRun.ID.String(), "attempt", taskRun.Retries+1, "error", output.Error())...)
+				taskRun.ScheduleRetry(output.Error(), now.Add(backoff))
+				if run.GetStatus().Runnable() {
+					run.SetStatus(models.RunStatusPendingSleep)
+				}
+				continue
+			}
+
+			// NOTE: adapters may define and return the new job run status in here
+			taskRun.ApplyOutput(output)
+			if run.GetStatus().Runnable() {
+				run.ApplyOutput(output)
+			}
 		}
 
-		if err := re.store.ORM.SaveJobRun(&run); errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
-			logger.Debugw("Optimistic update conflict while updating run", run.ForLogger()...)
-			return nil
-		} else if err != nil {
+		if err := re.saveJobRun(run); err != nil {
 			return err
 		}
-
-		re.statsPusher.PushNow()
 	}
 
-	if run.GetStatus().Finished() {
-		if run.GetStatus().Errored() {
-			logger.Warnw("Task failed", run.ForLogger()...)
+	// Skipped tasks or a concurrently pending bridge may leave the run in
+	// progress with nothing left to execute.
+	if next := run.NextTaskRun(); run.GetStatus() == models.RunStatusInProgress && (next == nil || next.Status.PendingBridge()) {
+		if next == nil {
+			run.SetStatus(models.RunStatusCompleted)
 		} else {
-			logger.Debugw("All tasks complete for run", run.ForLogger()...)
+			run.SetStatus(models.RunStatusPendingBridge)
 		}
+		return re.saveJobRun(run)
 	}
 	return nil
 }
 
-func (re *runExecutor) executeTask(run *models.JobRun, taskRun models.TaskRun) models.RunOutput {
+func (re *runExecutor) saveJobRun(run *models.JobRun) error {
+	if re.inMemory {
+		return nil
+	}
+	if err := re.store.ORM.SaveJobRun(run); errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+		logger.Debugw("Optimistic update conflict while updating run", run.ForLogger()...)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	re.statsPusher.PushNow()
+	return nil
+}
+
+type taskResult struct {
+	output  models.RunOutput
+	elapsed float64
+}
+
+// executeTasks performs the TaskRuns at the given indexes concurrently,
+// returning their results in the same order.
+func (re *runExecutor) executeTasks(run *models.JobRun, indexes []int) []taskResult {
+	results := make([]taskResult, len(indexes))
+	var wg sync.WaitGroup
+	wg.Add(len(indexes))
+	for i, index := range indexes {
+		go func(i, index int) {
+			defer wg.Done()
+			start := time.Now()
+			output := re.executeTask(run, index)
+			results[i] = taskResult{output: output, elapsed: time.Since(start).Seconds()}
+		}(i, index)
+	}
+	wg.Wait()
+	return results
+}
+
+func (re *runExecutor) executeTask(run *models.JobRun, index int) models.RunOutput {
+	taskRun := run.TaskRuns[index]
 	taskSpec := taskRun.TaskSpec
 
-	params, err := models.Merge(run.RunRequest.RequestParams, taskSpec.Params)
+	// Only the job's own params can reference secrets, so that run requests
+	// cannot have them sent elsewhere.
+	resolved, err := re.store.SecretStore.Resolve(taskSpec.Params)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	params, err := models.Merge(run.RunRequest.RequestParams, resolved)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
@@ -118,22 +281,40 @@ func (re *runExecutor) executeTask(run *models.JobRun, taskRun models.TaskRun) m
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
+	if re.inMemory {
+		adapter.BaseAdapter = adapters.RecordEthTx(adapter.BaseAdapter)
+	}
 
-	previousTaskRun := run.PreviousTaskRun()
-
-	previousTaskInput := models.JSON{}
-	if previousTaskRun != nil {
-		previousTaskInput = previousTaskRun.Result.Data
+	parents := run.ParentTaskRuns(index)
+	inputs := []models.JSON{run.RunRequest.RequestParams}
+	for _, parent := range parents {
+		inputs = append(inputs, parent.Result.Data)
 	}
+	inputs = append(inputs, taskRun.Result.Data)
 
-	data, err := models.Merge(run.RunRequest.RequestParams, previousTaskInput, taskRun.Result.Data)
+	data, err := models.Merge(inputs...)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
 
 	input := *models.NewRunInput(run.ID, *taskRun.ID, data, taskRun.Status)
-	result := adapter.Perform(input, re.store)
-	promAdapterCallsVec.WithLabelValues(run.JobSpecID.String(), string(adapter.TaskType()), string(result.Status())).Inc()
+	// Tasks with several parents are also given each parent's result, in the
+	// order of their dependencies, so that they can be aggregated. They are
+	// kept apart from the input data, which belongs to the run request and
+	// the parents.
+	if len(parents) > 1 {
+		results := make([]gjson.Result, len(parents))
+		for i, parent := range parents {
+			results[i] = parent.Result.Data.Get("result")
+		}
+		input = input.WithParentResults(results)
+	}
+	// Adapters may return the values of secrets in their params, which are
+	// redacted before the output is saved.
+	result := adapter.Perform(input, re.store).Redact(re.store.SecretStore.Redact)
+	if !re.inMemory {
+		promAdapterCallsVec.WithLabelValues(run.JobSpecID.String(), string(adapter.TaskType()), string(result.Status())).Inc()
+	}
 
 	return result
 }
@@ -3,7 +3,10 @@ package services_test
 import (
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -223,3 +227,170 @@ func TestJobRunner_prioritizeSpecParamsOverRequestParams(t *testing.T) {
 	expected := strconv.FormatUint(uint64(requestBase*specParameter), 10)
 	assert.Equal(t, expected, actual)
 }
+
+func TestRunExecutor_Execute_TaskGraph(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	j := cltest.NewJobWithWebInitiator()
+	first := cltest.NewTask(t, "multiply", `{"times":2}`)
+	first.Name = "first"
+	second := cltest.NewTask(t, "multiply", `{"times":3}`)
+	second.Name = "second"
+	compare := cltest.NewTask(t, "compare", `{"operator":"gt","value":"100"}`)
+	compare.Name = "compare"
+	compare.DependsOn = models.TaskNames{"first", "second"}
+	skipped := cltest.NewTask(t, "noop")
+	skipped.DependsOn = models.TaskNames{"compare"}
+	skipped.Condition = "compare"
+	j.Tasks = []models.TaskSpec{first, second, compare, skipped}
+	require.NoError(t, store.CreateJob(&j))
+
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result":10}`)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusCompleted, run.GetStatus())
+	require.Len(t, run.TaskRuns, 4)
+	assert.Equal(t, "20", run.TaskRuns[0].Result.Data.Get("result").String())
+	assert.Equal(t, "30", run.TaskRuns[1].Result.Data.Get("result").String())
+	assert.Equal(t, models.RunStatusCompleted, run.TaskRuns[2].Status)
+	assert.False(t, run.TaskRuns[2].Result.Data.Get("result").Bool())
+	assert.Equal(t, models.RunStatusSkipped, run.TaskRuns[3].Status)
+	assert.Len(t, run.TaskRuns[2].ParentIDs, 2)
+}
+
+func TestRunExecutor_Execute_KeepsResultsOfRunRequest(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask(t, "expression", `{"expression":"results[0] + result"}`)}
+	require.NoError(t, store.CreateJob(&j))
+
+	// A task without several parents sees the results of the run request
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result":"10","results":[1000,2000,3000]}`)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusCompleted, run.GetStatus())
+	assert.Equal(t, "1010", run.Result.Data.Get("result").String())
+}
+
+func TestRunExecutor_Execute_RetriesFailedTask(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"price":"100"}`))
+	}))
+	defer server.Close()
+
+	j := cltest.NewJobWithWebInitiator()
+	httpGet := cltest.NewTask(t, "httpget", fmt.Sprintf(`{"get":"%s"}`, server.URL))
+	httpGet.Retry = models.RetryPolicy{MaxAttempts: 3, InitialBackoff: models.MustMakeDuration(time.Millisecond)}
+	j.Tasks = []models.TaskSpec{httpGet, cltest.NewTask(t, "jsonparse", `{"path":["price"]}`)}
+	require.NoError(t, store.CreateJob(&j))
+
+	run := cltest.NewJobRun(j)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingSleep, run.GetStatus())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	run = executeUntilAwake(t, store, runExecutor, run.ID)
+	assert.Equal(t, models.RunStatusCompleted, run.GetStatus())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	require.Len(t, run.TaskRuns, 2)
+	assert.Equal(t, uint32(1), run.TaskRuns[0].Retries)
+	assert.True(t, run.TaskRuns[0].RetryAt.Valid)
+	assert.False(t, run.TaskRuns[0].Result.ErrorMessage.Valid)
+	assert.Equal(t, "100", run.Result.Data.Get("result").String())
+}
+
+func TestRunExecutor_Execute_RetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	j := cltest.NewJobWithWebInitiator()
+	httpGet := cltest.NewTask(t, "httpget", fmt.Sprintf(`{"get":"%s"}`, server.URL))
+	httpGet.Retry = models.RetryPolicy{MaxAttempts: 2, InitialBackoff: models.MustMakeDuration(time.Millisecond)}
+	j.Tasks = []models.TaskSpec{httpGet}
+	require.NoError(t, store.CreateJob(&j))
+
+	run := cltest.NewJobRun(j)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	run = executeUntilAwake(t, store, runExecutor, run.ID)
+	assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, uint32(1), run.TaskRuns[0].Retries)
+}
+
+// executeUntilAwake performs a run again each time its sleeping tasks are due
+// to be retried, as RunManager.ResumeAllPendingSleep would, until it no
+// longer sleeps.
+func executeUntilAwake(t *testing.T, store *strpkg.Store, runExecutor services.RunExecutor, runID *models.ID) models.JobRun {
+	for {
+		require.NoError(t, runExecutor.Execute(runID))
+		run, err := store.FindJobRun(runID)
+		require.NoError(t, err)
+		retryAt, ok := run.RetryAt()
+		if !run.GetStatus().PendingSleep() || !ok {
+			return run
+		}
+		time.Sleep(time.Until(retryAt))
+	}
+}
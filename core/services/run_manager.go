@@ -50,6 +50,7 @@ type RunManager interface {
 	ResumeAllInProgress() error
 	ResumeAllPendingNextBlock(currentBlockHeight *big.Int) error
 	ResumeAllPendingConnection() error
+	ResumeAllPendingSleep() error
 }
 
 // runManager implements RunManager
@@ -290,6 +291,15 @@ func (rm *runManager) ResumeAllPendingConnection() error {
 		models.RunStatusPendingConnection, models.RunStatusPendingOutgoingConfirmations)
 }
 
+// ResumeAllPendingSleep requeues runs whose sleeping tasks are due to be
+// retried.
+func (rm *runManager) ResumeAllPendingSleep() error {
+	return rm.orm.UnscopedJobRunsDueForRetry(func(run *models.JobRun) {
+		logger.Debugw("Resuming run for task retry", run.ForLogger()...)
+		rm.runQueue.Run(run)
+	}, rm.clock.Now())
+}
+
 // ResumePendingBridgeTask wakes up a task that required a response from a bridge adapter.
 func (rm *runManager) ResumePendingBridge(
 	runID *models.ID,
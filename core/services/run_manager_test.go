@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"errors"
 	"fmt"
 
 	"math/big"
@@ -856,6 +857,45 @@ func TestRunManager_ResumeAllInProgress_NotInProgressAndArchived(t *testing.T) {
 	}
 }
 
+func TestRunManager_ResumeAllPendingSleep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		retryAt time.Time
+		resumed bool
+	}{
+		{"due", time.Now().Add(-time.Second), true},
+		{"not due", time.Now().Add(time.Hour), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore(t)
+			defer cleanup()
+
+			job := cltest.NewJobWithWebInitiator()
+			require.NoError(t, store.CreateJob(&job))
+			run := cltest.NewJobRun(job)
+			run.TaskRuns[0].ScheduleRetry(errors.New("server error"), test.retryAt)
+			run.SetStatus(models.RunStatusPendingSleep)
+			require.NoError(t, store.CreateJobRun(&run))
+
+			pusher := new(mocks.StatsPusher)
+
+			runQueue := new(mocks.RunQueue)
+			if test.resumed {
+				runQueue.On("Run", mock.Anything).Return(nil)
+			}
+
+			runManager := services.NewRunManager(runQueue, store.Config, store.ORM, pusher, store.TxManager, store.Clock)
+			require.NoError(t, runManager.ResumeAllPendingSleep())
+
+			runQueue.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRunManager_ValidateRun_PaymentAboveThreshold(t *testing.T) {
 	jobSpecID := cltest.NewJob().ID
 	run := &models.JobRun{ID: models.NewID(), JobSpecID: jobSpecID, Payment: assets.NewLink(2)}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -30,7 +32,7 @@ type Scheduler struct {
 // and OneTime fields since jobs can contain tasks which utilize both.
 func NewScheduler(store *store.Store, runManager RunManager) *Scheduler {
 	return &Scheduler{
-		Recurring: NewRecurring(runManager),
+		Recurring: NewRecurring(runManager, store),
 		OneTime: &OneTime{
 			Store:      store,
 			Clock:      store.Clock,
@@ -93,25 +95,48 @@ func (s *Scheduler) AddJob(job models.JobSpec) {
 	s.addJob(&job)
 }
 
+// RemoveJob stops scheduling runs of the job, for it to be added again once
+// it is updated.
+func (s *Scheduler) RemoveJob(ID *models.ID) {
+	s.startedMutex.RLock()
+	defer s.startedMutex.RUnlock()
+	if !s.started {
+		return
+	}
+	s.Recurring.RemoveJob(ID)
+	s.OneTime.RemoveJob(ID)
+}
+
 // Recurring is used for runs that need to execute on a schedule,
 // and is configured with cron.
 // Instances of Recurring must be initialized using NewRecurring().
 type Recurring struct {
 	Cron       Cron
 	Clock      utils.Nower
+	store      *store.Store
 	runManager RunManager
+	done       chan struct{}
+	entries    map[string][]cron.EntryID
+	mutex      sync.Mutex
 }
 
+// maxCatchUpRuns is the most runs a cron initiator with the "all" catch-up
+// policy starts for the firings it missed; only the latest ones are run.
+const maxCatchUpRuns = 100
+
 // NewRecurring create a new instance of Recurring, ready to use.
-func NewRecurring(runManager RunManager) *Recurring {
+func NewRecurring(runManager RunManager, store *store.Store) *Recurring {
 	return &Recurring{
+		store:      store,
 		runManager: runManager,
+		entries:    make(map[string][]cron.EntryID),
 	}
 }
 
 // Start for Recurring types executes tasks with a "cron" initiator
 // based on the configured schedule for the run.
 func (r *Recurring) Start() error {
+	r.done = make(chan struct{})
 	r.Cron = cron.New(cron.WithParser(models.CronParser))
 	r.Cron.Start()
 	return nil
@@ -119,30 +144,116 @@ func (r *Recurring) Start() error {
 
 // Stop stops the cron scheduler and waits for running jobs to finish.
 func (r *Recurring) Stop() {
+	if r.done != nil {
+		close(r.done)
+	}
 	ctx := r.Cron.Stop()
 	// Wait for all jobs to finish
 	<-ctx.Done()
 }
 
 // AddJob looks for "cron" initiators, adds them to cron's schedule
-// for execution when specified.
+// for execution when specified, and catches up the runs they missed
+// since they last fired according to their catch-up policy.
 func (r *Recurring) AddJob(job models.JobSpec) {
 	for _, initr := range job.InitiatorsFor(models.InitiatorCron) {
-		_, err := r.Cron.AddFunc(string(initr.Schedule), func() {
-			now := time.Now()
-			if !job.Started(now) || job.Ended(now) {
-				return
-			}
-
-			_, err := r.runManager.Create(job.ID, &initr, nil, &models.RunRequest{})
-			if err != nil && !ExpectedRecurringScheduleJobError(err) {
-				logger.Errorw(err.Error())
-			}
+		initr := initr
+		schedule := initr.Schedule.InTimeZone(initr.Timezone).String()
+		id, err := r.Cron.AddFunc(schedule, func() {
+			r.fire(job, initr, time.Now())
 		})
 		if err != nil {
 			logger.Error(err)
+			continue
+		}
+		r.mutex.Lock()
+		r.entries[job.ID.String()] = append(r.entries[job.ID.String()], id)
+		r.mutex.Unlock()
+		r.catchUp(job, initr, schedule)
+	}
+}
+
+// RemoveJob removes the "cron" initiators of the job from cron's schedule.
+func (r *Recurring) RemoveJob(ID *models.ID) {
+	r.mutex.Lock()
+	ids := r.entries[ID.String()]
+	delete(r.entries, ID.String())
+	r.mutex.Unlock()
+
+	for _, id := range ids {
+		r.Cron.Remove(id)
+	}
+}
+
+// fire starts a run of the job, after waiting for a random duration of up
+// to the initiator's jitter.
+func (r *Recurring) fire(job models.JobSpec, initr models.Initiator, now time.Time) {
+	if !job.Started(now) || job.Ended(now) {
+		return
+	}
+
+	if jitter := initr.Jitter.Duration(); jitter > 0 {
+		select {
+		case <-r.done:
+			return
+		case <-time.After(time.Duration(rand.Int63n(int64(jitter)))):
 		}
 	}
+
+	r.createRun(job, initr)
+	if err := r.store.MarkFired(&initr, now); err != nil {
+		logger.Errorw("Failed to record cron firing", "job", job.ID.String(), "error", err)
+	}
+}
+
+// catchUp starts the runs a cron initiator missed since it last fired,
+// e.g. while the node was down: none with the "skip" policy, the latest one
+// with "once", and each of them with "all".
+func (r *Recurring) catchUp(job models.JobSpec, initr models.Initiator, schedule string) {
+	if !initr.LastFiredAt.Valid || (initr.CatchUp != models.CatchUpOnce && initr.CatchUp != models.CatchUpAll) {
+		return
+	}
+	sched, err := models.CronParser.Parse(schedule)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	now := time.Now()
+	var missed []time.Time
+	total := 0
+	for t := sched.Next(initr.LastFiredAt.Time); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		if !job.Started(t) || job.Ended(t) {
+			continue
+		}
+		total++
+		missed = append(missed, t)
+		if len(missed) > maxCatchUpRuns {
+			missed = missed[1:]
+		}
+	}
+	if total == 0 {
+		return
+	}
+	if initr.CatchUp == models.CatchUpOnce {
+		missed = missed[len(missed)-1:]
+	}
+
+	logger.Infow(fmt.Sprintf("Catching up %v of %v missed cron runs", len(missed), total),
+		"job", job.ID.String(), "lastFiredAt", initr.LastFiredAt.Time, "catchUp", initr.CatchUp)
+	for range missed {
+		r.createRun(job, initr)
+	}
+	if err := r.store.MarkFired(&initr, missed[len(missed)-1]); err != nil {
+		logger.Errorw("Failed to record cron firing", "job", job.ID.String(), "error", err)
+	}
+}
+
+func (r *Recurring) createRun(job models.JobSpec, initr models.Initiator) {
+	_, err := r.runManager.Create(job.ID, &initr, nil, &models.RunRequest{})
+	if err != nil && !ExpectedRecurringScheduleJobError(err) {
+		logger.Errorw(err.Error())
+	}
 }
 
 // OneTime represents runs that are to be executed only once.
@@ -151,6 +262,8 @@ type OneTime struct {
 	Clock      utils.Afterer
 	RunManager RunManager
 	done       chan struct{}
+	removed    map[string]chan struct{}
+	mutex      sync.Mutex
 }
 
 // Start allocates a channel for the "done" field with an empty struct.
@@ -161,13 +274,44 @@ func (ot *OneTime) Start() error {
 
 // AddJob runs the job at the time specified for the "runat" initiator.
 func (ot *OneTime) AddJob(job models.JobSpec) {
-	for _, initiator := range job.InitiatorsFor(models.InitiatorRunAt) {
+	initiators := job.InitiatorsFor(models.InitiatorRunAt)
+	if len(initiators) == 0 {
+		return
+	}
+
+	ot.mutex.Lock()
+	if ot.removed == nil {
+		ot.removed = make(map[string]chan struct{})
+	}
+	removed, ok := ot.removed[job.ID.String()]
+	if !ok {
+		removed = make(chan struct{})
+		ot.removed[job.ID.String()] = removed
+	}
+	ot.mutex.Unlock()
+
+	for _, initiator := range initiators {
 		if !initiator.Time.Valid {
 			logger.Errorf("RunJobAt: JobSpec %s must have initiator with valid run at time: %v", job.ID, initiator)
 			continue
 		}
+		// Jobs are added again when they are updated, after they may have run
+		if initiator.Ran {
+			continue
+		}
 
-		go ot.RunJobAt(initiator, job)
+		go ot.runJobAt(initiator, job, removed)
+	}
+}
+
+// RemoveJob stops waiting to run the job at the time of its "runat"
+// initiators.
+func (ot *OneTime) RemoveJob(ID *models.ID) {
+	ot.mutex.Lock()
+	defer ot.mutex.Unlock()
+	if removed, ok := ot.removed[ID.String()]; ok {
+		close(removed)
+		delete(ot.removed, ID.String())
 	}
 }
 
@@ -179,8 +323,14 @@ func (ot *OneTime) Stop() {
 // RunJobAt wait until the Stop() function has been called on the run
 // or the specified time for the run is after the present time.
 func (ot *OneTime) RunJobAt(initiator models.Initiator, job models.JobSpec) {
+	ot.runJobAt(initiator, job, nil)
+}
+
+// runJobAt is RunJobAt, which also returns once removed is closed.
+func (ot *OneTime) runJobAt(initiator models.Initiator, job models.JobSpec, removed chan struct{}) {
 	select {
 	case <-ot.done:
+	case <-removed:
 	case <-ot.Clock.After(utils.DurationFromNow(initiator.Time.Time)):
 		now := time.Now()
 		if !job.Started(now) || job.Ended(now) {
@@ -212,4 +362,5 @@ type Cron interface {
 	Start()
 	Stop() context.Context
 	AddFunc(string, func()) (cron.EntryID, error)
+	Remove(cron.EntryID)
 }
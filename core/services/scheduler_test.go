@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +50,9 @@ func TestScheduler_Start_LoadingRecurringJobs(t *testing.T) {
 }
 
 func TestRecurring_AddJob(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
 	executeJobChannel := make(chan struct{}, 1)
 	runManager := new(mocks.RunManager)
 	runManager.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -58,7 +62,7 @@ func TestRecurring_AddJob(t *testing.T) {
 		}).
 		Twice()
 
-	r := services.NewRecurring(runManager)
+	r := services.NewRecurring(runManager, store)
 	cron := cltest.NewMockCron()
 	r.Cron = cron
 
@@ -88,7 +92,7 @@ func TestRecurring_AddJob_PastEnd(t *testing.T) {
 
 	runManager := new(mocks.RunManager)
 
-	r := services.NewRecurring(runManager)
+	r := services.NewRecurring(runManager, store)
 	cron := cltest.NewMockCron()
 	r.Cron = cron
 
@@ -113,7 +117,7 @@ func TestRecurring_AddJob_BeforeStart(t *testing.T) {
 
 	runManager := new(mocks.RunManager)
 
-	r := services.NewRecurring(runManager)
+	r := services.NewRecurring(runManager, store)
 	cron := cltest.NewMockCron()
 	r.Cron = cron
 
@@ -132,6 +136,122 @@ func TestRecurring_AddJob_BeforeStart(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestRecurring_AddJob_CatchUp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		catchUp models.CatchUpPolicy
+		runs    int
+	}{
+		{"skip by default", "", 0},
+		{"skip", models.CatchUpSkip, 0},
+		{"once", models.CatchUpOnce, 1},
+		{"all", models.CatchUpAll, 3},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore(t)
+			defer cleanup()
+
+			runManager := new(mocks.RunManager)
+			if test.runs > 0 {
+				runManager.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).
+					Times(test.runs)
+			}
+
+			r := services.NewRecurring(runManager, store)
+			r.Cron = cltest.NewMockCron()
+
+			// The job last fired in the middle of the hour before the last
+			// two, missing three hourly runs
+			lastFiredAt := time.Now().Truncate(time.Hour).Add(-2*time.Hour - 30*time.Minute)
+			j := cltest.NewJobWithSchedule("CRON_TZ=UTC 0 * * * *")
+			j.Initiators[0].CatchUp = test.catchUp
+			j.Initiators[0].LastFiredAt = null.TimeFrom(lastFiredAt)
+			require.NoError(t, store.CreateJob(&j))
+
+			r.AddJob(j)
+			r.Stop()
+
+			runManager.AssertExpectations(t)
+
+			initr, err := store.FindInitiator(j.Initiators[0].ID)
+			require.NoError(t, err)
+			if test.runs > 0 {
+				assert.True(t, initr.LastFiredAt.Time.After(lastFiredAt))
+			} else {
+				assert.True(t, initr.LastFiredAt.Time.Equal(lastFiredAt))
+			}
+		})
+	}
+}
+
+func TestRecurring_AddJob_Jitter(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	executeJobChannel := make(chan struct{}, 1)
+	runManager := new(mocks.RunManager)
+	runManager.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Run(func(mock.Arguments) {
+			executeJobChannel <- struct{}{}
+		}).
+		Once()
+
+	r := services.NewRecurring(runManager, store)
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	j := cltest.NewJobWithSchedule("CRON_TZ=UTC * * * * *")
+	j.Initiators[0].Jitter = models.MustMakeDuration(100 * time.Millisecond)
+	require.NoError(t, store.CreateJob(&j))
+
+	r.AddJob(j)
+	cron.RunEntries()
+
+	cltest.CallbackOrTimeout(t, "Create", func() {
+		<-executeJobChannel
+	}, 3*time.Second)
+
+	r.Stop()
+
+	runManager.AssertExpectations(t)
+
+	initr, err := store.FindInitiator(j.Initiators[0].ID)
+	require.NoError(t, err)
+	assert.True(t, initr.LastFiredAt.Valid)
+}
+
+func TestRecurring_RemoveJob(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runManager := new(mocks.RunManager)
+
+	r := services.NewRecurring(runManager, store)
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	removed := cltest.NewJobWithSchedule("* * * * *")
+	r.AddJob(removed)
+	kept := cltest.NewJobWithSchedule("*/5 * * * *")
+	r.AddJob(kept)
+	require.Len(t, cron.Entries, 2)
+
+	r.RemoveJob(removed.ID)
+	require.Len(t, cron.Entries, 1)
+	assert.Contains(t, cron.Entries[0].Schedule, "*/5 * * * *")
+
+	r.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
 func TestOneTime_AddJob(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -238,6 +358,70 @@ func TestOneTime_AddJob_BeforeStart(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestOneTime_RemoveJob(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runManager := new(mocks.RunManager)
+
+	clock := cltest.NewTriggerClock(t)
+
+	ot := services.OneTime{
+		Clock:      clock,
+		Store:      store,
+		RunManager: runManager,
+	}
+	require.NoError(t, ot.Start())
+
+	j := cltest.NewJobWithRunAtInitiator(time.Now())
+	require.Nil(t, store.CreateJob(&j))
+
+	ot.AddJob(j)
+	ot.RemoveJob(j.ID)
+
+	// Give the removed job time to stop waiting on the clock
+	time.Sleep(100 * time.Millisecond)
+	go clock.TriggerWithoutTimeout()
+
+	// Sleep for some time to make sure no calls are made
+	time.Sleep(1 * time.Second)
+
+	ot.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
+func TestOneTime_AddJob_AlreadyRan(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runManager := new(mocks.RunManager)
+
+	clock := cltest.NewTriggerClock(t)
+
+	ot := services.OneTime{
+		Clock:      clock,
+		Store:      store,
+		RunManager: runManager,
+	}
+	require.NoError(t, ot.Start())
+
+	j := cltest.NewJobWithRunAtInitiator(time.Now())
+	j.Initiators[0].Ran = true
+	require.Nil(t, store.CreateJob(&j))
+
+	ot.AddJob(j)
+
+	go clock.TriggerWithoutTimeout()
+
+	// Sleep for some time to make sure no calls are made
+	time.Sleep(1 * time.Second)
+
+	ot.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
 func TestExpectedRecurringScheduleJobError(t *testing.T) {
 	t.Parallel()
 
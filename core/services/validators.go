@@ -16,6 +16,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 )
@@ -40,6 +41,9 @@ func ValidateJob(j models.JobSpec, store *store.Store) error {
 			fe.Merge(err)
 		}
 	}
+	if _, err := j.TaskDependencies(); err != nil {
+		fe.Add(err.Error())
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -49,7 +53,7 @@ func ValidateBridgeTypeNotExist(bt *models.BridgeTypeRequest, store *store.Store
 	bridge, err := store.ORM.FindBridge(bt.Name)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		fe.Add(fmt.Sprintf("Error determining if bridge type %v already exists", bt.Name))
-	} else if (bridge != models.BridgeType{}) {
+	} else if bridge.Name != "" {
 		fe.Add(fmt.Sprintf("Bridge Type %v already exists", bt.Name))
 	}
 	return fe.CoerceEmptyToNil()
@@ -77,6 +81,19 @@ func ValidateBridgeType(bt *models.BridgeTypeRequest, store *store.Store) error
 	if a := adapters.FindNativeAdapterFor(ts); a != nil {
 		fe.Add(fmt.Sprintf("Bridge Type %v is a native adapter", bt.Name))
 	}
+	if bt.HealthURL != nil && bt.HealthURL.Scheme != "http" && bt.HealthURL.Scheme != "https" {
+		fe.Add("HealthURL must be an http or https URL")
+	}
+	if bt.RotateSigningSecret && !bt.SigningEnabled {
+		fe.Add("RotateSigningSecret requires SigningEnabled")
+	}
+	for _, fallback := range bt.Fallbacks {
+		if fallback == bt.Name {
+			fe.Add(fmt.Sprintf("Bridge Type %v cannot be its own fallback", bt.Name))
+		} else if _, err := store.FindBridge(fallback); err != nil {
+			fe.Add(fmt.Sprintf("Fallback bridge %v does not exist", fallback))
+		}
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -134,6 +151,15 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if i.Address == utils.ZeroAddress {
 		fe.Add("no address")
 	}
+	fed := map[common.Address]bool{i.Address: true}
+	for _, aggregator := range i.Aggregators {
+		if aggregator == utils.ZeroAddress {
+			fe.Add("bad 'aggregators' parameter: no address")
+		} else if fed[aggregator] {
+			fe.Add(fmt.Sprintf("bad 'aggregators' parameter: %s is fed more than once", aggregator.Hex()))
+		}
+		fed[aggregator] = true
+	}
 	if i.RequestData.String() == "" {
 		fe.Add("no requestdata")
 	}
@@ -184,6 +210,21 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 		}
 	}
 
+	if err := i.ThresholdSchedule.Validate(); err != nil {
+		fe.Add("bad 'thresholdSchedule' parameter: " + err.Error())
+	}
+
+	if err := i.OutlierGuard.Validate(); err != nil {
+		fe.Add("bad 'outlierGuard' parameter: " + err.Error())
+	}
+
+	if !i.Heartbeat.IsInstant() {
+		minimumHeartbeat := models.Duration(store.Config.DefaultHTTPTimeout())
+		if i.Heartbeat.Shorter(minimumHeartbeat) {
+			fe.Add("heartbeat must be equal or greater than " + minimumHeartbeat.String())
+		}
+	}
+
 	if err := validateFeeds(i.Feeds, store); err != nil {
 		fe.Add(err.Error())
 	}
@@ -207,7 +248,14 @@ func validateFeeds(feeds models.Feeds, store *store.Store) error {
 			if _, err := url.ParseRequestURI(feed); err != nil {
 				return err
 			}
-		case map[string]interface{}: // named feed - ex: {"bridge": "bridgeName"}
+		case map[string]interface{}:
+			if models.IsFeedPipeline(feed) { // pipeline - ex: {"tasks": [{"type": "httpget", ...}]}
+				if err := validateFeedPipeline(feed, store); err != nil {
+					return err
+				}
+				continue
+			}
+			// named feed - ex: {"bridge": "bridgeName"}
 			bridgeName := feed["bridge"]
 			bridgeNameString, ok := bridgeName.(string)
 			if bridgeName == nil {
@@ -229,6 +277,27 @@ func validateFeeds(feeds models.Feeds, store *store.Store) error {
 	return nil
 }
 
+func validateFeedPipeline(feed map[string]interface{}, store *store.Store) error {
+	pipeline, err := models.NewFeedPipeline(feed)
+	if err != nil {
+		return err
+	}
+	job := pipeline.JobSpec()
+	for _, task := range job.Tasks {
+		switch task.Type {
+		case adapters.TaskTypeEthTx, adapters.TaskTypeEthTxABIEncode:
+			return fmt.Errorf("feed pipeline cannot have %s tasks", task.Type)
+		}
+		if err := validateTask(task, store); err != nil {
+			return errors.Wrap(err, "invalid feed pipeline")
+		}
+	}
+	if _, err := job.TaskDependencies(); err != nil {
+		return errors.Wrap(err, "invalid feed pipeline")
+	}
+	return nil
+}
+
 func validateRunLogInitiator(i models.Initiator, j models.JobSpec) error {
 	fe := models.NewJSONAPIErrors()
 	ethTxCount := 0
@@ -269,7 +338,24 @@ func validateCronInitiator(i models.Initiator) error {
 	if i.Schedule == "" {
 		return models.NewJSONAPIErrorsWith("Schedule must have a cron")
 	}
-	return nil
+	fe := models.NewJSONAPIErrors()
+	if i.Timezone == "" && !i.Schedule.HasTimeZone() {
+		fe.Add("Schedule must specify a time zone using CRON_TZ or the timezone param, e.g. 'CRON_TZ=UTC 5 * * * *'")
+	} else if i.Timezone != "" && i.Schedule.HasTimeZone() {
+		fe.Add("Schedule cannot specify a time zone using both CRON_TZ and the timezone param")
+	} else if i.Timezone != "" {
+		if _, err := time.LoadLocation(i.Timezone); err != nil {
+			fe.Add(fmt.Sprintf("Invalid timezone %q: %v", i.Timezone, err))
+		} else if _, err := models.CronParser.Parse(i.Schedule.InTimeZone(i.Timezone).String()); err != nil {
+			fe.Add(err.Error())
+		}
+	}
+	switch i.CatchUp {
+	case "", models.CatchUpSkip, models.CatchUpOnce, models.CatchUpAll:
+	default:
+		fe.Add(fmt.Sprintf("CatchUp must be one of %q, %q or %q", models.CatchUpSkip, models.CatchUpOnce, models.CatchUpAll))
+	}
+	return fe.CoerceEmptyToNil()
 }
 
 func validateExternalInitiator(i models.Initiator) error {
@@ -303,6 +389,9 @@ func validateTask(task models.TaskSpec, store *store.Store) error {
 	if err != nil {
 		return err
 	}
+	if err := task.Retry.Validate(); err != nil {
+		return err
+	}
 	if !store.Config.EnableExperimentalAdapters() {
 		if _, ok := adapter.BaseAdapter.(*adapters.Sleep); ok {
 			return errors.New("Sleep Adapter is not implemented yet")
@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -175,6 +176,15 @@ func TestValidateBridgeType(t *testing.T) {
 			},
 			models.NewJSONAPIErrorsWith("Bridge Type ethtx is a native adapter"),
 		},
+		{
+			"rotating a secret without signing",
+			models.BridgeTypeRequest{
+				Name:                "unsignedadapter",
+				URL:                 cltest.WebURL(t, "https://denergy.eth"),
+				RotateSigningSecret: true,
+			},
+			models.NewJSONAPIErrorsWith("RotateSigningSecret requires SigningEnabled"),
+		},
 		{
 			"new external adapter",
 			models.BridgeTypeRequest{
@@ -281,6 +291,12 @@ func TestValidateInitiator(t *testing.T) {
 		{"cron standard", `{"type":"cron","params": {"schedule":"CRON_TZ=UTC * * * * *"}}`, false},
 		{"cron with 6 fields", `{"type":"cron","params": {"schedule":"CRON_TZ=UTC * * * * * *"}}`, false},
 		{"cron w/o schedule", `{"type":"cron"}`, true},
+		{"cron w/o time zone", `{"type":"cron","params": {"schedule":"* * * * *"}}`, true},
+		{"cron with timezone", `{"type":"cron","params": {"schedule":"0 9 * * *","timezone":"America/New_York"}}`, false},
+		{"cron with unknown timezone", `{"type":"cron","params": {"schedule":"0 9 * * *","timezone":"Nowhere/Special"}}`, true},
+		{"cron with timezone and CRON_TZ", `{"type":"cron","params": {"schedule":"CRON_TZ=UTC 0 9 * * *","timezone":"America/New_York"}}`, true},
+		{"cron with jitter and catch-up", `{"type":"cron","params": {"schedule":"CRON_TZ=UTC 0 9 * * *","jitter":"30s","catchUp":"all"}}`, false},
+		{"cron with unknown catch-up", `{"type":"cron","params": {"schedule":"CRON_TZ=UTC 0 9 * * *","catchUp":"sometimes"}}`, true},
 		{"external w/o name", `{"type":"external"}`, true},
 		{"non-existent initiator", `{"type":"doesntExist"}`, true},
 	}
@@ -391,6 +407,50 @@ func TestValidateInitiator_FluxMonitorHappy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateInitiator_FluxMonitorAggregators(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	initrJSON := cltest.MustJSONSet(t, validInitiator, "params.aggregators", []string{
+		"0x9FBDa871d559710256a2502A2517b794B482Db40",
+		"0x2f6e2D3a2FD5E4bd5f4D1D2ba7d4A6aE0f3C9B11",
+	})
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(initrJSON), &initr))
+	assert.Equal(t, []common.Address{
+		common.HexToAddress("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"),
+		common.HexToAddress("0x9FBDa871d559710256a2502A2517b794B482Db40"),
+		common.HexToAddress("0x2f6e2D3a2FD5E4bd5f4D1D2ba7d4A6aE0f3C9B11"),
+	}, initr.FluxAggregators())
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
+func TestValidateInitiator_FluxMonitorScheduleAndHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	initrJSON := cltest.MustJSONSet(t, validInitiator, "params.heartbeat", "1h")
+	initrJSON = cltest.MustJSONSet(t, initrJSON, "params.thresholdSchedule", []map[string]interface{}{
+		{"days": []string{"mon", "tue", "wed", "thu", "fri"}, "start": "09:30", "end": "16:00", "timezone": "America/New_York", "threshold": 0.1},
+		{"days": []string{"saturday", "sunday"}, "threshold": 2, "absoluteThreshold": 1},
+	})
+	initrJSON = cltest.MustJSONSet(t, initrJSON, "params.outlierGuard", map[string]interface{}{"minAnswer": "1", "maxAnswer": "1000", "circuitBreaker": 20})
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(initrJSON), &initr))
+	require.Len(t, initr.ThresholdSchedule, 2)
+	assert.Equal(t, time.Hour, initr.Heartbeat.Duration())
+	assert.Equal(t, float32(20), initr.OutlierGuard.CircuitBreaker)
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
 func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 	t.Parallel()
 
@@ -410,6 +470,17 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 		{"pollTimer enabled, but no period specified", cltest.MustJSONDel(t, validInitiator, "params.pollTimer.period")},
 		{"period must be equal or greater than 15s", cltest.MustJSONSet(t, validInitiator, "params.pollTimer.period", "1s")},
 		{"idleTimer.duration must be >= than pollTimer.period", cltest.MustJSONSet(t, validInitiator, "params.idleTimer.duration", "30s")},
+		{"heartbeat must be equal or greater than 15s", cltest.MustJSONSet(t, validInitiator, "params.heartbeat", "1s")},
+		{"thresholdSchedule", cltest.MustJSONSet(t, validInitiator, "params.thresholdSchedule", []map[string]interface{}{{"days": []string{"someday"}, "threshold": 0.1}})},
+		{"thresholdSchedule", cltest.MustJSONSet(t, validInitiator, "params.thresholdSchedule", []map[string]interface{}{{"start": "09:30", "end": "09:30", "threshold": 0.1}})},
+		{"thresholdSchedule", cltest.MustJSONSet(t, validInitiator, "params.thresholdSchedule", []map[string]interface{}{{"start": "9am", "threshold": 0.1}})},
+		{"thresholdSchedule", cltest.MustJSONSet(t, validInitiator, "params.thresholdSchedule", []map[string]interface{}{{"timezone": "Mars/Olympus_Mons", "threshold": 0.1}})},
+		{"thresholdSchedule", cltest.MustJSONSet(t, validInitiator, "params.thresholdSchedule", []map[string]interface{}{{"days": []string{"sat", "sun"}}})},
+		{"outlierGuard", cltest.MustJSONSet(t, validInitiator, "params.outlierGuard", map[string]interface{}{"circuitBreaker": -1})},
+		{"outlierGuard", cltest.MustJSONSet(t, validInitiator, "params.outlierGuard", map[string]interface{}{"minAnswer": "2", "maxAnswer": "1"})},
+		{"aggregators", cltest.MustJSONSet(t, validInitiator, "params.aggregators", []string{"0x0000000000000000000000000000000000000000"})},
+		{"aggregators", cltest.MustJSONSet(t, validInitiator, "params.aggregators", []string{"0x9FBDa871d559710256a2502A2517b794B482Db40", "0x9FBDa871d559710256a2502A2517b794B482Db40"})},
+		{"aggregators", cltest.MustJSONSet(t, validInitiator, "params.aggregators", []string{"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"})},
 	}
 	for _, test := range tests {
 		t.Run("bad "+test.Field, func(t *testing.T) {
@@ -453,7 +524,11 @@ func TestValidateInitiator_FeedsHappy(t *testing.T) {
 	job := cltest.NewJob()
 	var initr models.Initiator
 	require.NoError(t, json.Unmarshal([]byte(validInitiator), &initr))
-	initr.Feeds = cltest.JSONFromString(t, `["https://lambda.staging.devnet.tools/bnc/call", {"bridge": "testbridge"}]`)
+	initr.Feeds = cltest.JSONFromString(t, `[
+		"https://lambda.staging.devnet.tools/bnc/call",
+		{"bridge": "testbridge"},
+		{"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}, {"type": "testbridge"}, {"type": "jsonparse", "params": {"path": ["last"]}}]}
+	]`)
 	err := services.ValidateInitiator(initr, job, store)
 	require.NoError(t, err)
 }
@@ -484,6 +559,10 @@ func TestValidateInitiator_FeedsErrors(t *testing.T) {
 		{"missing bridge", `[{"bridgeName": "doesnotexist"}]`},
 		{"unsupported bridge properties", `[{"bridge": "testbridge", "foo": "bar"}]`},
 		{"invalid entry", `["http://example.com", {"bridge": "testbridge"}, 1]`},
+		{"empty pipeline", `[{"tasks": []}]`},
+		{"pipeline with unknown task", `[{"tasks": [{"type": "doesnotexist"}]}]`},
+		{"pipeline with ethtx", `[{"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}, {"type": "ethtx"}]}]`},
+		{"unsupported pipeline properties", `[{"tasks": [{"type": "httpget", "params": {"get": "https://example.com"}}], "bridge": "testbridge"}]`},
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
@@ -0,0 +1,782 @@
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// instruction is an instruction compiled for the interpreter. Blocks and
+// loops compile to nothing, as branches are resolved to the index of the
+// instruction they continue at. Numeric instructions are given the number of
+// values they take, and memory instructions their offset and access size.
+type instruction struct {
+	op uint16
+	a  uint64
+	b  uint64
+}
+
+// Opcodes of the instructions the compiler resolves specially, along with
+// the prefixed ones, which are given as prefix<<8|opcode.
+const (
+	opUnreachable  = 0x00
+	opNop          = 0x01
+	opBlock        = 0x02
+	opLoop         = 0x03
+	opIf           = 0x04
+	opElse         = 0x05
+	opEnd          = 0x0b
+	opBr           = 0x0c
+	opBrIf         = 0x0d
+	opBrTable      = 0x0e
+	opReturn       = 0x0f
+	opCall         = 0x10
+	opCallIndirect = 0x11
+	opDrop         = 0x1a
+	opSelect       = 0x1b
+	opSelectTyped  = 0x1c
+	opLocalGet     = 0x20
+	opLocalSet     = 0x21
+	opLocalTee     = 0x22
+	opGlobalGet    = 0x23
+	opGlobalSet    = 0x24
+	opMemorySize   = 0x3f
+	opMemoryGrow   = 0x40
+	opI32Const     = 0x41
+	opI64Const     = 0x42
+	opF32Const     = 0x43
+	opF64Const     = 0x44
+
+	opPrefix     = 0xfc
+	opMemoryCopy = opPrefix<<8 | 10
+	opMemoryFill = opPrefix<<8 | 11
+)
+
+// branchTarget is where a branch continues: the index of the instruction,
+// the number of values it carries and the height of the operand stack,
+// relative to the function's, to leave them at.
+type branchTarget struct {
+	pc     int
+	arity  int
+	height int
+}
+
+func (t branchTarget) encode() (uint64, uint64) {
+	return uint64(t.pc), uint64(t.arity)<<32 | uint64(t.height)
+}
+
+func decodeBranchTarget(a, b uint64) branchTarget {
+	return branchTarget{pc: int(a), arity: int(b >> 32), height: int(uint32(b))}
+}
+
+// memoryAccess gives the type and the size in bytes of the value loaded or
+// stored by each memory instruction.
+var memoryAccess = map[byte]struct {
+	valueType valueType
+	size      uint32
+	store     bool
+}{
+	0x28: {i32, 4, false}, 0x29: {i64, 8, false}, 0x2a: {f32, 4, false}, 0x2b: {f64, 8, false},
+	0x2c: {i32, 1, false}, 0x2d: {i32, 1, false}, 0x2e: {i32, 2, false}, 0x2f: {i32, 2, false},
+	0x30: {i64, 1, false}, 0x31: {i64, 1, false}, 0x32: {i64, 2, false}, 0x33: {i64, 2, false},
+	0x34: {i64, 4, false}, 0x35: {i64, 4, false},
+	0x36: {i32, 4, true}, 0x37: {i64, 8, true}, 0x38: {f32, 4, true}, 0x39: {f64, 8, true},
+	0x3a: {i32, 1, true}, 0x3b: {i32, 2, true}, 0x3c: {i64, 1, true}, 0x3d: {i64, 2, true},
+	0x3e: {i64, 4, true},
+}
+
+// conversions gives the param and the result of each conversion instruction.
+var conversions = map[uint16][2]valueType{
+	0xa7: {i64, i32}, 0xa8: {f32, i32}, 0xa9: {f32, i32}, 0xaa: {f64, i32}, 0xab: {f64, i32},
+	0xac: {i32, i64}, 0xad: {i32, i64}, 0xae: {f32, i64}, 0xaf: {f32, i64}, 0xb0: {f64, i64},
+	0xb1: {f64, i64}, 0xb2: {i32, f32}, 0xb3: {i32, f32}, 0xb4: {i64, f32}, 0xb5: {i64, f32},
+	0xb6: {f64, f32}, 0xb7: {i32, f64}, 0xb8: {i32, f64}, 0xb9: {i64, f64}, 0xba: {i64, f64},
+	0xbb: {f32, f64}, 0xbc: {f32, i32}, 0xbd: {f64, i64}, 0xbe: {i32, f32}, 0xbf: {i64, f64},
+	0xc0: {i32, i32}, 0xc1: {i32, i32}, 0xc2: {i64, i64}, 0xc3: {i64, i64}, 0xc4: {i64, i64},
+	opPrefix<<8 | 0: {f32, i32}, opPrefix<<8 | 1: {f32, i32},
+	opPrefix<<8 | 2: {f64, i32}, opPrefix<<8 | 3: {f64, i32},
+	opPrefix<<8 | 4: {f32, i64}, opPrefix<<8 | 5: {f32, i64},
+	opPrefix<<8 | 6: {f64, i64}, opPrefix<<8 | 7: {f64, i64},
+}
+
+// numericType returns the params and the result of a numeric instruction.
+func numericType(op uint16) ([]valueType, valueType, bool) {
+	unary := func(t, result valueType) ([]valueType, valueType, bool) {
+		return []valueType{t}, result, true
+	}
+	binary := func(t, result valueType) ([]valueType, valueType, bool) {
+		return []valueType{t, t}, result, true
+	}
+	switch {
+	case op == 0x45:
+		return unary(i32, i32)
+	case op >= 0x46 && op <= 0x4f:
+		return binary(i32, i32)
+	case op == 0x50:
+		return unary(i64, i32)
+	case op >= 0x51 && op <= 0x5a:
+		return binary(i64, i32)
+	case op >= 0x5b && op <= 0x60:
+		return binary(f32, i32)
+	case op >= 0x61 && op <= 0x66:
+		return binary(f64, i32)
+	case op >= 0x67 && op <= 0x69:
+		return unary(i32, i32)
+	case op >= 0x6a && op <= 0x78:
+		return binary(i32, i32)
+	case op >= 0x79 && op <= 0x7b:
+		return unary(i64, i64)
+	case op >= 0x7c && op <= 0x8a:
+		return binary(i64, i64)
+	case op >= 0x8b && op <= 0x91:
+		return unary(f32, f32)
+	case op >= 0x92 && op <= 0x98:
+		return binary(f32, f32)
+	case op >= 0x99 && op <= 0x9f:
+		return unary(f64, f64)
+	case op >= 0xa0 && op <= 0xa6:
+		return binary(f64, f64)
+	}
+	if c, ok := conversions[op]; ok {
+		return unary(c[0], c[1])
+	}
+	return nil, 0, false
+}
+
+// controlFrame is a block, loop, if or the function body being compiled.
+type controlFrame struct {
+	op          byte
+	params      []valueType
+	results     []valueType
+	height      int
+	unreachable bool
+	// start is the index of the first instruction of a loop.
+	start int
+	// ifIndex is the index of an if instruction until its else is reached.
+	ifIndex int
+	// branches are the indexes of the instructions branching to the end of
+	// the frame, resolved when it is reached.
+	branches []int
+	// tableBranches are the br_table entries branching to the end of the
+	// frame.
+	tableBranches [][2]int
+}
+
+func (f *controlFrame) labelTypes() []valueType {
+	if f.op == opLoop {
+		return f.params
+	}
+	return f.results
+}
+
+// compiler validates a function body as the spec's validation algorithm
+// does, compiling it as it goes.
+type compiler struct {
+	module    *module
+	function  *function
+	locals    []valueType
+	reader    *reader
+	values    []valueType
+	frames    []controlFrame
+	maxHeight int
+}
+
+// compileFunction validates the body of a function and compiles it to
+// instructions.
+func compileFunction(m *module, f *function) error {
+	r := &reader{bytes: f.body}
+	ft := m.types[f.typeIndex]
+	locals := append([]valueType{}, ft.params...)
+	groups, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < groups; i++ {
+		count, err := r.u32()
+		if err != nil {
+			return err
+		}
+		t, err := r.valueType()
+		if err != nil {
+			return err
+		}
+		if uint64(len(locals))+uint64(count) > maxLocals {
+			return errors.New("too many locals")
+		}
+		for j := uint32(0); j < count; j++ {
+			locals = append(locals, t)
+		}
+	}
+	f.locals = locals[len(ft.params):]
+
+	c := &compiler{module: m, function: f, locals: locals, reader: r}
+	c.pushFrame(0, nil, ft.results)
+	for len(c.frames) > 0 {
+		if r.done() {
+			return errUnexpectedEnd
+		}
+		if err := c.instruction(); err != nil {
+			return errors.Wrapf(err, "at byte %d", r.pos)
+		}
+	}
+	if !r.done() {
+		return errors.New("unexpected bytes after the end of the function")
+	}
+	f.maxHeight = c.maxHeight
+	f.body = nil
+	return nil
+}
+
+func (c *compiler) emit(op uint16, a, b uint64) int {
+	c.function.code = append(c.function.code, instruction{op: op, a: a, b: b})
+	return len(c.function.code) - 1
+}
+
+func (c *compiler) push(t valueType) {
+	c.values = append(c.values, t)
+	if len(c.values) > c.maxHeight {
+		c.maxHeight = len(c.values)
+	}
+}
+
+func (c *compiler) pushAll(types []valueType) {
+	for _, t := range types {
+		c.push(t)
+	}
+}
+
+func (c *compiler) pop() (valueType, error) {
+	frame := &c.frames[len(c.frames)-1]
+	if len(c.values) == frame.height {
+		if frame.unreachable {
+			return unknownType, nil
+		}
+		return 0, errors.New("type mismatch: operand stack underflow")
+	}
+	t := c.values[len(c.values)-1]
+	c.values = c.values[:len(c.values)-1]
+	return t, nil
+}
+
+func (c *compiler) popExpect(expected valueType) (valueType, error) {
+	actual, err := c.pop()
+	if err != nil {
+		return 0, err
+	}
+	if actual == unknownType {
+		return expected, nil
+	}
+	if expected != unknownType && actual != expected {
+		return 0, fmt.Errorf("type mismatch: expected %s, got %s", expected, actual)
+	}
+	return actual, nil
+}
+
+func (c *compiler) popAll(types []valueType) error {
+	for i := len(types) - 1; i >= 0; i-- {
+		if _, err := c.popExpect(types[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiler) pushFrame(op byte, params, results []valueType) {
+	c.frames = append(c.frames, controlFrame{
+		op:      op,
+		params:  params,
+		results: results,
+		height:  len(c.values),
+		start:   len(c.function.code),
+		ifIndex: -1,
+	})
+	c.pushAll(params)
+}
+
+func (c *compiler) popFrame() (controlFrame, error) {
+	if len(c.frames) == 0 {
+		return controlFrame{}, errors.New("unexpected end")
+	}
+	frame := c.frames[len(c.frames)-1]
+	if err := c.popAll(frame.results); err != nil {
+		return frame, err
+	}
+	if len(c.values) != frame.height {
+		return frame, errors.New("type mismatch: values remaining at the end of a block")
+	}
+	c.frames = c.frames[:len(c.frames)-1]
+	return frame, nil
+}
+
+func (c *compiler) setUnreachable() {
+	frame := &c.frames[len(c.frames)-1]
+	c.values = c.values[:frame.height]
+	frame.unreachable = true
+}
+
+// resolveEnd points the branches to the end of a frame at the next
+// instruction.
+func (c *compiler) resolveEnd(frame controlFrame) {
+	end := uint64(len(c.function.code))
+	for _, index := range frame.branches {
+		c.function.code[index].a = end
+	}
+	for _, entry := range frame.tableBranches {
+		c.function.brTables[entry[0]][entry[1]].pc = int(end)
+	}
+}
+
+// target returns the target of a branch to the given label, and the frame
+// to resolve it when its end is unknown.
+func (c *compiler) target(depth uint32) (branchTarget, *controlFrame, error) {
+	if int(depth) >= len(c.frames) {
+		return branchTarget{}, nil, fmt.Errorf("unknown label %d", depth)
+	}
+	frame := &c.frames[len(c.frames)-1-int(depth)]
+	target := branchTarget{arity: len(frame.labelTypes()), height: frame.height}
+	if frame.op == opLoop {
+		target.pc = frame.start
+		return target, nil, nil
+	}
+	return target, frame, nil
+}
+
+func (c *compiler) emitBranch(op uint16, depth uint32) error {
+	target, frame, err := c.target(depth)
+	if err != nil {
+		return err
+	}
+	a, b := target.encode()
+	index := c.emit(op, a, b)
+	if frame != nil {
+		frame.branches = append(frame.branches, index)
+	}
+	return nil
+}
+
+func (c *compiler) blockType() ([]valueType, []valueType, error) {
+	b, err := c.reader.byte()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch valueType(b) {
+	case 0x40:
+		return nil, nil, nil
+	case i32, i64, f32, f64:
+		return nil, []valueType{valueType(b)}, nil
+	}
+	c.reader.pos--
+	index, err := c.reader.s33()
+	if err != nil {
+		return nil, nil, err
+	}
+	if index < 0 || index >= int64(len(c.module.types)) {
+		return nil, nil, fmt.Errorf("invalid block type %d", index)
+	}
+	ft := c.module.types[index]
+	return ft.params, ft.results, nil
+}
+
+func (c *compiler) memoryIndex() error {
+	index, err := c.reader.byte()
+	if err != nil {
+		return err
+	}
+	if index != 0 {
+		return errors.New("memory index must be zero")
+	}
+	if c.module.memory == nil {
+		return errors.New("unknown memory")
+	}
+	return nil
+}
+
+func (c *compiler) local() (uint32, valueType, error) {
+	index, err := c.reader.u32()
+	if err != nil {
+		return 0, 0, err
+	}
+	if int(index) >= len(c.locals) {
+		return 0, 0, fmt.Errorf("unknown local %d", index)
+	}
+	return index, c.locals[index], nil
+}
+
+func (c *compiler) global() (uint32, global, error) {
+	index, err := c.reader.u32()
+	if err != nil {
+		return 0, global{}, err
+	}
+	if int(index) >= len(c.module.globals) {
+		return 0, global{}, fmt.Errorf("unknown global %d", index)
+	}
+	return index, c.module.globals[index], nil
+}
+
+func (c *compiler) call(ft functionType) error {
+	if err := c.popAll(ft.params); err != nil {
+		return err
+	}
+	c.pushAll(ft.results)
+	return nil
+}
+
+// instruction validates and compiles the next instruction.
+func (c *compiler) instruction() error {
+	r := c.reader
+	b, err := r.byte()
+	if err != nil {
+		return err
+	}
+	op := uint16(b)
+	switch op {
+	case opUnreachable:
+		c.emit(op, 0, 0)
+		c.setUnreachable()
+	case opNop:
+	case opBlock, opLoop, opIf:
+		params, results, err := c.blockType()
+		if err != nil {
+			return err
+		}
+		if op == opIf {
+			if _, err := c.popExpect(i32); err != nil {
+				return err
+			}
+		}
+		if err := c.popAll(params); err != nil {
+			return err
+		}
+		c.pushFrame(byte(op), params, results)
+		if op == opIf {
+			c.frames[len(c.frames)-1].ifIndex = c.emit(op, 0, 0)
+		}
+	case opElse:
+		frame, err := c.popFrame()
+		if err != nil {
+			return err
+		}
+		if frame.op != opIf {
+			return errors.New("else without if")
+		}
+		// The end of the then branch continues after the else branch
+		frame.branches = append(frame.branches, c.emit(op, 0, 0))
+		c.function.code[frame.ifIndex].a = uint64(len(c.function.code))
+		frame.ifIndex = -1
+		frame.op = opElse
+		frame.unreachable = false
+		c.frames = append(c.frames, frame)
+		c.pushAll(frame.params)
+	case opEnd:
+		frame, err := c.popFrame()
+		if err != nil {
+			return err
+		}
+		if frame.ifIndex >= 0 {
+			// An if without an else passes its params through
+			if !equalTypes(frame.params, frame.results) {
+				return errors.New("type mismatch: if without else must not change the stack")
+			}
+			c.function.code[frame.ifIndex].a = uint64(len(c.function.code))
+		}
+		c.resolveEnd(frame)
+		c.pushAll(frame.results)
+		if len(c.frames) == 0 {
+			c.emit(opReturn, 0, 0)
+		}
+	case opBr, opBrIf:
+		depth, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if op == opBrIf {
+			if _, err := c.popExpect(i32); err != nil {
+				return err
+			}
+		}
+		if int(depth) >= len(c.frames) {
+			return fmt.Errorf("unknown label %d", depth)
+		}
+		labelTypes := c.frames[len(c.frames)-1-int(depth)].labelTypes()
+		if err := c.popAll(labelTypes); err != nil {
+			return err
+		}
+		if err := c.emitBranch(op, depth); err != nil {
+			return err
+		}
+		if op == opBr {
+			c.setUnreachable()
+		} else {
+			c.pushAll(labelTypes)
+		}
+	case opBrTable:
+		count, err := r.count()
+		if err != nil {
+			return err
+		}
+		depths := make([]uint32, count+1)
+		for i := range depths {
+			if depths[i], err = r.u32(); err != nil {
+				return err
+			}
+		}
+		if _, err := c.popExpect(i32); err != nil {
+			return err
+		}
+		table := make([]branchTarget, len(depths))
+		tableIndex := len(c.function.brTables)
+		var arity = -1
+		for i, depth := range depths {
+			target, frame, err := c.target(depth)
+			if err != nil {
+				return err
+			}
+			if arity >= 0 && target.arity != arity {
+				return errors.New("type mismatch: br_table targets of different arities")
+			}
+			arity = target.arity
+			// Each label's types must match the values on the stack
+			labelTypes := c.frames[len(c.frames)-1-int(depth)].labelTypes()
+			if err := c.popAll(labelTypes); err != nil {
+				return err
+			}
+			c.pushAll(labelTypes)
+			table[i] = target
+			if frame != nil {
+				frame.tableBranches = append(frame.tableBranches, [2]int{tableIndex, i})
+			}
+		}
+		c.function.brTables = append(c.function.brTables, table)
+		c.emit(op, uint64(tableIndex), 0)
+		c.setUnreachable()
+	case opReturn:
+		if err := c.popAll(c.frames[0].results); err != nil {
+			return err
+		}
+		c.emit(op, 0, 0)
+		c.setUnreachable()
+	case opCall:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(index) >= c.module.functionCount() {
+			return fmt.Errorf("unknown function %d", index)
+		}
+		if err := c.call(c.module.functionType(index)); err != nil {
+			return err
+		}
+		c.emit(op, uint64(index), 0)
+	case opCallIndirect:
+		typeIndex, err := r.u32()
+		if err != nil {
+			return err
+		}
+		tableIndex, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if tableIndex != 0 || c.module.table == nil {
+			return errors.New("unknown table")
+		}
+		if int(typeIndex) >= len(c.module.types) {
+			return fmt.Errorf("unknown type %d", typeIndex)
+		}
+		if _, err := c.popExpect(i32); err != nil {
+			return err
+		}
+		if err := c.call(c.module.types[typeIndex]); err != nil {
+			return err
+		}
+		c.emit(op, uint64(typeIndex), 0)
+	case opDrop:
+		if _, err := c.pop(); err != nil {
+			return err
+		}
+		c.emit(op, 0, 0)
+	case opSelect, opSelectTyped:
+		var t valueType
+		if op == opSelectTyped {
+			types, err := r.valueTypes()
+			if err != nil {
+				return err
+			}
+			if len(types) != 1 {
+				return errors.New("select must have a single type")
+			}
+			t = types[0]
+		}
+		if _, err := c.popExpect(i32); err != nil {
+			return err
+		}
+		first, err := c.popExpect(t)
+		if err != nil {
+			return err
+		}
+		second, err := c.popExpect(t)
+		if err != nil {
+			return err
+		}
+		if first != unknownType && second != unknownType && first != second {
+			return errors.New("type mismatch: select operands of different types")
+		}
+		if first == unknownType {
+			first = second
+		}
+		c.push(first)
+		c.emit(opSelect, 0, 0)
+	case opLocalGet, opLocalSet, opLocalTee:
+		index, t, err := c.local()
+		if err != nil {
+			return err
+		}
+		if op != opLocalGet {
+			if _, err := c.popExpect(t); err != nil {
+				return err
+			}
+		}
+		if op != opLocalSet {
+			c.push(t)
+		}
+		c.emit(op, uint64(index), 0)
+	case opGlobalGet, opGlobalSet:
+		index, g, err := c.global()
+		if err != nil {
+			return err
+		}
+		if op == opGlobalGet {
+			c.push(g.valueType)
+		} else {
+			if !g.mutable {
+				return fmt.Errorf("global %d is immutable", index)
+			}
+			if _, err := c.popExpect(g.valueType); err != nil {
+				return err
+			}
+		}
+		c.emit(op, uint64(index), 0)
+	case opMemorySize, opMemoryGrow:
+		if err := c.memoryIndex(); err != nil {
+			return err
+		}
+		if op == opMemoryGrow {
+			if _, err := c.popExpect(i32); err != nil {
+				return err
+			}
+		}
+		c.push(i32)
+		c.emit(op, 0, 0)
+	case opI32Const:
+		v, err := r.s32()
+		if err != nil {
+			return err
+		}
+		c.push(i32)
+		c.emit(op, uint64(uint32(v)), 0)
+	case opI64Const:
+		v, err := r.s64()
+		if err != nil {
+			return err
+		}
+		c.push(i64)
+		c.emit(op, uint64(v), 0)
+	case opF32Const:
+		v, err := r.fixed32()
+		if err != nil {
+			return err
+		}
+		c.push(f32)
+		c.emit(op, uint64(v), 0)
+	case opF64Const:
+		v, err := r.fixed64()
+		if err != nil {
+			return err
+		}
+		c.push(f64)
+		c.emit(op, v, 0)
+	case opPrefix:
+		sub, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if sub > 0xff {
+			return fmt.Errorf("unsupported instruction 0xfc %d", sub)
+		}
+		return c.prefixed(opPrefix<<8 | uint16(sub))
+	default:
+		if access, ok := memoryAccess[b]; ok {
+			return c.memoryInstruction(op, access.valueType, access.size, access.store)
+		}
+		params, result, ok := numericType(op)
+		if !ok {
+			return fmt.Errorf("unsupported instruction 0x%x", op)
+		}
+		if err := c.popAll(params); err != nil {
+			return err
+		}
+		c.push(result)
+		c.emit(op, 0, uint64(len(params)))
+	}
+	return nil
+}
+
+func (c *compiler) memoryInstruction(op uint16, t valueType, size uint32, store bool) error {
+	align, err := c.reader.u32()
+	if err != nil {
+		return err
+	}
+	offset, err := c.reader.u32()
+	if err != nil {
+		return err
+	}
+	if c.module.memory == nil {
+		return errors.New("unknown memory")
+	}
+	if align >= 32 || 1<<align > size {
+		return errors.New("alignment must not be larger than natural")
+	}
+	if store {
+		if _, err := c.popExpect(t); err != nil {
+			return err
+		}
+		if _, err := c.popExpect(i32); err != nil {
+			return err
+		}
+	} else {
+		if _, err := c.popExpect(i32); err != nil {
+			return err
+		}
+		c.push(t)
+	}
+	c.emit(op, uint64(offset), uint64(size))
+	return nil
+}
+
+func (c *compiler) prefixed(op uint16) error {
+	switch op {
+	case opMemoryCopy, opMemoryFill:
+		if err := c.memoryIndex(); err != nil {
+			return err
+		}
+		if op == opMemoryCopy {
+			if err := c.memoryIndex(); err != nil {
+				return err
+			}
+		}
+		if err := c.popAll([]valueType{i32, i32, i32}); err != nil {
+			return err
+		}
+		c.emit(op, 0, 0)
+		return nil
+	}
+	params, result, ok := numericType(op)
+	if !ok {
+		return fmt.Errorf("unsupported instruction 0xfc %d", op&0xff)
+	}
+	if err := c.popAll(params); err != nil {
+		return err
+	}
+	c.push(result)
+	c.emit(op, 0, uint64(len(params)))
+	return nil
+}
@@ -0,0 +1,802 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxPages is the most 64KiB pages a memory can have.
+	maxPages = 65536
+	// maxTableSize is the most elements a module's table can have.
+	maxTableSize = 65536
+	// maxLocals is the most locals, including its params, a function can
+	// declare.
+	maxLocals = 50000
+)
+
+// valueType is the type of a wasm value.
+type valueType byte
+
+const (
+	unknownType valueType = 0
+	i32         valueType = 0x7f
+	i64         valueType = 0x7e
+	f32         valueType = 0x7d
+	f64         valueType = 0x7c
+)
+
+func (t valueType) String() string {
+	switch t {
+	case i32:
+		return "i32"
+	case i64:
+		return "i64"
+	case f32:
+		return "f32"
+	case f64:
+		return "f64"
+	default:
+		return "unknown"
+	}
+}
+
+type functionType struct {
+	params  []valueType
+	results []valueType
+}
+
+func (ft functionType) equal(other functionType) bool {
+	return equalTypes(ft.params, other.params) && equalTypes(ft.results, other.results)
+}
+
+func equalTypes(a, b []valueType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type limits struct {
+	min uint32
+	max uint32
+	// hasMax is false when the maximum was not given.
+	hasMax bool
+}
+
+type global struct {
+	valueType valueType
+	mutable   bool
+	init      uint64
+}
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+const (
+	externalFunction byte = 0x00
+	externalTable    byte = 0x01
+	externalMemory   byte = 0x02
+	externalGlobal   byte = 0x03
+)
+
+type elementSegment struct {
+	offset    uint32
+	functions []uint32
+}
+
+type dataSegment struct {
+	offset uint32
+	data   []byte
+}
+
+// function is a function defined by a module, compiled to instructions the
+// interpreter runs.
+type function struct {
+	typeIndex uint32
+	// locals holds the types of the locals following the params.
+	locals   []valueType
+	body     []byte
+	code     []instruction
+	brTables [][]branchTarget
+	// maxHeight is the highest the operand stack of the function gets.
+	maxHeight int
+}
+
+// module is a decoded and validated wasm module.
+type module struct {
+	types     []functionType
+	imports   []hostFunction
+	functions []function
+	table     *limits
+	memory    *limits
+	globals   []global
+	exports   map[string]export
+	start     *uint32
+	elements  []elementSegment
+	data      []dataSegment
+}
+
+// functionType returns the type of the function with the given index, which
+// counts imported functions first.
+func (m *module) functionType(index uint32) functionType {
+	if int(index) < len(m.imports) {
+		return m.imports[index].functionType
+	}
+	return m.types[m.functions[int(index)-len(m.imports)].typeIndex]
+}
+
+func (m *module) functionCount() int {
+	return len(m.imports) + len(m.functions)
+}
+
+var errUnexpectedEnd = errors.New("unexpected end")
+
+// reader decodes the binary format of wasm modules.
+type reader struct {
+	bytes []byte
+	pos   int
+}
+
+func (r *reader) done() bool {
+	return r.pos >= len(r.bytes)
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.bytes) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.bytes[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) read(n uint32) ([]byte, error) {
+	if uint64(n) > uint64(len(r.bytes)-r.pos) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.bytes[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// leb128 decodes an integer of the given number of bits, sign extending it
+// if signed.
+func (r *reader) leb128(bits uint, signed bool) (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= bits {
+			return 0, errors.New("integer representation too long")
+		}
+		result |= uint64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			remaining := int(bits) - int(shift)
+			if remaining < 0 {
+				// The unused bits of the last byte must be zero, or extend
+				// the sign of a signed integer
+				if signed {
+					unused := int8(b<<1) >> uint(8+remaining-1)
+					if unused != 0 && unused != -1 {
+						return 0, errors.New("integer too large")
+					}
+				} else if int8(b<<1)>>uint(8+remaining) != 0 {
+					return 0, errors.New("integer too large")
+				}
+			}
+			if signed && shift < 64 && b&0x40 != 0 {
+				result |= ^uint64(0) << shift
+			}
+			return result, nil
+		}
+	}
+}
+
+func (r *reader) u32() (uint32, error) {
+	v, err := r.leb128(32, false)
+	return uint32(v), err
+}
+
+func (r *reader) s32() (int32, error) {
+	v, err := r.leb128(32, true)
+	return int32(v), err
+}
+
+func (r *reader) s33() (int64, error) {
+	v, err := r.leb128(33, true)
+	return int64(v), err
+}
+
+func (r *reader) s64() (int64, error) {
+	v, err := r.leb128(64, true)
+	return int64(v), err
+}
+
+func (r *reader) fixed32() (uint32, error) {
+	b, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *reader) fixed64() (uint64, error) {
+	b, err := r.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (r *reader) name() (string, error) {
+	length, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.read(length)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8 name")
+	}
+	return string(b), nil
+}
+
+func (r *reader) valueType() (valueType, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	switch t := valueType(b); t {
+	case i32, i64, f32, f64:
+		return t, nil
+	default:
+		return 0, fmt.Errorf("unsupported value type 0x%x", b)
+	}
+}
+
+func (r *reader) valueTypes() ([]valueType, error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(count) > uint64(len(r.bytes)-r.pos) {
+		return nil, errUnexpectedEnd
+	}
+	types := make([]valueType, count)
+	for i := range types {
+		if types[i], err = r.valueType(); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+func (r *reader) limits(max uint32) (limits, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return limits{}, err
+	}
+	var l limits
+	if l.min, err = r.u32(); err != nil {
+		return l, err
+	}
+	switch flag {
+	case 0x00:
+	case 0x01:
+		if l.max, err = r.u32(); err != nil {
+			return l, err
+		}
+		l.hasMax = true
+		if l.max < l.min {
+			return l, errors.New("size minimum must not be greater than maximum")
+		}
+	default:
+		return l, fmt.Errorf("invalid limits flag 0x%x", flag)
+	}
+	if l.min > max || l.hasMax && l.max > max {
+		return l, fmt.Errorf("size must be at most %d", max)
+	}
+	return l, nil
+}
+
+// count reads the length of a vector, each item of which takes at least a
+// byte, so that a corrupt length cannot make the decoder allocate more than
+// the size of the module.
+func (r *reader) count() (uint32, error) {
+	count, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(count) > uint64(len(r.bytes)-r.pos) {
+		return 0, errUnexpectedEnd
+	}
+	return count, nil
+}
+
+// decode decodes and validates the binary module, resolving its imports
+// against the host functions.
+func decode(binary []byte, hostFunctions map[string]hostFunction) (*module, error) {
+	r := &reader{bytes: binary}
+	header, err := r.read(8)
+	if err != nil || string(header[:4]) != "\x00asm" {
+		return nil, errors.New("not a wasm module")
+	}
+	if header[4] != 1 || header[5] != 0 || header[6] != 0 || header[7] != 0 {
+		return nil, errors.New("unsupported wasm version")
+	}
+
+	m := &module{exports: make(map[string]export)}
+	var functionTypes []uint32
+	var codes [][]byte
+	var lastID byte
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := r.read(size)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			// Custom sections, such as names, are ignored
+			continue
+		}
+		// The data count section comes before the code section
+		order := id
+		if id == 12 {
+			order = 9
+		} else if id >= 10 {
+			order = id + 1
+		}
+		if order <= lastID {
+			return nil, fmt.Errorf("section %d out of order", id)
+		}
+		lastID = order
+
+		s := &reader{bytes: payload}
+		switch id {
+		case 1:
+			err = m.decodeTypes(s)
+		case 2:
+			err = m.decodeImports(s, hostFunctions)
+		case 3:
+			functionTypes, err = m.decodeFunctions(s)
+		case 4:
+			err = m.decodeTable(s)
+		case 5:
+			err = m.decodeMemory(s)
+		case 6:
+			err = m.decodeGlobals(s)
+		case 7:
+			err = m.decodeExports(s)
+		case 8:
+			err = m.decodeStart(s)
+		case 9:
+			err = m.decodeElements(s)
+		case 10:
+			codes, err = decodeCodes(s)
+		case 11:
+			err = m.decodeData(s)
+		case 12:
+			_, err = s.u32()
+		default:
+			err = fmt.Errorf("unknown section %d", id)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "section %d", id)
+		}
+		if !s.done() {
+			return nil, fmt.Errorf("section %d has unexpected trailing bytes", id)
+		}
+	}
+
+	if len(functionTypes) != len(codes) {
+		return nil, errors.New("function and code section have inconsistent lengths")
+	}
+	m.functions = make([]function, len(codes))
+	for i, code := range codes {
+		m.functions[i] = function{typeIndex: functionTypes[i], body: code}
+	}
+	if err := m.validateIndexes(); err != nil {
+		return nil, err
+	}
+	for i := range m.functions {
+		if err := compileFunction(m, &m.functions[i]); err != nil {
+			return nil, errors.Wrapf(err, "function %d", len(m.imports)+i)
+		}
+	}
+	return m, nil
+}
+
+func (m *module) decodeTypes(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	m.types = make([]functionType, count)
+	for i := range m.types {
+		form, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if form != 0x60 {
+			return fmt.Errorf("invalid function type form 0x%x", form)
+		}
+		if m.types[i].params, err = r.valueTypes(); err != nil {
+			return err
+		}
+		if m.types[i].results, err = r.valueTypes(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *module) decodeImports(r *reader, hostFunctions map[string]hostFunction) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		moduleName, err := r.name()
+		if err != nil {
+			return err
+		}
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if kind != externalFunction {
+			return fmt.Errorf("import %s.%s: only functions can be imported", moduleName, name)
+		}
+		typeIndex, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(typeIndex) >= len(m.types) {
+			return fmt.Errorf("import %s.%s: unknown type %d", moduleName, name, typeIndex)
+		}
+		host, ok := hostFunctions[name]
+		if moduleName != hostModuleName || !ok {
+			return fmt.Errorf("unknown import %s.%s", moduleName, name)
+		}
+		if !m.types[typeIndex].equal(host.functionType) {
+			return fmt.Errorf("import %s.%s has the wrong type", moduleName, name)
+		}
+		m.imports = append(m.imports, host)
+	}
+	return nil
+}
+
+func (m *module) decodeFunctions(r *reader) ([]uint32, error) {
+	count, err := r.count()
+	if err != nil {
+		return nil, err
+	}
+	typeIndexes := make([]uint32, count)
+	for i := range typeIndexes {
+		if typeIndexes[i], err = r.u32(); err != nil {
+			return nil, err
+		}
+		if int(typeIndexes[i]) >= len(m.types) {
+			return nil, fmt.Errorf("unknown type %d", typeIndexes[i])
+		}
+	}
+	return typeIndexes, nil
+}
+
+func (m *module) decodeTable(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	if count > 1 {
+		return errors.New("multiple tables")
+	}
+	for i := uint32(0); i < count; i++ {
+		elementType, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if elementType != 0x70 {
+			return fmt.Errorf("unsupported table element type 0x%x", elementType)
+		}
+		l, err := r.limits(maxTableSize)
+		if err != nil {
+			return err
+		}
+		m.table = &l
+	}
+	return nil
+}
+
+func (m *module) decodeMemory(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	if count > 1 {
+		return errors.New("multiple memories")
+	}
+	for i := uint32(0); i < count; i++ {
+		l, err := r.limits(maxPages)
+		if err != nil {
+			return err
+		}
+		m.memory = &l
+	}
+	return nil
+}
+
+// constant evaluates a constant expression of the given type.
+func (m *module) constant(r *reader, t valueType) (uint64, error) {
+	op, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	var value uint64
+	var actual valueType
+	switch op {
+	case 0x41:
+		v, err := r.s32()
+		value, actual = uint64(uint32(v)), i32
+		if err != nil {
+			return 0, err
+		}
+	case 0x42:
+		v, err := r.s64()
+		value, actual = uint64(v), i64
+		if err != nil {
+			return 0, err
+		}
+	case 0x43:
+		v, err := r.fixed32()
+		value, actual = uint64(v), f32
+		if err != nil {
+			return 0, err
+		}
+	case 0x44:
+		v, err := r.fixed64()
+		value, actual = v, f64
+		if err != nil {
+			return 0, err
+		}
+	case 0x23:
+		index, err := r.u32()
+		if err != nil {
+			return 0, err
+		}
+		if int(index) >= len(m.globals) || m.globals[index].mutable {
+			return 0, fmt.Errorf("constant expression references global %d", index)
+		}
+		value, actual = m.globals[index].init, m.globals[index].valueType
+	default:
+		return 0, fmt.Errorf("unsupported constant expression 0x%x", op)
+	}
+	if actual != t {
+		return 0, fmt.Errorf("constant expression of type %s, expected %s", actual, t)
+	}
+	end, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	if end != 0x0b {
+		return 0, errors.New("constant expression must be a single instruction")
+	}
+	return value, nil
+}
+
+func (m *module) decodeGlobals(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		t, err := r.valueType()
+		if err != nil {
+			return err
+		}
+		mutability, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if mutability > 1 {
+			return fmt.Errorf("invalid global mutability 0x%x", mutability)
+		}
+		init, err := m.constant(r, t)
+		if err != nil {
+			return err
+		}
+		m.globals = append(m.globals, global{valueType: t, mutable: mutability == 1, init: init})
+	}
+	return nil
+}
+
+func (m *module) decodeExports(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if kind > externalGlobal {
+			return fmt.Errorf("invalid export kind 0x%x", kind)
+		}
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if _, ok := m.exports[name]; ok {
+			return fmt.Errorf("duplicate export %s", name)
+		}
+		m.exports[name] = export{kind: kind, index: index}
+	}
+	return nil
+}
+
+func (m *module) decodeStart(r *reader) error {
+	index, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.start = &index
+	return nil
+}
+
+func (m *module) decodeElements(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		flags, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flags != 0 {
+			return errors.New("only active element segments of function indexes are supported")
+		}
+		offset, err := m.constant(r, i32)
+		if err != nil {
+			return err
+		}
+		length, err := r.count()
+		if err != nil {
+			return err
+		}
+		segment := elementSegment{offset: uint32(offset), functions: make([]uint32, length)}
+		for j := range segment.functions {
+			if segment.functions[j], err = r.u32(); err != nil {
+				return err
+			}
+		}
+		m.elements = append(m.elements, segment)
+	}
+	return nil
+}
+
+func decodeCodes(r *reader) ([][]byte, error) {
+	count, err := r.count()
+	if err != nil {
+		return nil, err
+	}
+	codes := make([][]byte, count)
+	for i := range codes {
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		if codes[i], err = r.read(size); err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+func (m *module) decodeData(r *reader) error {
+	count, err := r.count()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		flags, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flags != 0 {
+			return errors.New("only active data segments are supported")
+		}
+		offset, err := m.constant(r, i32)
+		if err != nil {
+			return err
+		}
+		length, err := r.u32()
+		if err != nil {
+			return err
+		}
+		data, err := r.read(length)
+		if err != nil {
+			return err
+		}
+		m.data = append(m.data, dataSegment{offset: uint32(offset), data: data})
+	}
+	return nil
+}
+
+// validateIndexes checks the indexes the module's sections refer to each
+// other by.
+func (m *module) validateIndexes() error {
+	functions := uint32(m.functionCount())
+	for name, e := range m.exports {
+		var ok bool
+		switch e.kind {
+		case externalFunction:
+			ok = e.index < functions
+		case externalTable:
+			ok = m.table != nil && e.index == 0
+		case externalMemory:
+			ok = m.memory != nil && e.index == 0
+		case externalGlobal:
+			ok = int(e.index) < len(m.globals)
+		}
+		if !ok {
+			return fmt.Errorf("export %s refers to an unknown index %d", name, e.index)
+		}
+	}
+	if m.start != nil {
+		if *m.start >= functions {
+			return fmt.Errorf("unknown start function %d", *m.start)
+		}
+		start := m.functionType(*m.start)
+		if len(start.params) != 0 || len(start.results) != 0 {
+			return errors.New("start function must take and return nothing")
+		}
+	}
+	if len(m.elements) > 0 && m.table == nil {
+		return errors.New("element segments require a table")
+	}
+	for _, segment := range m.elements {
+		for _, index := range segment.functions {
+			if index >= functions {
+				return fmt.Errorf("element segment refers to unknown function %d", index)
+			}
+		}
+	}
+	if len(m.data) > 0 && m.memory == nil {
+		return errors.New("data segments require a memory")
+	}
+	return nil
+}
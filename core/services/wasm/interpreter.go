@@ -0,0 +1,389 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pageSize = 65536
+	// maxCallDepth is the deepest functions can call each other.
+	maxCallDepth = 1000
+	// maxStackSize is the most values the stack of a run can hold.
+	maxStackSize = 1 << 20
+	// deadlineInterval is how many instructions run between checks of the
+	// deadline.
+	deadlineInterval = 1 << 12
+)
+
+var (
+	errUnreachable        = errors.New("unreachable executed")
+	errOutOfBounds        = errors.New("out of bounds memory access")
+	errDivideByZero       = errors.New("integer divide by zero")
+	errIntegerOverflow    = errors.New("integer overflow")
+	errInvalidConversion  = errors.New("invalid conversion to integer")
+	errUndefinedElement   = errors.New("undefined element")
+	errIndirectCallType   = errors.New("indirect call type mismatch")
+	errCallStackExhausted = errors.New("call stack exhausted")
+	errTimedOut           = errors.New("wasm module timed out")
+)
+
+// trap aborts a run with the error it failed on. It is raised as a panic
+// and recovered where the run was started.
+type trap struct {
+	err error
+}
+
+// hostFunction is a function the node offers modules to import.
+type hostFunction struct {
+	functionType
+	call func(in *instance, args []uint64) uint64
+}
+
+// instance is a module instantiated for a single run, which meters every
+// instruction against its fuel.
+type instance struct {
+	module   *module
+	memory   []byte
+	maxPages uint32
+	table    []int64
+	globals  []uint64
+	stack    []uint64
+	// top is the end of the stack used by the innermost function.
+	top      int
+	depth    int
+	fuel     uint64
+	deadline time.Time
+	host     Host
+}
+
+// instantiate allocates the memory, table and globals of the module, and
+// runs its start function.
+func instantiate(m *module, memoryLimitPages uint32, fuel uint64, deadline time.Time, host Host) (*instance, error) {
+	in := &instance{
+		module:   m,
+		fuel:     fuel,
+		deadline: deadline,
+		host:     host,
+	}
+	if m.memory != nil {
+		in.memory = make([]byte, int(m.memory.min)*pageSize)
+		in.maxPages = memoryLimitPages
+		if m.memory.hasMax && m.memory.max < in.maxPages {
+			in.maxPages = m.memory.max
+		}
+	}
+	if m.table != nil {
+		in.table = make([]int64, m.table.min)
+		for i := range in.table {
+			in.table[i] = -1
+		}
+	}
+	in.globals = make([]uint64, len(m.globals))
+	for i, g := range m.globals {
+		in.globals[i] = g.init
+	}
+	for _, segment := range m.elements {
+		if uint64(segment.offset)+uint64(len(segment.functions)) > uint64(len(in.table)) {
+			return nil, errors.New("element segment does not fit the table")
+		}
+		for i, index := range segment.functions {
+			in.table[int(segment.offset)+i] = int64(index)
+		}
+	}
+	for _, segment := range m.data {
+		if uint64(segment.offset)+uint64(len(segment.data)) > uint64(len(in.memory)) {
+			return nil, errors.New("data segment does not fit the memory")
+		}
+		copy(in.memory[segment.offset:], segment.data)
+	}
+	if m.start != nil {
+		if err := in.guard(func() { in.invoke(*m.start) }); err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// guard runs f, returning the error of any trap it raises.
+func (in *instance) guard(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			t, ok := r.(trap)
+			if !ok {
+				panic(r)
+			}
+			err = t.err
+		}
+	}()
+	f()
+	return nil
+}
+
+// invoke calls a function on top of the stack, which may be in use by the
+// function calling out to the host.
+func (in *instance) invoke(index uint32, args ...uint64) []uint64 {
+	base := in.top
+	in.reserve(base + len(args))
+	copy(in.stack[base:], args)
+	in.call(index, base)
+	results := len(in.module.functionType(index).results)
+	return append([]uint64(nil), in.stack[base:base+results]...)
+}
+
+// reserve grows the stack to the given size.
+func (in *instance) reserve(size int) {
+	if size <= len(in.stack) {
+		return
+	}
+	if size > maxStackSize {
+		panic(trap{errCallStackExhausted})
+	}
+	capacity := 2 * len(in.stack)
+	if capacity < size {
+		capacity = size
+	}
+	if capacity > maxStackSize {
+		capacity = maxStackSize
+	}
+	stack := make([]uint64, capacity)
+	copy(stack, in.stack)
+	in.stack = stack
+}
+
+// call calls a function with its args on the stack from base, leaving its
+// results there.
+func (in *instance) call(index uint32, base int) {
+	m := in.module
+	if int(index) < len(m.imports) {
+		host := m.imports[index]
+		result := host.call(in, in.stack[base:base+len(host.params)])
+		if len(host.results) > 0 {
+			in.stack[base] = result
+		}
+		return
+	}
+
+	in.depth++
+	if in.depth > maxCallDepth {
+		panic(trap{errCallStackExhausted})
+	}
+	f := &m.functions[int(index)-len(m.imports)]
+	ft := m.types[f.typeIndex]
+	operands := base + len(ft.params) + len(f.locals)
+	top := operands + f.maxHeight
+	in.reserve(top)
+	locals := in.stack[base+len(ft.params) : operands]
+	for i := range locals {
+		locals[i] = 0
+	}
+	callerTop := in.top
+	in.top = top
+	in.execute(f, len(ft.results), base, operands)
+	in.top = callerTop
+	in.depth--
+}
+
+// address returns the address of an access of size bytes to the memory,
+// trapping when it is out of bounds.
+func (in *instance) address(base, offset uint64, size uint64) uint64 {
+	address := uint64(uint32(base)) + offset
+	if address+size > uint64(len(in.memory)) {
+		panic(trap{errOutOfBounds})
+	}
+	return address
+}
+
+// branch moves the values carried by a branch to where its target expects
+// them, returning the new top of the operand stack.
+func branch(stack []uint64, operands, sp int, target branchTarget) int {
+	height := operands + target.height
+	if height+target.arity != sp {
+		copy(stack[height:], stack[sp-target.arity:sp])
+	}
+	return height + target.arity
+}
+
+// execute runs the body of a function, with its locals from base and its
+// operands from operands.
+func (in *instance) execute(f *function, results, base, operands int) {
+	code := f.code
+	stack := in.stack
+	sp := operands
+	pc := 0
+	for {
+		if in.fuel == 0 {
+			panic(trap{ErrFuelExhausted})
+		}
+		in.fuel--
+		if in.fuel%deadlineInterval == 0 && time.Now().After(in.deadline) {
+			panic(trap{errTimedOut})
+		}
+
+		ins := &code[pc]
+		pc++
+		switch ins.op {
+		case opUnreachable:
+			panic(trap{errUnreachable})
+		case opIf:
+			sp--
+			if uint32(stack[sp]) == 0 {
+				pc = int(ins.a)
+			}
+		case opElse:
+			pc = int(ins.a)
+		case opBr:
+			sp = branch(stack, operands, sp, decodeBranchTarget(ins.a, ins.b))
+			pc = int(ins.a)
+		case opBrIf:
+			sp--
+			if uint32(stack[sp]) != 0 {
+				sp = branch(stack, operands, sp, decodeBranchTarget(ins.a, ins.b))
+				pc = int(ins.a)
+			}
+		case opBrTable:
+			sp--
+			table := f.brTables[ins.a]
+			i := uint64(uint32(stack[sp]))
+			if i >= uint64(len(table)) {
+				i = uint64(len(table) - 1)
+			}
+			target := table[i]
+			sp = branch(stack, operands, sp, target)
+			pc = target.pc
+		case opReturn:
+			copy(stack[base:], stack[sp-results:sp])
+			return
+		case opCall:
+			index := uint32(ins.a)
+			ft := in.module.functionType(index)
+			sp -= len(ft.params)
+			in.call(index, sp)
+			stack = in.stack
+			sp += len(ft.results)
+		case opCallIndirect:
+			sp--
+			i := uint64(uint32(stack[sp]))
+			if i >= uint64(len(in.table)) || in.table[i] < 0 {
+				panic(trap{errUndefinedElement})
+			}
+			index := uint32(in.table[i])
+			ft := in.module.functionType(index)
+			if !ft.equal(in.module.types[ins.a]) {
+				panic(trap{errIndirectCallType})
+			}
+			sp -= len(ft.params)
+			in.call(index, sp)
+			stack = in.stack
+			sp += len(ft.results)
+		case opDrop:
+			sp--
+		case opSelect:
+			sp -= 2
+			if uint32(stack[sp+1]) == 0 {
+				stack[sp-1] = stack[sp]
+			}
+		case opLocalGet:
+			stack[sp] = stack[base+int(ins.a)]
+			sp++
+		case opLocalSet:
+			sp--
+			stack[base+int(ins.a)] = stack[sp]
+		case opLocalTee:
+			stack[base+int(ins.a)] = stack[sp-1]
+		case opGlobalGet:
+			stack[sp] = in.globals[ins.a]
+			sp++
+		case opGlobalSet:
+			sp--
+			in.globals[ins.a] = stack[sp]
+		case opMemorySize:
+			stack[sp] = uint64(len(in.memory) / pageSize)
+			sp++
+		case opMemoryGrow:
+			pages := uint64(len(in.memory) / pageSize)
+			delta := uint64(uint32(stack[sp-1]))
+			if pages+delta > uint64(in.maxPages) {
+				stack[sp-1] = uint64(uint32(0xffffffff))
+			} else {
+				in.memory = append(in.memory, make([]byte, delta*pageSize)...)
+				stack[sp-1] = pages
+			}
+		case opI32Const, opI64Const, opF32Const, opF64Const:
+			stack[sp] = ins.a
+			sp++
+		case opMemoryCopy:
+			sp -= 3
+			dst, src, n := uint64(uint32(stack[sp])), uint64(uint32(stack[sp+1])), uint64(uint32(stack[sp+2]))
+			in.address(src, 0, n)
+			in.address(dst, 0, n)
+			copy(in.memory[dst:dst+n], in.memory[src:src+n])
+		case opMemoryFill:
+			sp -= 3
+			dst, value, n := uint64(uint32(stack[sp])), byte(stack[sp+1]), uint64(uint32(stack[sp+2]))
+			in.address(dst, 0, n)
+			fill := in.memory[dst : dst+n]
+			for i := range fill {
+				fill[i] = value
+			}
+		default:
+			if ins.op >= 0x28 && ins.op <= 0x35 {
+				stack[sp-1] = in.load(ins.op, stack[sp-1], ins.a, ins.b)
+			} else if ins.op >= 0x36 && ins.op <= 0x3e {
+				sp -= 2
+				in.store(stack[sp], ins.a, ins.b, stack[sp+1])
+			} else if ins.b == 2 {
+				sp--
+				stack[sp-1] = binaryNumeric(ins.op, stack[sp-1], stack[sp])
+			} else {
+				stack[sp-1] = unaryNumeric(ins.op, stack[sp-1])
+			}
+		}
+	}
+}
+
+func (in *instance) load(op uint16, base, offset, size uint64) uint64 {
+	a := in.address(base, offset, size)
+	m := in.memory[a : a+size]
+	switch op {
+	case 0x28, 0x2a:
+		return uint64(binary.LittleEndian.Uint32(m))
+	case 0x29, 0x2b:
+		return binary.LittleEndian.Uint64(m)
+	case 0x2c:
+		return uint64(uint32(int32(int8(m[0]))))
+	case 0x2d, 0x31:
+		return uint64(m[0])
+	case 0x2e:
+		return uint64(uint32(int32(int16(binary.LittleEndian.Uint16(m)))))
+	case 0x2f, 0x33:
+		return uint64(binary.LittleEndian.Uint16(m))
+	case 0x30:
+		return uint64(int64(int8(m[0])))
+	case 0x32:
+		return uint64(int64(int16(binary.LittleEndian.Uint16(m))))
+	case 0x34:
+		return uint64(int64(int32(binary.LittleEndian.Uint32(m))))
+	case 0x35:
+		return uint64(binary.LittleEndian.Uint32(m))
+	}
+	panic(trap{fmt.Errorf("unknown load 0x%x", op)})
+}
+
+func (in *instance) store(base, offset, size, value uint64) {
+	a := in.address(base, offset, size)
+	m := in.memory[a : a+size]
+	switch size {
+	case 1:
+		m[0] = byte(value)
+	case 2:
+		binary.LittleEndian.PutUint16(m, uint16(value))
+	case 4:
+		binary.LittleEndian.PutUint32(m, uint32(value))
+	default:
+		binary.LittleEndian.PutUint64(m, value)
+	}
+}
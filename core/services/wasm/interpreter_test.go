@@ -0,0 +1,367 @@
+package wasm
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeU32(n uint32) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func encodeVec(items ...[]byte) []byte {
+	out := encodeU32(uint32(len(items)))
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func encodeSection(id byte, items ...[]byte) []byte {
+	payload := encodeVec(items...)
+	return append(append([]byte{id}, encodeU32(uint32(len(payload)))...), payload...)
+}
+
+func encodeTypes(types []valueType) []byte {
+	out := encodeU32(uint32(len(types)))
+	for _, t := range types {
+		out = append(out, byte(t))
+	}
+	return out
+}
+
+// testFunction assembles a module of a single function f with the given
+// type, locals and body, a table holding f, and a memory of one page which
+// can grow to two.
+func testFunction(params, results, locals []valueType, body ...byte) []byte {
+	var localGroups [][]byte
+	for _, t := range locals {
+		localGroups = append(localGroups, []byte{1, byte(t)})
+	}
+	code := append(encodeVec(localGroups...), body...)
+	code = append(code, 0x0b)
+
+	var binary []byte
+	for _, part := range [][]byte{
+		[]byte("\x00asm\x01\x00\x00\x00"),
+		encodeSection(1, append(append([]byte{0x60}, encodeTypes(params)...), encodeTypes(results)...)),
+		encodeSection(3, []byte{0}),
+		encodeSection(4, []byte{0x70, 0x00, 2}),
+		encodeSection(5, []byte{0x01, 1, 2}),
+		encodeSection(7, append([]byte{1, 'f'}, 0x00, 0)),
+		encodeSection(9, []byte{0x00, 0x41, 0x00, 0x0b, 1, 0}),
+		encodeSection(10, append(encodeU32(uint32(len(code))), code...)),
+	} {
+		binary = append(binary, part...)
+	}
+	return binary
+}
+
+func call(t *testing.T, binary []byte, args ...uint64) ([]uint64, error) {
+	m, err := decode(binary, hostFunctions)
+	require.NoError(t, err)
+	in, err := instantiate(m, 16, 1e6, time.Now().Add(time.Minute), nil)
+	require.NoError(t, err)
+	var results []uint64
+	err = in.guard(func() {
+		results = in.invoke(m.exports["f"].index, args...)
+	})
+	return results, err
+}
+
+func f32Bits(f float32) uint64 {
+	return uint64(math.Float32bits(f))
+}
+
+func f64Bits(f float64) uint64 {
+	return math.Float64bits(f)
+}
+
+func TestInterpreter_Numeric(t *testing.T) {
+	t.Parallel()
+
+	i32s, i64s, f32s, f64s := []valueType{i32}, []valueType{i64}, []valueType{f32}, []valueType{f64}
+	pair := func(t valueType) []valueType { return []valueType{t, t} }
+	// local.get 0; local.get 1; op
+	binaryOp := func(op ...byte) []byte { return append([]byte{0x20, 0, 0x20, 1}, op...) }
+	unaryOp := func(op ...byte) []byte { return append([]byte{0x20, 0}, op...) }
+	minInt32 := uint64(1 << 31)
+
+	tests := []struct {
+		name      string
+		params    []valueType
+		result    []valueType
+		body      []byte
+		args      []uint64
+		want      uint64
+		wantError error
+	}{
+		{"i32.add wraps", pair(i32), i32s, binaryOp(0x6a), []uint64{math.MaxUint32, 2}, 1, nil},
+		{"i32.sub", pair(i32), i32s, binaryOp(0x6b), []uint64{1, 2}, math.MaxUint32, nil},
+		{"i32.div_s", pair(i32), i32s, binaryOp(0x6d), []uint64{uint64(uint32(-7 & math.MaxUint32)), 2}, uint64(uint32(-3 & math.MaxUint32)), nil},
+		{"i32.div_s by zero", pair(i32), i32s, binaryOp(0x6d), []uint64{1, 0}, 0, errDivideByZero},
+		{"i32.div_s overflow", pair(i32), i32s, binaryOp(0x6d), []uint64{minInt32, math.MaxUint32}, 0, errIntegerOverflow},
+		{"i32.rem_s of the minimum by -1", pair(i32), i32s, binaryOp(0x6f), []uint64{minInt32, math.MaxUint32}, 0, nil},
+		{"i32.shl masks the count", pair(i32), i32s, binaryOp(0x74), []uint64{1, 33}, 2, nil},
+		{"i32.shr_s", pair(i32), i32s, binaryOp(0x75), []uint64{minInt32, 31}, math.MaxUint32, nil},
+		{"i32.rotr", pair(i32), i32s, binaryOp(0x78), []uint64{1, 1}, minInt32, nil},
+		{"i32.lt_s", pair(i32), i32s, binaryOp(0x48), []uint64{math.MaxUint32, 0}, 1, nil},
+		{"i32.lt_u", pair(i32), i32s, binaryOp(0x49), []uint64{math.MaxUint32, 0}, 0, nil},
+		{"i32.clz", i32s, i32s, unaryOp(0x67), []uint64{1}, 31, nil},
+		{"i32.eqz", i32s, i32s, unaryOp(0x45), []uint64{0}, 1, nil},
+		{"i32.extend8_s", i32s, i32s, unaryOp(0xc0), []uint64{0x80}, 0xffffff80, nil},
+		{"i64.mul", pair(i64), i64s, binaryOp(0x7e), []uint64{1 << 32, 1 << 31}, 1 << 63, nil},
+		{"i64.rotl", pair(i64), i64s, binaryOp(0x89), []uint64{1 << 63, 1}, 1, nil},
+		{"i64.popcnt", i64s, i64s, unaryOp(0x7b), []uint64{math.MaxUint64}, 64, nil},
+		{"i64.extend_i32_s", i32s, i64s, unaryOp(0xac), []uint64{math.MaxUint32}, math.MaxUint64, nil},
+		{"i32.wrap_i64", i64s, i32s, unaryOp(0xa7), []uint64{1<<32 | 5}, 5, nil},
+		{"f32.add", pair(f32), f32s, binaryOp(0x92), []uint64{f32Bits(1.5), f32Bits(2.25)}, f32Bits(3.75), nil},
+		{"f32.nearest rounds to even", f32s, f32s, unaryOp(0x90), []uint64{f32Bits(2.5)}, f32Bits(2), nil},
+		{"f32.neg", f32s, f32s, unaryOp(0x8c), []uint64{f32Bits(1)}, f32Bits(-1), nil},
+		{"f64.min of zeros", pair(f64), f64s, binaryOp(0xa4), []uint64{f64Bits(0), f64Bits(math.Copysign(0, -1))}, f64Bits(math.Copysign(0, -1)), nil},
+		{"f64.max with NaN", pair(f64), f64s, binaryOp(0xa5), []uint64{f64Bits(1), f64Bits(math.NaN())}, f64Bits(math.NaN()), nil},
+		{"f64.div by zero", pair(f64), f64s, binaryOp(0xa3), []uint64{f64Bits(1), f64Bits(0)}, f64Bits(math.Inf(1)), nil},
+		{"f64.copysign", pair(f64), f64s, binaryOp(0xa6), []uint64{f64Bits(2), f64Bits(-1)}, f64Bits(-2), nil},
+		{"f64.lt", pair(f64), i32s, binaryOp(0x63), []uint64{f64Bits(1), f64Bits(2)}, 1, nil},
+		{"f64.promote_f32", f32s, f64s, unaryOp(0xbb), []uint64{f32Bits(0.5)}, f64Bits(0.5), nil},
+		{"f64.convert_i64_u", i64s, f64s, unaryOp(0xba), []uint64{1 << 63}, f64Bits(1 << 63), nil},
+		{"i32.trunc_f64_s", f64s, i32s, unaryOp(0xaa), []uint64{f64Bits(-3.9)}, uint64(uint32(-3 & math.MaxUint32)), nil},
+		{"i32.trunc_f64_s of NaN", f64s, i32s, unaryOp(0xaa), []uint64{f64Bits(math.NaN())}, 0, errInvalidConversion},
+		{"i32.trunc_f64_u out of range", f64s, i32s, unaryOp(0xab), []uint64{f64Bits(1 << 32)}, 0, errIntegerOverflow},
+		{"i32.trunc_f64_u of a small negative", f64s, i32s, unaryOp(0xab), []uint64{f64Bits(-0.5)}, 0, nil},
+		{"i32.trunc_sat_f64_s", f64s, i32s, unaryOp(0xfc, 2), []uint64{f64Bits(1e10)}, math.MaxInt32, nil},
+		{"i64.trunc_sat_f64_u of NaN", f64s, i64s, unaryOp(0xfc, 7), []uint64{f64Bits(math.NaN())}, 0, nil},
+		{"i64.reinterpret_f64", f64s, i64s, unaryOp(0xbd), []uint64{f64Bits(1)}, f64Bits(1), nil},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			results, err := call(t, testFunction(test.params, test.result, nil, test.body...), test.args...)
+			if test.wantError != nil {
+				assert.Equal(t, test.wantError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{test.want}, results)
+		})
+	}
+}
+
+func TestInterpreter_Control(t *testing.T) {
+	t.Parallel()
+
+	i32s := []valueType{i32}
+
+	tests := []struct {
+		name   string
+		locals []valueType
+		body   []byte
+		arg    uint64
+		want   uint64
+	}{
+		{
+			// if (result i32) local.get 0 else i32.const 7 end
+			"if", nil,
+			[]byte{0x20, 0, 0x04, 0x7f, 0x20, 0, 0x05, 0x41, 7, 0x0b},
+			3, 3,
+		},
+		{
+			"else", nil,
+			[]byte{0x20, 0, 0x04, 0x7f, 0x20, 0, 0x05, 0x41, 7, 0x0b},
+			0, 7,
+		},
+		{
+			// Sums 1 to n:
+			// loop
+			//   local.get 1; local.get 0; i32.add; local.set 1
+			//   local.get 0; i32.const 1; i32.sub; local.tee 0
+			//   br_if 0
+			// end
+			// local.get 1
+			"loop", i32s,
+			[]byte{
+				0x03, 0x40,
+				0x20, 1, 0x20, 0, 0x6a, 0x21, 1,
+				0x20, 0, 0x41, 1, 0x6b, 0x22, 0,
+				0x0d, 0,
+				0x0b,
+				0x20, 1,
+			},
+			100, 5050,
+		},
+		{
+			// block (result i32)
+			//   i32.const 1; i32.const 2; local.get 0; br_if 0; drop; drop; i32.const 3
+			// end
+			// Taking the branch carries 2, dropping the 1 beneath it
+			"br_if carries values", nil,
+			[]byte{
+				0x02, 0x7f,
+				0x41, 1, 0x41, 2, 0x20, 0, 0x0d, 0, 0x1a, 0x1a, 0x41, 3,
+				0x0b,
+			},
+			1, 2,
+		},
+		{
+			"br_if falls through", nil,
+			[]byte{
+				0x02, 0x7f,
+				0x41, 1, 0x41, 2, 0x20, 0, 0x0d, 0, 0x1a, 0x1a, 0x41, 3,
+				0x0b,
+			},
+			0, 3,
+		},
+		{
+			// block block block local.get 0 br_table 0 1 2 end i32.const 10
+			// return end i32.const 11 return end i32.const 12
+			"br_table", nil,
+			[]byte{
+				0x02, 0x40, 0x02, 0x40, 0x02, 0x40,
+				0x20, 0, 0x0e, 2, 0, 1, 2,
+				0x0b, 0x41, 10, 0x0f,
+				0x0b, 0x41, 11, 0x0f,
+				0x0b, 0x41, 12,
+			},
+			1, 11,
+		},
+		{
+			"br_table default", nil,
+			[]byte{
+				0x02, 0x40, 0x02, 0x40, 0x02, 0x40,
+				0x20, 0, 0x0e, 2, 0, 1, 2,
+				0x0b, 0x41, 10, 0x0f,
+				0x0b, 0x41, 11, 0x0f,
+				0x0b, 0x41, 12,
+			},
+			9, 12,
+		},
+		{
+			// Counts down through the table:
+			// local.get 0 i32.eqz if (result i32) i32.const 42 else
+			//   local.get 0 i32.const 1 i32.sub i32.const 0 call_indirect 0
+			// end
+			"call_indirect", nil,
+			[]byte{
+				0x20, 0, 0x45, 0x04, 0x7f, 0x41, 42, 0x05,
+				0x20, 0, 0x41, 1, 0x6b, 0x41, 0, 0x11, 0, 0,
+				0x0b,
+			},
+			5, 42,
+		},
+		{
+			// i32.const 8 local.get 0 i32.store offset=4
+			// i32.const 12 i32.load
+			"memory", nil,
+			[]byte{0x41, 8, 0x20, 0, 0x36, 2, 4, 0x41, 12, 0x28, 2, 0},
+			0xdeadbeef, 0xdeadbeef,
+		},
+		{
+			// memory.grow beyond the maximum fails, otherwise returns the
+			// previous size: local.get 0 memory.grow 0
+			"memory.grow", nil,
+			[]byte{0x20, 0, 0x40, 0},
+			1, 1,
+		},
+		{
+			"memory.grow beyond the maximum", nil,
+			[]byte{0x20, 0, 0x40, 0},
+			2, math.MaxUint32,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			results, err := call(t, testFunction(i32s, i32s, test.locals, test.body...), test.arg)
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{test.want}, results)
+		})
+	}
+}
+
+func TestInterpreter_Traps(t *testing.T) {
+	t.Parallel()
+
+	i32s := []valueType{i32}
+
+	tests := []struct {
+		name      string
+		body      []byte
+		arg       uint64
+		wantError error
+	}{
+		{"unreachable", []byte{0x00}, 0, errUnreachable},
+		// local.get 0 i32.load
+		{"load out of bounds", []byte{0x20, 0, 0x28, 2, 0}, pageSize - 3, errOutOfBounds},
+		// local.get 0 local.get 0 call_indirect 0
+		{"undefined element", []byte{0x20, 0, 0x20, 0, 0x11, 0, 0}, 1, errUndefinedElement},
+		// local.get 0 call 0
+		{"call stack exhausted", []byte{0x20, 0, 0x10, 0}, 0, errCallStackExhausted},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			_, err := call(t, testFunction(i32s, i32s, nil, test.body...), test.arg)
+			assert.Equal(t, test.wantError, err)
+		})
+	}
+}
+
+func TestInterpreter_Fuel(t *testing.T) {
+	t.Parallel()
+
+	// loop br 0 end, which compiles to a single branch to itself
+	binary := testFunction(nil, nil, nil, 0x03, 0x40, 0x0c, 0, 0x0b)
+	m, err := decode(binary, hostFunctions)
+	require.NoError(t, err)
+	in, err := instantiate(m, 16, 1000, time.Now().Add(time.Minute), nil)
+	require.NoError(t, err)
+
+	err = in.guard(func() { in.invoke(0) })
+	assert.Equal(t, ErrFuelExhausted, err)
+	assert.Equal(t, uint64(0), in.fuel)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	i32s := []valueType{i32}
+
+	tests := []struct {
+		name      string
+		binary    []byte
+		wantError string
+	}{
+		{"not wasm", []byte("\x00wasm"), "not a wasm module"},
+		{"type mismatch", testFunction(nil, i32s, nil, 0x42, 0), "type mismatch"},
+		{"stack underflow", testFunction(nil, i32s, nil, 0x6a), "type mismatch"},
+		{"unknown local", testFunction(nil, nil, nil, 0x20, 1, 0x1a), "unknown local 1"},
+		{"unknown label", testFunction(nil, nil, nil, 0x0c, 1), "unknown label 1"},
+		{"unsupported instruction", testFunction(nil, nil, nil, 0xfd, 0), "unsupported instruction"},
+		{"missing end", testFunction(nil, nil, nil, 0x02, 0x40), "unexpected end"},
+		{"alignment", testFunction(nil, i32s, nil, 0x41, 0, 0x28, 3, 0), "alignment"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decode(test.binary, hostFunctions)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantError)
+		})
+	}
+}
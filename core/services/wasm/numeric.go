@@ -0,0 +1,445 @@
+package wasm
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	f32SignBit = 1 << 31
+	f64SignBit = 1 << 63
+)
+
+func boolValue(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func f32Value(f float32) uint64 {
+	return uint64(math.Float32bits(f))
+}
+
+func asF32(v uint64) float32 {
+	return math.Float32frombits(uint32(v))
+}
+
+func f64Value(f float64) uint64 {
+	return math.Float64bits(f)
+}
+
+func asF64(v uint64) float64 {
+	return math.Float64frombits(v)
+}
+
+// unaryNumeric applies a numeric instruction taking one value.
+func unaryNumeric(op uint16, a uint64) uint64 {
+	switch op {
+	case 0x45:
+		return boolValue(uint32(a) == 0)
+	case 0x50:
+		return boolValue(a == 0)
+
+	case 0x67:
+		return uint64(bits.LeadingZeros32(uint32(a)))
+	case 0x68:
+		return uint64(bits.TrailingZeros32(uint32(a)))
+	case 0x69:
+		return uint64(bits.OnesCount32(uint32(a)))
+	case 0x79:
+		return uint64(bits.LeadingZeros64(a))
+	case 0x7a:
+		return uint64(bits.TrailingZeros64(a))
+	case 0x7b:
+		return uint64(bits.OnesCount64(a))
+
+	case 0x8b:
+		return a &^ f32SignBit
+	case 0x8c:
+		return a ^ f32SignBit
+	case 0x8d:
+		return f32Value(float32(math.Ceil(float64(asF32(a)))))
+	case 0x8e:
+		return f32Value(float32(math.Floor(float64(asF32(a)))))
+	case 0x8f:
+		return f32Value(float32(math.Trunc(float64(asF32(a)))))
+	case 0x90:
+		return f32Value(float32(math.RoundToEven(float64(asF32(a)))))
+	case 0x91:
+		return f32Value(float32(math.Sqrt(float64(asF32(a)))))
+	case 0x99:
+		return a &^ f64SignBit
+	case 0x9a:
+		return a ^ f64SignBit
+	case 0x9b:
+		return f64Value(math.Ceil(asF64(a)))
+	case 0x9c:
+		return f64Value(math.Floor(asF64(a)))
+	case 0x9d:
+		return f64Value(math.Trunc(asF64(a)))
+	case 0x9e:
+		return f64Value(math.RoundToEven(asF64(a)))
+	case 0x9f:
+		return f64Value(math.Sqrt(asF64(a)))
+
+	case 0xa7:
+		return uint64(uint32(a))
+	case 0xa8:
+		return uint64(uint32(int32(truncate(float64(asF32(a)), -1<<31, 1<<31))))
+	case 0xa9:
+		return uint64(uint32(truncate(float64(asF32(a)), 0, 1<<32)))
+	case 0xaa:
+		return uint64(uint32(int32(truncate(asF64(a), -1<<31, 1<<31))))
+	case 0xab:
+		return uint64(uint32(truncate(asF64(a), 0, 1<<32)))
+	case 0xac:
+		return uint64(int64(int32(a)))
+	case 0xad:
+		return uint64(uint32(a))
+	case 0xae:
+		return uint64(int64(truncate(float64(asF32(a)), -1<<63, 1<<63)))
+	case 0xaf:
+		return uint64(truncate(float64(asF32(a)), 0, 1<<64))
+	case 0xb0:
+		return uint64(int64(truncate(asF64(a), -1<<63, 1<<63)))
+	case 0xb1:
+		return uint64(truncate(asF64(a), 0, 1<<64))
+	case 0xb2:
+		return f32Value(float32(int32(a)))
+	case 0xb3:
+		return f32Value(float32(uint32(a)))
+	case 0xb4:
+		return f32Value(float32(int64(a)))
+	case 0xb5:
+		return f32Value(float32(a))
+	case 0xb6:
+		return f32Value(float32(asF64(a)))
+	case 0xb7:
+		return f64Value(float64(int32(a)))
+	case 0xb8:
+		return f64Value(float64(uint32(a)))
+	case 0xb9:
+		return f64Value(float64(int64(a)))
+	case 0xba:
+		return f64Value(float64(a))
+	case 0xbb:
+		return f64Value(float64(asF32(a)))
+	case 0xbc, 0xbd, 0xbe, 0xbf:
+		// Values are held as their bits, so reinterpreting does nothing
+		return a
+	case 0xc0:
+		return uint64(uint32(int32(int8(a))))
+	case 0xc1:
+		return uint64(uint32(int32(int16(a))))
+	case 0xc2:
+		return uint64(int64(int8(a)))
+	case 0xc3:
+		return uint64(int64(int16(a)))
+	case 0xc4:
+		return uint64(int64(int32(a)))
+
+	case opPrefix<<8 | 0:
+		return uint64(uint32(int32(saturate(float64(asF32(a)), -1<<31, 1<<31-1))))
+	case opPrefix<<8 | 1:
+		return uint64(uint32(saturate(float64(asF32(a)), 0, 1<<32-1)))
+	case opPrefix<<8 | 2:
+		return uint64(uint32(int32(saturate(asF64(a), -1<<31, 1<<31-1))))
+	case opPrefix<<8 | 3:
+		return uint64(uint32(saturate(asF64(a), 0, 1<<32-1)))
+	case opPrefix<<8 | 4:
+		return saturateI64(float64(asF32(a)))
+	case opPrefix<<8 | 5:
+		return saturateU64(float64(asF32(a)))
+	case opPrefix<<8 | 6:
+		return saturateI64(asF64(a))
+	case opPrefix<<8 | 7:
+		return saturateU64(asF64(a))
+	}
+	panic(trap{errUnreachable})
+}
+
+// truncate truncates a float to an integer in [min, max), trapping when it
+// is not a number or out of range.
+func truncate(f, min, max float64) float64 {
+	if math.IsNaN(f) {
+		panic(trap{errInvalidConversion})
+	}
+	t := math.Trunc(f)
+	if t < min || t >= max {
+		panic(trap{errIntegerOverflow})
+	}
+	return t
+}
+
+// saturate truncates a float to an integer in [min, max], clamping it when
+// out of range.
+func saturate(f, min, max float64) float64 {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f <= min:
+		return min
+	case f >= max:
+		return max
+	}
+	return math.Trunc(f)
+}
+
+func saturateI64(f float64) uint64 {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f < -1<<63:
+		return 1 << 63
+	case f >= 1<<63:
+		return math.MaxInt64
+	}
+	return uint64(int64(f))
+}
+
+func saturateU64(f float64) uint64 {
+	switch {
+	case math.IsNaN(f) || f <= 0:
+		return 0
+	case f >= 1<<64:
+		return math.MaxUint64
+	}
+	return uint64(f)
+}
+
+// binaryNumeric applies a numeric instruction taking two values.
+func binaryNumeric(op uint16, a, b uint64) uint64 {
+	switch {
+	case op >= 0x46 && op <= 0x4f:
+		return compareI32(op-0x46, uint32(a), uint32(b))
+	case op >= 0x51 && op <= 0x5a:
+		return compareI64(op-0x51, a, b)
+	case op >= 0x5b && op <= 0x60:
+		return compareFloat(op-0x5b, float64(asF32(a)), float64(asF32(b)))
+	case op >= 0x61 && op <= 0x66:
+		return compareFloat(op-0x61, asF64(a), asF64(b))
+	case op >= 0x6a && op <= 0x78:
+		return uint64(arithmeticI32(op-0x6a, uint32(a), uint32(b)))
+	case op >= 0x7c && op <= 0x8a:
+		return arithmeticI64(op-0x7c, a, b)
+	case op >= 0x92 && op <= 0x98:
+		return arithmeticF32(op-0x92, a, b)
+	case op >= 0xa0 && op <= 0xa6:
+		return arithmeticF64(op-0xa0, a, b)
+	}
+	panic(trap{errUnreachable})
+}
+
+func compareI32(op uint16, a, b uint32) uint64 {
+	switch op {
+	case 0:
+		return boolValue(a == b)
+	case 1:
+		return boolValue(a != b)
+	case 2:
+		return boolValue(int32(a) < int32(b))
+	case 3:
+		return boolValue(a < b)
+	case 4:
+		return boolValue(int32(a) > int32(b))
+	case 5:
+		return boolValue(a > b)
+	case 6:
+		return boolValue(int32(a) <= int32(b))
+	case 7:
+		return boolValue(a <= b)
+	case 8:
+		return boolValue(int32(a) >= int32(b))
+	default:
+		return boolValue(a >= b)
+	}
+}
+
+func compareI64(op uint16, a, b uint64) uint64 {
+	switch op {
+	case 0:
+		return boolValue(a == b)
+	case 1:
+		return boolValue(a != b)
+	case 2:
+		return boolValue(int64(a) < int64(b))
+	case 3:
+		return boolValue(a < b)
+	case 4:
+		return boolValue(int64(a) > int64(b))
+	case 5:
+		return boolValue(a > b)
+	case 6:
+		return boolValue(int64(a) <= int64(b))
+	case 7:
+		return boolValue(a <= b)
+	case 8:
+		return boolValue(int64(a) >= int64(b))
+	default:
+		return boolValue(a >= b)
+	}
+}
+
+func compareFloat(op uint16, a, b float64) uint64 {
+	switch op {
+	case 0:
+		return boolValue(a == b)
+	case 1:
+		return boolValue(a != b)
+	case 2:
+		return boolValue(a < b)
+	case 3:
+		return boolValue(a > b)
+	case 4:
+		return boolValue(a <= b)
+	default:
+		return boolValue(a >= b)
+	}
+}
+
+func arithmeticI32(op uint16, a, b uint32) uint32 {
+	switch op {
+	case 0:
+		return a + b
+	case 1:
+		return a - b
+	case 2:
+		return a * b
+	case 3:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			panic(trap{errIntegerOverflow})
+		}
+		return uint32(int32(a) / int32(b))
+	case 4:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a / b
+	case 5:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int32(b) == -1 {
+			return 0
+		}
+		return uint32(int32(a) % int32(b))
+	case 6:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a % b
+	case 7:
+		return a & b
+	case 8:
+		return a | b
+	case 9:
+		return a ^ b
+	case 10:
+		return a << (b & 31)
+	case 11:
+		return uint32(int32(a) >> (b & 31))
+	case 12:
+		return a >> (b & 31)
+	case 13:
+		return bits.RotateLeft32(a, int(b&31))
+	default:
+		return bits.RotateLeft32(a, -int(b&31))
+	}
+}
+
+func arithmeticI64(op uint16, a, b uint64) uint64 {
+	switch op {
+	case 0:
+		return a + b
+	case 1:
+		return a - b
+	case 2:
+		return a * b
+	case 3:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			panic(trap{errIntegerOverflow})
+		}
+		return uint64(int64(a) / int64(b))
+	case 4:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a / b
+	case 5:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int64(b) == -1 {
+			return 0
+		}
+		return uint64(int64(a) % int64(b))
+	case 6:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a % b
+	case 7:
+		return a & b
+	case 8:
+		return a | b
+	case 9:
+		return a ^ b
+	case 10:
+		return a << (b & 63)
+	case 11:
+		return uint64(int64(a) >> (b & 63))
+	case 12:
+		return a >> (b & 63)
+	case 13:
+		return bits.RotateLeft64(a, int(b&63))
+	default:
+		return bits.RotateLeft64(a, -int(b&63))
+	}
+}
+
+func arithmeticF32(op uint16, a, b uint64) uint64 {
+	x, y := asF32(a), asF32(b)
+	switch op {
+	case 0:
+		return f32Value(x + y)
+	case 1:
+		return f32Value(x - y)
+	case 2:
+		return f32Value(x * y)
+	case 3:
+		return f32Value(x / y)
+	case 4:
+		return f32Value(float32(math.Min(float64(x), float64(y))))
+	case 5:
+		return f32Value(float32(math.Max(float64(x), float64(y))))
+	default:
+		return a&^f32SignBit | b&f32SignBit
+	}
+}
+
+func arithmeticF64(op uint16, a, b uint64) uint64 {
+	x, y := asF64(a), asF64(b)
+	switch op {
+	case 0:
+		return f64Value(x + y)
+	case 1:
+		return f64Value(x - y)
+	case 2:
+		return f64Value(x * y)
+	case 3:
+		return f64Value(x / y)
+	case 4:
+		return f64Value(math.Min(x, y))
+	case 5:
+		return f64Value(math.Max(x, y))
+	default:
+		return a&^f64SignBit | b&f64SignBit
+	}
+}
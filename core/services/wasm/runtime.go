@@ -0,0 +1,198 @@
+package wasm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	"github.com/pkg/errors"
+)
+
+// Runtime compiles and runs modules within memory, time and fuel limits,
+// caching compiled modules by hash.
+//
+// Modules are run by an interpreter which spends a unit of fuel on every
+// instruction, so that a run ends deterministically once its fuel is spent,
+// however it loops or recurses. The timeout bounds runs which spend their
+// time in the host, such as waiting on HTTP requests.
+type Runtime struct {
+	compiled         map[string]*module
+	mutex            sync.Mutex
+	memoryLimitPages uint32
+	timeout          time.Duration
+	fuelLimit        uint64
+}
+
+// NewRuntime returns a runtime limiting modules to the given number of 64KiB
+// memory pages, run time, and instructions.
+func NewRuntime(memoryLimitPages uint32, timeout time.Duration, fuelLimit uint64) (*Runtime, error) {
+	if memoryLimitPages > maxPages {
+		return nil, fmt.Errorf("wasm memory limit must be at most %d pages", maxPages)
+	}
+	return &Runtime{
+		compiled:         make(map[string]*module),
+		memoryLimitPages: memoryLimitPages,
+		timeout:          timeout,
+		fuelLimit:        fuelLimit,
+	}, nil
+}
+
+// Close releases the compiled modules.
+func (r *Runtime) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.compiled = make(map[string]*module)
+	return nil
+}
+
+// Compile validates the given module, and caches it compiled, returning its
+// hash.
+func (r *Runtime) Compile(binary []byte) (string, error) {
+	hash := Hash(binary)
+	_, err := r.compile(hash, binary)
+	return hash, err
+}
+
+func (r *Runtime) compile(hash string, binary []byte) (*module, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if compiled, ok := r.compiled[hash]; ok {
+		return compiled, nil
+	}
+
+	compiled, err := decode(binary, hostFunctions)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid wasm module")
+	}
+	if compiled.memory != nil && compiled.memory.min > r.memoryLimitPages {
+		return nil, fmt.Errorf("invalid wasm module: memory of %d pages exceeds the limit of %d pages",
+			compiled.memory.min, r.memoryLimitPages)
+	}
+	if err := checkABI(compiled); err != nil {
+		return nil, err
+	}
+	r.compiled[hash] = compiled
+	return compiled, nil
+}
+
+func checkABI(m *module) error {
+	if e, ok := m.exports[memoryName]; !ok || e.kind != externalMemory {
+		return fmt.Errorf("wasm module must export its memory as %q", memoryName)
+	}
+	signatures := []struct {
+		name string
+		functionType
+	}{
+		{allocateFunction, functionType{[]valueType{i32}, []valueType{i32}}},
+		{performFunction, functionType{[]valueType{i32, i32}, []valueType{i64}}},
+	}
+	for _, signature := range signatures {
+		e, ok := m.exports[signature.name]
+		if !ok || e.kind != externalFunction || !m.functionType(e.index).equal(signature.functionType) {
+			return fmt.Errorf("wasm module must export %s(%s) %s",
+				signature.name, signature.params[0], signature.results[0])
+		}
+	}
+	return nil
+}
+
+// Run runs the given module on the given JSON input, and returns its JSON
+// output.
+func (r *Runtime) Run(binary []byte, input []byte, host Host) ([]byte, error) {
+	compiled, err := r.compile(Hash(binary), binary)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(r.timeout)
+	in, err := instantiate(compiled, r.memoryLimitPages, r.fuelLimit, deadline, host)
+	if err != nil {
+		return nil, runError(err)
+	}
+
+	var results []uint64
+	err = in.guard(func() {
+		address := in.writeBytes(input)
+		results = in.invoke(compiled.exports[performFunction].index, uint64(address), uint64(len(input)))
+	})
+	if err != nil {
+		return nil, runError(err)
+	}
+
+	address, length := uint64(uint32(results[0]>>32)), uint64(uint32(results[0]))
+	if address+length > uint64(len(in.memory)) {
+		return nil, errors.New("wasm module returned output out of its memory")
+	}
+	return append([]byte(nil), in.memory[address:address+length]...), nil
+}
+
+func runError(err error) error {
+	if err == ErrFuelExhausted || err == errTimedOut {
+		return err
+	}
+	return errors.Wrap(err, "wasm module failed")
+}
+
+// writeBytes copies the given bytes to memory allocated by the module.
+func (in *instance) writeBytes(bytes []byte) uint32 {
+	results := in.invoke(in.module.exports[allocateFunction].index, uint64(len(bytes)))
+	address := uint64(uint32(results[0]))
+	if address+uint64(len(bytes)) > uint64(len(in.memory)) {
+		panic(trap{errors.New("wasm module allocated memory out of its memory")})
+	}
+	copy(in.memory[address:], bytes)
+	return uint32(address)
+}
+
+func (in *instance) readString(args []uint64, maxLength uint64) (string, bool) {
+	address, length := uint64(uint32(args[0])), uint64(uint32(args[1]))
+	if length > maxLength {
+		length = maxLength
+	}
+	if address+length > uint64(len(in.memory)) {
+		return "", false
+	}
+	return string(in.memory[address : address+length]), true
+}
+
+// hostFunctions are the functions modules can import from the host module.
+var hostFunctions = map[string]hostFunction{
+	"log": {
+		functionType: functionType{params: []valueType{i32, i32}},
+		call:         hostLog,
+	},
+	"http_get": {
+		functionType: functionType{params: []valueType{i32, i32}, results: []valueType{i64}},
+		call:         hostHTTPGet,
+	},
+}
+
+func hostLog(in *instance, args []uint64) uint64 {
+	message, ok := in.readString(args, maxLogLength)
+	if in.host != nil && ok {
+		in.host.Log(message)
+	}
+	return 0
+}
+
+func hostHTTPGet(in *instance, args []uint64) uint64 {
+	failed := ^uint64(0)
+	url, ok := in.readString(args, uint64(len(in.memory)))
+	if in.host == nil || !ok {
+		return failed
+	}
+
+	body, err := in.host.HTTPGet(url)
+	if err != nil {
+		logger.Debugw("wasm module HTTP request failed", "url", url, "error", err)
+		return failed
+	}
+	if time.Now().After(in.deadline) {
+		panic(trap{errTimedOut})
+	}
+	address := in.writeBytes(body)
+	return uint64(address)<<32 | uint64(len(body))
+}
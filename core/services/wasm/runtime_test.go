@@ -0,0 +1,254 @@
+package wasm_test
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/wasm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func leb128(n int) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func vec(items ...[]byte) []byte {
+	out := leb128(len(items))
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func section(id byte, items ...[]byte) []byte {
+	payload := vec(items...)
+	return append(append([]byte{id}, leb128(len(payload))...), payload...)
+}
+
+func name(s string) []byte {
+	return append(leb128(len(s)), s...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, part := range parts {
+		out = append(out, part...)
+	}
+	return out
+}
+
+const (
+	withoutHost = false
+	withHost    = true
+)
+
+// module assembles a module with the given pages of memory, a bump
+// allocator exported as allocate, and the given body exported as perform.
+// With the host API, http_get is function 0, log function 1, and perform
+// function 3, otherwise perform is function 1.
+func module(pages int, host bool, perform ...byte) []byte {
+	i32, i64 := byte(0x7f), byte(0x7e)
+	var imports, allocateIndex []byte
+	if host {
+		imports = section(2,
+			concat(name("chainlink"), name("http_get"), []byte{0x00, 1}),
+			concat(name("chainlink"), name("log"), []byte{0x00, 2}),
+		)
+		allocateIndex = []byte{2}
+	} else {
+		allocateIndex = []byte{0}
+	}
+	performIndex := []byte{allocateIndex[0] + 1}
+
+	// global.get 0; global.get 0; local.get 0; i32.add; global.set 0
+	allocate := []byte{0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00}
+	code := func(instructions []byte) []byte {
+		body := concat([]byte{0x00}, instructions, []byte{0x0b})
+		return append(leb128(len(body)), body...)
+	}
+
+	return concat(
+		[]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		section(1,
+			[]byte{0x60, 1, i32, 1, i32},
+			[]byte{0x60, 2, i32, i32, 1, i64},
+			[]byte{0x60, 2, i32, i32, 0},
+		),
+		imports,
+		section(3, []byte{0}, []byte{1}),
+		section(5, concat([]byte{0x00}, leb128(pages))),
+		// A mutable i32 heap pointer, starting at 1024
+		section(6, []byte{i32, 0x01, 0x41, 0x80, 0x08, 0x0b}),
+		section(7,
+			concat(name("memory"), []byte{0x02, 0}),
+			concat(name("allocate"), []byte{0x00}, allocateIndex),
+			concat(name("perform"), []byte{0x00}, performIndex),
+		),
+		section(10, code(allocate), code(perform)),
+	)
+}
+
+// echo returns its input:
+// (i64.extend_i32_u address) << 32 | (i64.extend_i32_u size)
+var echo = []byte{0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84}
+
+type stubHost struct {
+	logs      []string
+	responses map[string]string
+}
+
+func (h *stubHost) Log(message string) {
+	h.logs = append(h.logs, message)
+}
+
+func (h *stubHost) HTTPGet(url string) ([]byte, error) {
+	response, ok := h.responses[url]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(response), nil
+}
+
+func newRuntime(t *testing.T, timeout time.Duration, fuelLimit uint64) *wasm.Runtime {
+	runtime, err := wasm.NewRuntime(16, timeout, fuelLimit)
+	require.NoError(t, err)
+	return runtime
+}
+
+func TestRuntime_Run(t *testing.T) {
+	t.Parallel()
+
+	runtime := newRuntime(t, time.Second, 1000)
+	defer runtime.Close()
+
+	binary := module(1, withoutHost, echo...)
+	output, err := runtime.Run(binary, []byte(`{"result":"3.14"}`), &stubHost{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"result":"3.14"}`, string(output))
+
+	hash, err := runtime.Compile(binary)
+	require.NoError(t, err)
+	assert.Equal(t, wasm.Hash(binary), hash)
+	assert.Len(t, hash, 64)
+}
+
+func TestRuntime_Run_Concurrently(t *testing.T) {
+	t.Parallel()
+
+	runtime := newRuntime(t, time.Second, 1000)
+	defer runtime.Close()
+
+	binary := module(1, withoutHost, echo...)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			output, err := runtime.Run(binary, []byte(`{}`), &stubHost{})
+			assert.NoError(t, err)
+			assert.Equal(t, `{}`, string(output))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRuntime_Run_HostAPI(t *testing.T) {
+	t.Parallel()
+
+	runtime := newRuntime(t, time.Second, 1000)
+	defer runtime.Close()
+
+	// log(address, size); return http_get(address, size)
+	binary := module(1, withHost, 0x20, 0x00, 0x20, 0x01, 0x10, 0x01, 0x20, 0x00, 0x20, 0x01, 0x10, 0x00)
+	host := &stubHost{responses: map[string]string{"https://example.com/price": `{"result":231.57}`}}
+
+	output, err := runtime.Run(binary, []byte("https://example.com/price"), host)
+	require.NoError(t, err)
+	assert.Equal(t, `{"result":231.57}`, string(output))
+	assert.Equal(t, []string{"https://example.com/price"}, host.logs)
+
+	// A failed request returns -1, which is out of memory when used as output
+	_, err = runtime.Run(binary, []byte("https://example.com/missing"), host)
+	assert.Error(t, err)
+}
+
+func TestRuntime_Run_Limits(t *testing.T) {
+	t.Parallel()
+
+	// loop br 0 end; i64.const 0
+	infiniteLoop := []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00}
+	// perform(address, size), calling itself forever
+	infiniteRecursion := []byte{0x20, 0x00, 0x20, 0x01, 0x10, 0x01}
+
+	tests := []struct {
+		name      string
+		binary    []byte
+		timeout   time.Duration
+		fuelLimit uint64
+		wantError string
+	}{
+		{"fuel", module(1, withoutHost, infiniteLoop...), time.Minute, 1000, wasm.ErrFuelExhausted.Error()},
+		{"fuel with recursion", module(1, withoutHost, infiniteRecursion...), time.Minute, 100, wasm.ErrFuelExhausted.Error()},
+		{"timeout", module(1, withoutHost, infiniteLoop...), 100 * time.Millisecond, math.MaxUint64, "timed out"},
+		{"call depth", module(1, withoutHost, infiniteRecursion...), time.Minute, math.MaxUint64, "call stack exhausted"},
+		{"memory", module(17, withoutHost, echo...), time.Second, 1000, "invalid wasm module"},
+		{"invalid module", []byte("not wasm"), time.Second, 1000, "invalid wasm module"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			runtime := newRuntime(t, test.timeout, test.fuelLimit)
+			defer runtime.Close()
+
+			_, err := runtime.Run(test.binary, []byte(`{}`), &stubHost{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantError)
+		})
+	}
+}
+
+func TestRuntime_Run_FuelIsPerRun(t *testing.T) {
+	t.Parallel()
+
+	// The allocate and echo functions run 14 instructions, including their
+	// returns
+	runtime := newRuntime(t, time.Second, 14)
+	defer runtime.Close()
+
+	binary := module(1, withoutHost, echo...)
+	for i := 0; i < 3; i++ {
+		_, err := runtime.Run(binary, []byte(`{}`), &stubHost{})
+		require.NoError(t, err)
+	}
+
+	runtime = newRuntime(t, time.Second, 13)
+	defer runtime.Close()
+	_, err := runtime.Run(binary, []byte(`{}`), &stubHost{})
+	assert.Equal(t, wasm.ErrFuelExhausted, err)
+}
+
+func TestRuntime_Compile_ChecksABI(t *testing.T) {
+	t.Parallel()
+
+	runtime := newRuntime(t, time.Second, 1000)
+	defer runtime.Close()
+
+	// A module exporting nothing
+	_, err := runtime.Compile([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `must export its memory as "memory"`)
+}
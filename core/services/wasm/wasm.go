@@ -0,0 +1,54 @@
+// Package wasm runs WebAssembly modules for the wasm adapter.
+//
+// Modules follow a JSON in, JSON out ABI. They must export their memory as
+// "memory", and the functions:
+//
+//   allocate(size i32) i32
+//     returns the address of size bytes of memory owned by the host.
+//   perform(address i32, size i32) i64
+//     takes the JSON input written by the host at the given address, and
+//     returns the address and size of its JSON output packed into the high
+//     and low 32 bits of the result.
+//
+// Modules may import the following functions from the "chainlink" module:
+//
+//   log(address i32, size i32)
+//     logs the given message.
+//   http_get(address i32, size i32) i64
+//     fetches the URL at the given address, with the restrictions of the
+//     httpget adapter, and returns the response body, allocated with the
+//     module's allocate function, packed like the result of perform, or
+//     -1 if the request failed.
+package wasm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	hostModuleName   = "chainlink"
+	allocateFunction = "allocate"
+	performFunction  = "perform"
+	memoryName       = "memory"
+	// maxLogLength is the length of the longest message a module can log.
+	maxLogLength = 4096
+)
+
+// ErrFuelExhausted is returned when a module runs more instructions than its
+// fuel limit allows.
+var ErrFuelExhausted = errors.New("wasm module ran out of fuel")
+
+// Host is the API offered to modules by the node while they run.
+type Host interface {
+	Log(message string)
+	HTTPGet(url string) ([]byte, error)
+}
+
+// Hash returns the hash identifying a module.
+func Hash(binary []byte) string {
+	sum := sha256.Sum256(binary)
+	return hex.EncodeToString(sum[:])
+}
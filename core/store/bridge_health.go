@@ -0,0 +1,261 @@
+package store
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// States of a bridge's circuit breaker.
+const (
+	// CircuitClosed lets requests through to a healthy bridge.
+	CircuitClosed = "closed"
+	// CircuitOpen fails requests to an unhealthy bridge without sending them.
+	CircuitOpen = "open"
+	// CircuitHalfOpen has let one request through to an unhealthy bridge
+	// after the cooldown, whose outcome closes or opens the circuit again. A
+	// probe whose outcome isn't recorded within the cooldown is given up on,
+	// and another let through.
+	CircuitHalfOpen = "half_open"
+)
+
+// BridgeHealth is the health of a bridge, as seen by its circuit breaker.
+type BridgeHealth struct {
+	Circuit             string     `json:"circuit"`
+	ConsecutiveFailures uint16     `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastCheckedAt       *time.Time `json:"lastCheckedAt,omitempty"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	probedAt            time.Time
+}
+
+// Healthy returns true if requests can be sent to the bridge.
+func (h BridgeHealth) Healthy() bool {
+	return h.Circuit == CircuitClosed
+}
+
+// BridgeHealthMonitor keeps a circuit breaker for each bridge, opened when
+// threshold consecutive requests to the bridge fail, or its health URL
+// fails a check. While open, requests to the bridge are failed without being
+// sent, until the cooldown has passed and one request is let through to
+// probe it, or its health URL passes a check.
+type BridgeHealthMonitor struct {
+	bridges   func() ([]models.BridgeType, error)
+	client    *http.Client
+	clock     utils.Nower
+	threshold uint16
+	cooldown  time.Duration
+	interval  time.Duration
+	breakers  map[models.TaskType]*BridgeHealth
+	mutex     sync.Mutex
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewBridgeHealthMonitor returns a monitor checking the health URLs of the
+// bridges returned by the given function every interval.
+func NewBridgeHealthMonitor(
+	bridges func() ([]models.BridgeType, error),
+	threshold uint16,
+	cooldown time.Duration,
+	interval time.Duration,
+	clock utils.Nower,
+) *BridgeHealthMonitor {
+	return &BridgeHealthMonitor{
+		bridges:   bridges,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		clock:     clock,
+		threshold: threshold,
+		cooldown:  cooldown,
+		interval:  interval,
+		breakers:  make(map[models.TaskType]*BridgeHealth),
+	}
+}
+
+// Start checks the health URLs of the bridges every interval.
+func (m *BridgeHealthMonitor) Start() {
+	if m.interval <= 0 {
+		return
+	}
+	m.done = make(chan struct{})
+	m.wg.Add(1)
+	go m.checkHealthPeriodically()
+}
+
+// Stop stops checking the health URLs of the bridges.
+func (m *BridgeHealthMonitor) Stop() {
+	if m.done != nil {
+		close(m.done)
+		m.wg.Wait()
+	}
+}
+
+func (m *BridgeHealthMonitor) checkHealthPeriodically() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			m.CheckHealth()
+		}
+	}
+}
+
+// CheckHealth requests the health URL of each bridge which has one, and
+// opens or closes its circuit depending on whether it answers with a 2xx
+// status code.
+func (m *BridgeHealthMonitor) CheckHealth() {
+	bridges, err := m.bridges()
+	if err != nil {
+		logger.Errorw("Unable to load bridges to check their health", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, bridge := range bridges {
+		if bridge.HealthURL == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(bridge models.BridgeType) {
+			defer wg.Done()
+			err := m.checkHealthURL(bridge.HealthURL.String())
+
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			health := m.breaker(bridge.Name)
+			now := m.clock.Now()
+			health.LastCheckedAt = &now
+			if err != nil {
+				m.open(bridge.Name, health, err)
+			} else {
+				m.close(bridge.Name, health)
+			}
+		}(bridge)
+	}
+	wg.Wait()
+}
+
+func (m *BridgeHealthMonitor) checkHealthURL(url string) error {
+	response, err := m.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("health check returned status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// Health returns the health of the bridge.
+func (m *BridgeHealthMonitor) Health(name models.TaskType) BridgeHealth {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return *m.breaker(name)
+}
+
+// Allow returns true if a request can be sent to the bridge: if its circuit
+// is closed, or if it is open and the cooldown has passed, in which case the
+// request is let through as a probe. The outcome of a request which is let
+// through must be recorded with RecordSuccess or RecordFailure, or Skip if it
+// is not sent after all.
+func (m *BridgeHealthMonitor) Allow(name models.TaskType) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.clock.Now()
+	health := m.breaker(name)
+	switch health.Circuit {
+	case CircuitClosed:
+		return true
+	case CircuitOpen:
+		if now.Sub(*health.OpenedAt) >= m.cooldown {
+			health.Circuit = CircuitHalfOpen
+			health.probedAt = now
+			return true
+		}
+	case CircuitHalfOpen:
+		if now.Sub(health.probedAt) >= m.cooldown {
+			logger.Warnw("Bridge probe timed out, letting another through", "bridge", name)
+			health.probedAt = now
+			return true
+		}
+	}
+	return false
+}
+
+// Skip records that a request let through by Allow was not sent, so that
+// another request can probe the bridge if its circuit was half open.
+func (m *BridgeHealthMonitor) Skip(name models.TaskType) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	health := m.breaker(name)
+	if health.Circuit == CircuitHalfOpen {
+		health.Circuit = CircuitOpen
+	}
+}
+
+// RecordSuccess closes the circuit of the bridge after a successful request.
+func (m *BridgeHealthMonitor) RecordSuccess(name models.TaskType) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.close(name, m.breaker(name))
+}
+
+// RecordFailure counts a failed request to the bridge, opening its circuit
+// if the threshold is reached, or if the request was a probe.
+func (m *BridgeHealthMonitor) RecordFailure(name models.TaskType, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	health := m.breaker(name)
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	if health.Circuit == CircuitHalfOpen || health.ConsecutiveFailures >= m.threshold {
+		m.open(name, health, err)
+	}
+}
+
+func (m *BridgeHealthMonitor) breaker(name models.TaskType) *BridgeHealth {
+	health, ok := m.breakers[name]
+	if !ok {
+		health = &BridgeHealth{Circuit: CircuitClosed}
+		m.breakers[name] = health
+	}
+	return health
+}
+
+func (m *BridgeHealthMonitor) open(name models.TaskType, health *BridgeHealth, err error) {
+	if health.Circuit != CircuitOpen {
+		logger.Warnw("Bridge is unhealthy, opening its circuit", "bridge", name, "error", err)
+	}
+	now := m.clock.Now()
+	health.Circuit = CircuitOpen
+	health.OpenedAt = &now
+	health.LastError = err.Error()
+}
+
+func (m *BridgeHealthMonitor) close(name models.TaskType, health *BridgeHealth) {
+	if health.Circuit != CircuitClosed {
+		logger.Infow("Bridge is healthy again, closing its circuit", "bridge", name)
+	}
+	health.Circuit = CircuitClosed
+	health.ConsecutiveFailures = 0
+	health.OpenedAt = nil
+	health.LastError = ""
+}
@@ -0,0 +1,133 @@
+package store_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func noBridges() ([]models.BridgeType, error) {
+	return nil, nil
+}
+
+func TestBridgeHealthMonitor_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	clock := &fixedClock{now: time.Unix(1593005428, 0)}
+	monitor := strpkg.NewBridgeHealthMonitor(noBridges, 3, time.Minute, 0, clock)
+	name := models.MustNewTaskType("bridge")
+	failure := errors.New("connection refused")
+
+	assert.True(t, monitor.Allow(name))
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(name).Circuit)
+
+	monitor.RecordFailure(name, failure)
+	monitor.RecordFailure(name, failure)
+	monitor.RecordSuccess(name)
+	assert.Equal(t, uint16(0), monitor.Health(name).ConsecutiveFailures, "success should reset failures")
+
+	for i := 0; i < 3; i++ {
+		require.True(t, monitor.Allow(name))
+		monitor.RecordFailure(name, failure)
+	}
+	health := monitor.Health(name)
+	assert.Equal(t, strpkg.CircuitOpen, health.Circuit)
+	assert.False(t, health.Healthy())
+	assert.Equal(t, "connection refused", health.LastError)
+	assert.False(t, monitor.Allow(name), "should fail fast while open")
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.True(t, monitor.Allow(name), "should let a probe through after the cooldown")
+	assert.Equal(t, strpkg.CircuitHalfOpen, monitor.Health(name).Circuit)
+	assert.False(t, monitor.Allow(name), "should let only one probe through")
+
+	monitor.RecordFailure(name, failure)
+	assert.Equal(t, strpkg.CircuitOpen, monitor.Health(name).Circuit, "failed probe should reopen")
+	assert.False(t, monitor.Allow(name))
+
+	clock.now = clock.now.Add(time.Minute)
+	require.True(t, monitor.Allow(name))
+	monitor.RecordSuccess(name)
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(name).Circuit, "successful probe should close")
+	assert.True(t, monitor.Allow(name))
+}
+
+func TestBridgeHealthMonitor_HalfOpen(t *testing.T) {
+	t.Parallel()
+
+	clock := &fixedClock{now: time.Unix(1593005428, 0)}
+	monitor := strpkg.NewBridgeHealthMonitor(noBridges, 1, time.Minute, 0, clock)
+	name := models.MustNewTaskType("bridge")
+
+	require.True(t, monitor.Allow(name))
+	monitor.RecordFailure(name, errors.New("connection refused"))
+	require.Equal(t, strpkg.CircuitOpen, monitor.Health(name).Circuit)
+
+	clock.now = clock.now.Add(time.Minute)
+	require.True(t, monitor.Allow(name))
+	monitor.Skip(name)
+	assert.Equal(t, strpkg.CircuitOpen, monitor.Health(name).Circuit, "unsent probe should reopen")
+	assert.True(t, monitor.Allow(name), "should let another probe through")
+	assert.False(t, monitor.Allow(name))
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.True(t, monitor.Allow(name), "should give up on a probe after the cooldown")
+	assert.Equal(t, strpkg.CircuitHalfOpen, monitor.Health(name).Circuit)
+	monitor.RecordSuccess(name)
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(name).Circuit)
+
+	monitor.Skip(name)
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(name).Circuit, "skip should leave a closed circuit")
+}
+
+func TestBridgeHealthMonitor_CheckHealth(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	parsed, err := url.Parse(server.URL + "/health")
+	require.NoError(t, err)
+	healthURL := models.WebURL(*parsed)
+	checked := models.BridgeType{Name: models.MustNewTaskType("checked"), HealthURL: &healthURL}
+	unchecked := models.BridgeType{Name: models.MustNewTaskType("unchecked")}
+	bridges := func() ([]models.BridgeType, error) {
+		return []models.BridgeType{checked, unchecked}, nil
+	}
+
+	clock := &fixedClock{now: time.Unix(1593005428, 0)}
+	monitor := strpkg.NewBridgeHealthMonitor(bridges, 3, time.Minute, 0, clock)
+
+	monitor.CheckHealth()
+	health := monitor.Health(checked.Name)
+	assert.Equal(t, strpkg.CircuitOpen, health.Circuit, "a failed health check should open the circuit at once")
+	assert.Contains(t, health.LastError, "503")
+	require.NotNil(t, health.LastCheckedAt)
+	assert.Equal(t, clock.now, *health.LastCheckedAt)
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(unchecked.Name).Circuit)
+
+	status = http.StatusOK
+	monitor.CheckHealth()
+	assert.Equal(t, strpkg.CircuitClosed, monitor.Health(checked.Name).Circuit)
+	assert.True(t, monitor.Allow(checked.Name))
+}
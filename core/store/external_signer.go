@@ -0,0 +1,263 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrExternalSignerUnsupported is returned for key store operations which
+// have to be performed on the external signer itself.
+var ErrExternalSignerUnsupported = errors.New("operation not supported with an external signer, see ETH_SIGNER_URL")
+
+// ExternalSigner is a KeyStoreInterface for keys held by a remote signer,
+// which signs over JSON-RPC with Clef's external API, so that the keys never
+// live on the node's host.
+//
+// See: https://github.com/ethereum/go-ethereum/blob/master/cmd/clef/extapi_changelog.md
+type ExternalSigner struct {
+	client   *rpc.Client
+	url      string
+	accounts []accounts.Account
+	mutex    *sync.Mutex
+}
+
+// NewExternalSigner connects to the signer at the given URL, and checks
+// that it is reachable.
+func NewExternalSigner(url string) (*ExternalSigner, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var version string
+	if err := client.Call(&version, "account_version"); err != nil {
+		return nil, fmt.Errorf("unable to reach external signer at %s: %v", url, err)
+	}
+	logger.Infow("Connected to external signer", "url", url, "version", version)
+
+	return &ExternalSigner{
+		client: client,
+		url:    url,
+		mutex:  &sync.Mutex{},
+	}, nil
+}
+
+// Accounts returns the accounts of the signer. They are listed once, so
+// accounts added to the signer are only used after a restart.
+func (es *ExternalSigner) Accounts() []accounts.Account {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	if es.accounts != nil {
+		return es.accounts
+	}
+
+	var addresses []common.Address
+	if err := es.client.Call(&addresses, "account_list"); err != nil {
+		logger.Errorw("Unable to list external signer accounts", "url", es.url, "error", err)
+		return nil
+	}
+	es.accounts = make([]accounts.Account, len(addresses))
+	for i, address := range addresses {
+		es.accounts[i] = accounts.Account{
+			Address: address,
+			URL:     accounts.URL{Scheme: "extapi", Path: es.url},
+		}
+	}
+	return es.accounts
+}
+
+// GetAccounts returns all accounts
+func (es *ExternalSigner) GetAccounts() []accounts.Account {
+	return es.Accounts()
+}
+
+// Wallets returns no wallets, as those of the signer are not exposed.
+func (es *ExternalSigner) Wallets() []accounts.Wallet {
+	return nil
+}
+
+// GetFirstAccount returns the first account of the signer.
+func (es *ExternalSigner) GetFirstAccount() (accounts.Account, error) {
+	accts := es.Accounts()
+	if len(accts) == 0 {
+		return accounts.Account{}, fmt.Errorf("no Ethereum Accounts in external signer at %s", es.url)
+	}
+	return accts[0], nil
+}
+
+// HasAccounts returns true if the signer has accounts.
+func (es *ExternalSigner) HasAccounts() bool {
+	return len(es.Accounts()) > 0
+}
+
+// Unlock does nothing, as the signer decides which requests to sign.
+func (es *ExternalSigner) Unlock(phrase string) error {
+	return nil
+}
+
+// NewAccount is not supported, accounts must be created on the signer.
+func (es *ExternalSigner) NewAccount(passphrase string) (accounts.Account, error) {
+	return accounts.Account{}, ErrExternalSignerUnsupported
+}
+
+// Import is not supported, keys must be imported into the signer.
+func (es *ExternalSigner) Import(keyJSON []byte, passphrase, newPassphrase string) (accounts.Account, error) {
+	return accounts.Account{}, ErrExternalSignerUnsupported
+}
+
+// externalSignerTxArgs are the arguments of account_signTransaction.
+type externalSignerTxArgs struct {
+	From                 common.MixedcaseAddress  `json:"from"`
+	To                   *common.MixedcaseAddress `json:"to"`
+	Gas                  hexutil.Uint64           `json:"gas"`
+	GasPrice             *hexutil.Big             `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big             `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big             `json:"maxPriorityFeePerGas,omitempty"`
+	Value                hexutil.Big              `json:"value"`
+	Nonce                hexutil.Uint64           `json:"nonce"`
+	Data                 *hexutil.Bytes           `json:"data"`
+	ChainID              *hexutil.Big             `json:"chainId,omitempty"`
+}
+
+func (es *ExternalSigner) signTransaction(args externalSignerTxArgs) ([]byte, error) {
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := es.client.Call(&result, "account_signTransaction", &args); err != nil {
+		return nil, err
+	}
+	return result.Raw, nil
+}
+
+// SignTx asks the signer to sign the given transaction, and checks that it
+// signed that transaction with the given account.
+func (es *ExternalSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	var to *common.MixedcaseAddress
+	if tx.To() != nil {
+		address := common.NewMixedcaseAddress(*tx.To())
+		to = &address
+	}
+	raw, err := es.signTransaction(externalSignerTxArgs{
+		From:     common.NewMixedcaseAddress(account.Address),
+		To:       to,
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Value:    hexutil.Big(*tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Data:     &data,
+		ChainID:  (*hexutil.Big)(chainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, signed); err != nil {
+		return nil, err
+	}
+	signer := types.NewEIP155Signer(chainID)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, errors.New("external signer signed another transaction")
+	}
+	if err := checkSender(signer, signed, account); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+func checkSender(signer types.Signer, tx *types.Transaction, account accounts.Account) error {
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		return err
+	}
+	if sender != account.Address {
+		return fmt.Errorf("external signer signed with %s instead of %s", sender.Hex(), account.Address.Hex())
+	}
+	return nil
+}
+
+// SignDynamicFeeTx asks the signer to sign the given EIP-1559 transaction,
+// and returns it encoded along with its hash.
+func (es *ExternalSigner) SignDynamicFeeTx(account accounts.Account, tx *eth.DynamicFeeTx) ([]byte, common.Hash, error) {
+	data := hexutil.Bytes(tx.Data)
+	to := common.NewMixedcaseAddress(tx.To)
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	raw, err := es.signTransaction(externalSignerTxArgs{
+		From:                 common.NewMixedcaseAddress(account.Address),
+		To:                   &to,
+		Gas:                  hexutil.Uint64(tx.Gas),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap),
+		Value:                hexutil.Big(*value),
+		Nonce:                hexutil.Uint64(tx.Nonce),
+		Data:                 &data,
+		ChainID:              (*hexutil.Big)(tx.ChainID),
+	})
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	signature, err := tx.Signature(raw)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("external signer signed another transaction: %v", err)
+	}
+	hash, err := tx.SigningHash()
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	publicKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if sender := crypto.PubkeyToAddress(*publicKey); sender != account.Address {
+		return nil, common.Hash{}, fmt.Errorf("external signer signed with %s instead of %s", sender.Hex(), account.Address.Hex())
+	}
+	return raw, crypto.Keccak256Hash(raw), nil
+}
+
+// SignHash asks the signer to sign the given digest with the first account,
+// as a text message, which adds the same ethereum message prefix as
+// KeyStore#SignHash.
+func (es *ExternalSigner) SignHash(hash common.Hash) (models.Signature, error) {
+	account, err := es.GetFirstAccount()
+	if err != nil {
+		return models.Signature{}, err
+	}
+
+	address := common.NewMixedcaseAddress(account.Address)
+	var output hexutil.Bytes
+	if err := es.client.Call(&output, "account_signData", accounts.MimetypeTextPlain, &address, hash.Hex()); err != nil {
+		return models.Signature{}, err
+	}
+	if len(output) != crypto.SignatureLength {
+		return models.Signature{}, fmt.Errorf("wrong size for signature: got %d, want %d", len(output), crypto.SignatureLength)
+	}
+	// The signer returns V as 27 or 28, rather than the 0 or 1 returned by
+	// the local key store
+	if output[64] >= 27 {
+		output[64] -= 27
+	}
+
+	var signature models.Signature
+	signature.SetBytes(output)
+	return signature, nil
+}
@@ -0,0 +1,216 @@
+package store_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSigner implements the parts of Clef's external API used by
+// ExternalSigner, signing with key on behalf of address.
+type stubSigner struct {
+	address common.Address
+	key     *ecdsa.PrivateKey
+}
+
+type stubSignerTxArgs struct {
+	To                   *common.MixedcaseAddress `json:"to"`
+	Gas                  hexutil.Uint64           `json:"gas"`
+	GasPrice             *hexutil.Big             `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big             `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big             `json:"maxPriorityFeePerGas"`
+	Value                hexutil.Big              `json:"value"`
+	Nonce                hexutil.Uint64           `json:"nonce"`
+	Data                 *hexutil.Bytes           `json:"data"`
+	ChainID              *hexutil.Big             `json:"chainId"`
+}
+
+type stubSignerTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func (s *stubSigner) Version() string {
+	return "6.0.0"
+}
+
+func (s *stubSigner) List() []common.Address {
+	return []common.Address{s.address}
+}
+
+func (s *stubSigner) SignTransaction(args stubSignerTxArgs) (*stubSignerTxResult, error) {
+	if args.MaxFeePerGas != nil {
+		tx := &eth.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To.Address(),
+			Value:     args.Value.ToInt(),
+			Data:      *args.Data,
+		}
+		hash, err := tx.SigningHash()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := crypto.Sign(hash.Bytes(), s.key)
+		if err != nil {
+			return nil, err
+		}
+		raw, _, err := tx.EncodeSigned(sig)
+		return &stubSignerTxResult{Raw: raw}, err
+	}
+
+	tx := types.NewTransaction(uint64(args.Nonce), args.To.Address(), args.Value.ToInt(), uint64(args.Gas), args.GasPrice.ToInt(), *args.Data)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rlp.EncodeToBytes(signed)
+	return &stubSignerTxResult{Raw: raw}, err
+}
+
+func (s *stubSigner) SignData(contentType string, address common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func newStubSigner(t *testing.T, address common.Address, key *ecdsa.PrivateKey) (*strpkg.ExternalSigner, func()) {
+	t.Helper()
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", &stubSigner{address: address, key: key}))
+	httpServer := httptest.NewServer(server)
+
+	signer, err := strpkg.NewExternalSigner(httpServer.URL)
+	require.NoError(t, err)
+	return signer, func() {
+		httpServer.Close()
+		server.Stop()
+	}
+}
+
+func TestExternalSigner_Accounts(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	signer, cleanup := newStubSigner(t, address, key)
+	defer cleanup()
+
+	assert.True(t, signer.HasAccounts())
+	account, err := signer.GetFirstAccount()
+	require.NoError(t, err)
+	assert.Equal(t, address, account.Address)
+	assert.NoError(t, signer.Unlock("ignored"))
+
+	_, err = signer.NewAccount("password")
+	assert.Equal(t, strpkg.ErrExternalSignerUnsupported, err)
+}
+
+func TestExternalSigner_SignTx(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	signer, cleanup := newStubSigner(t, address, key)
+	defer cleanup()
+
+	account, err := signer.GetFirstAccount()
+	require.NoError(t, err)
+	chainID := big.NewInt(3)
+	tx := types.NewTransaction(7, common.HexToAddress("0x356a04bce728ba4c62a30294a55e6a8600a320b3"), big.NewInt(1), 500000, big.NewInt(20000000000), []byte{0xde, 0xad})
+
+	signed, err := signer.SignTx(account, tx, chainID)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+	assert.Equal(t, tx.Nonce(), signed.Nonce())
+	assert.Equal(t, tx.Data(), signed.Data())
+}
+
+func TestExternalSigner_SignTx_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, cleanup := newStubSigner(t, crypto.PubkeyToAddress(key.PublicKey), otherKey)
+	defer cleanup()
+
+	account, err := signer.GetFirstAccount()
+	require.NoError(t, err)
+	tx := types.NewTransaction(7, common.HexToAddress("0x356a04bce728ba4c62a30294a55e6a8600a320b3"), big.NewInt(1), 500000, big.NewInt(20000000000), []byte{})
+
+	_, err = signer.SignTx(account, tx, big.NewInt(3))
+	assert.Error(t, err)
+}
+
+func TestExternalSigner_SignDynamicFeeTx(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	signer, cleanup := newStubSigner(t, address, key)
+	defer cleanup()
+
+	account, err := signer.GetFirstAccount()
+	require.NoError(t, err)
+	tx := &eth.DynamicFeeTx{
+		ChainID:   big.NewInt(3),
+		Nonce:     7,
+		GasTipCap: big.NewInt(2000000000),
+		GasFeeCap: big.NewInt(100000000000),
+		Gas:       500000,
+		To:        common.HexToAddress("0x356a04bce728ba4c62a30294a55e6a8600a320b3"),
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad},
+	}
+
+	raw, hash, err := signer.SignDynamicFeeTx(account, tx)
+	require.NoError(t, err)
+	assert.Equal(t, eth.DynamicFeeTxType, raw[0])
+	assert.Equal(t, crypto.Keccak256Hash(raw), hash)
+}
+
+func TestExternalSigner_SignHash(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	signer, cleanup := newStubSigner(t, address, key)
+	defer cleanup()
+
+	hash := common.HexToHash("0x9a6ab2b6b1ff6d1ad5e0bcf4a6f5a6bde00b07c2e9dd0b8c6ea54c8e6fd2e2a9")
+	signature, err := signer.SignHash(hash)
+	require.NoError(t, err)
+
+	prefixed := crypto.Keccak256([]byte(strpkg.EthereumMessageHashPrefix), hash.Bytes())
+	publicKey, err := crypto.SigToPub(prefixed, signature.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(*publicKey))
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"golang.org/x/time/rate"
+)
+
+// hostLimitRetryDelay is how long to wait before retrying a request to a
+// host which had the maximum number of requests in flight.
+const hostLimitRetryDelay = time.Second
+
+// HostLimitedError is returned when a request cannot be sent to a host
+// without going over its limits.
+type HostLimitedError struct {
+	Host string
+	// Wait is how long to wait before the request can be sent.
+	Wait time.Duration
+}
+
+func (e *HostLimitedError) Error() string {
+	return fmt.Sprintf("requests to %s are over its limits, retry in %s", e.Host, e.Wait)
+}
+
+// HostLimiter limits the rate of the requests sent to each host, and the
+// number of requests in flight to it, so that bursts of runs stay within
+// the quotas of data providers. It is shared by the HTTP and bridge adapters
+// and the flux monitor.
+type HostLimiter struct {
+	defaults orm.HTTPHostLimit
+	limits   orm.HTTPHostLimits
+	hosts    map[string]*hostLimiter
+	mutex    sync.Mutex
+}
+
+type hostLimiter struct {
+	limit    orm.HTTPHostLimit
+	rate     *rate.Limiter
+	inFlight uint64
+}
+
+// NewHostLimiter returns a limiter applying the given limits to each host,
+// or the defaults to hosts without limits of their own.
+func NewHostLimiter(defaults orm.HTTPHostLimit, limits orm.HTTPHostLimits) *HostLimiter {
+	return &HostLimiter{
+		defaults: defaults,
+		limits:   limits,
+		hosts:    make(map[string]*hostLimiter),
+	}
+}
+
+// Acquire reserves a request to the host of the URL, returning a function
+// to call once the request is finished, or a HostLimitedError if the
+// request would go over the host's limits. A nil limiter has no limits.
+func (l *HostLimiter) Acquire(u *url.URL) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	limiter := l.limiter(host)
+	if limiter.limit.MaxInFlight > 0 && limiter.inFlight >= limiter.limit.MaxInFlight {
+		return nil, &HostLimitedError{Host: host, Wait: hostLimitRetryDelay}
+	}
+	if limiter.rate != nil {
+		reservation := limiter.rate.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			return nil, &HostLimitedError{Host: host, Wait: delay}
+		}
+	}
+
+	limiter.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+			limiter.inFlight--
+		})
+	}, nil
+}
+
+func newHostLimiterFromConfig(config orm.ConfigReader) *HostLimiter {
+	return NewHostLimiter(
+		orm.HTTPHostLimit{
+			RequestsPerSecond: config.HTTPHostRequestsPerSecond(),
+			MaxInFlight:       config.HTTPHostMaxInFlight(),
+		},
+		config.HTTPHostLimits(),
+	)
+}
+
+func (l *HostLimiter) limiter(host string) *hostLimiter {
+	limiter, ok := l.hosts[host]
+	if ok {
+		return limiter
+	}
+
+	limit, ok := l.limits[host]
+	if !ok {
+		limit = l.defaults
+	}
+	limiter = &hostLimiter{limit: limit}
+	if limit.RequestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Floor(limit.RequestsPerSecond)))
+		limiter.rate = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+	}
+	l.hosts[host] = limiter
+	return limiter
+}
@@ -0,0 +1,87 @@
+package store_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseURL(t *testing.T, str string) *url.URL {
+	u, err := url.Parse(str)
+	require.NoError(t, err)
+	return u
+}
+
+func TestHostLimiter_MaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	limiter := strpkg.NewHostLimiter(orm.HTTPHostLimit{MaxInFlight: 2}, nil)
+	u := mustParseURL(t, "https://api.example.com/price")
+
+	first, err := limiter.Acquire(u)
+	require.NoError(t, err)
+	second, err := limiter.Acquire(u)
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire(mustParseURL(t, "https://API.example.com:443/other"))
+	require.Error(t, err)
+	limited, ok := err.(*strpkg.HostLimitedError)
+	require.True(t, ok)
+	assert.Equal(t, "api.example.com", limited.Host)
+	assert.True(t, limited.Wait > 0)
+
+	other, err := limiter.Acquire(mustParseURL(t, "https://other.example.com"))
+	require.NoError(t, err, "limits should be per host")
+	other()
+
+	first()
+	first()
+	third, err := limiter.Acquire(u)
+	require.NoError(t, err, "a released request should make room for another")
+	_, err = limiter.Acquire(u)
+	require.Error(t, err, "releasing twice should only make room for one")
+
+	second()
+	third()
+}
+
+func TestHostLimiter_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	limiter := strpkg.NewHostLimiter(
+		orm.HTTPHostLimit{},
+		orm.HTTPHostLimits{"api.example.com": {RequestsPerSecond: 0.5}},
+	)
+	u := mustParseURL(t, "https://api.example.com/price")
+
+	release, err := limiter.Acquire(u)
+	require.NoError(t, err)
+	release()
+
+	_, err = limiter.Acquire(u)
+	require.Error(t, err)
+	limited, ok := err.(*strpkg.HostLimitedError)
+	require.True(t, ok)
+	assert.True(t, limited.Wait > time.Second && limited.Wait <= 2*time.Second)
+
+	for i := 0; i < 10; i++ {
+		release, err = limiter.Acquire(mustParseURL(t, "https://unlimited.example.com"))
+		require.NoError(t, err, "hosts without limits should not be limited by default")
+		release()
+	}
+}
+
+func TestHostLimiter_Nil(t *testing.T) {
+	t.Parallel()
+
+	var limiter *strpkg.HostLimiter
+	release, err := limiter.Acquire(mustParseURL(t, "https://api.example.com"))
+	require.NoError(t, err)
+	release()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -34,6 +35,7 @@ type KeyStoreInterface interface {
 	GetAccounts() []accounts.Account
 
 	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignDynamicFeeTx(account accounts.Account, tx *eth.DynamicFeeTx) ([]byte, common.Hash, error)
 }
 
 // KeyStore manages a key storage directory on disk.
@@ -95,6 +97,20 @@ func (ks *KeyStore) SignTx(account accounts.Account, tx *types.Transaction, chai
 	return ks.KeyStore.SignTx(account, tx, chainID)
 }
 
+// SignDynamicFeeTx uses the unlocked account to sign the given EIP-1559
+// transaction, and returns it encoded along with its hash.
+func (ks *KeyStore) SignDynamicFeeTx(account accounts.Account, tx *eth.DynamicFeeTx) ([]byte, common.Hash, error) {
+	hash, err := tx.SigningHash()
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	signature, err := ks.KeyStore.SignHash(account, hash.Bytes())
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return tx.EncodeSigned(signature)
+}
+
 // SignHash signs a precomputed digest, using the first account's private key
 // This method adds an ethereum message prefix to the message before signing it,
 // invalidating any would-be valid Ethereum transactions
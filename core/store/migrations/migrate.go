@@ -54,6 +54,21 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1589470036"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1590226486"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1591141873"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1591603775"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1591863523"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1591952146"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1592212450"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1592385614"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1592471530"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1592836612"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1592918524"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593005428"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593091876"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593178312"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593264756"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593350572"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593437512"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1593523904"
 
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -267,6 +282,66 @@ func init() {
 			ID:      "1591141873",
 			Migrate: migration1591141873.Migrate,
 		},
+		{
+			ID:      "1591603775",
+			Migrate: migration1591603775.Migrate,
+		},
+		{
+			ID:      "1591863523",
+			Migrate: migration1591863523.Migrate,
+		},
+		{
+			ID:      "1591952146",
+			Migrate: migration1591952146.Migrate,
+		},
+		{
+			ID:      "1592212450",
+			Migrate: migration1592212450.Migrate,
+		},
+		{
+			ID:      "1592385614",
+			Migrate: migration1592385614.Migrate,
+		},
+		{
+			ID:      "1592471530",
+			Migrate: migration1592471530.Migrate,
+		},
+		{
+			ID:      "1592836612",
+			Migrate: migration1592836612.Migrate,
+		},
+		{
+			ID:      "1592918524",
+			Migrate: migration1592918524.Migrate,
+		},
+		{
+			ID:      "1593005428",
+			Migrate: migration1593005428.Migrate,
+		},
+		{
+			ID:      "1593091876",
+			Migrate: migration1593091876.Migrate,
+		},
+		{
+			ID:      "1593178312",
+			Migrate: migration1593178312.Migrate,
+		},
+		{
+			ID:      "1593264756",
+			Migrate: migration1593264756.Migrate,
+		},
+		{
+			ID:      "1593350572",
+			Migrate: migration1593350572.Migrate,
+		},
+		{
+			ID:      "1593437512",
+			Migrate: migration1593437512.Migrate,
+		},
+		{
+			ID:      "1593523904",
+			Migrate: migration1593523904.Migrate,
+		},
 	}
 }
 
@@ -0,0 +1,35 @@
+package migration1591603775
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the columns needed to express task specs as a graph, records
+// the parent edges of each task run, and adds the skipped run status for
+// tasks whose condition was not met.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE task_specs
+			ADD COLUMN name text,
+			ADD COLUMN depends_on text,
+			ADD COLUMN condition text;
+		ALTER TABLE task_runs ADD COLUMN parent_ids text;
+
+		-- Drop partial indexes and defaults which will otherwise cause cast to fail
+		DROP INDEX idx_job_runs_status;
+		DROP INDEX idx_task_runs_status;
+		ALTER TABLE job_runs ALTER COLUMN status SET DEFAULT NULL;
+		ALTER TABLE task_runs ALTER COLUMN status SET DEFAULT NULL;
+
+		ALTER TYPE run_status RENAME TO run_status_old;
+		CREATE TYPE run_status AS ENUM ('unstarted', 'in_progress', 'pending_incoming_confirmations', 'pending_outgoing_confirmations', 'pending_connection', 'pending_bridge', 'pending_sleep', 'errored', 'completed', 'cancelled', 'skipped');
+		ALTER TABLE job_runs ALTER COLUMN status TYPE run_status USING status::text::run_status;
+		ALTER TABLE task_runs ALTER COLUMN status TYPE run_status USING status::text::run_status;
+		DROP TYPE run_status_old;
+
+		CREATE INDEX idx_job_runs_status ON job_runs(status) WHERE status != 'completed'::run_status;
+		CREATE INDEX idx_task_runs_status ON task_runs(status) WHERE status != 'completed'::run_status;
+		ALTER TABLE job_runs ALTER COLUMN status SET DEFAULT 'unstarted';
+		ALTER TABLE task_runs ALTER COLUMN status SET DEFAULT 'unstarted';
+	`).Error
+}
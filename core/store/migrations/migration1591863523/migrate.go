@@ -0,0 +1,16 @@
+package migration1591863523
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the retry policy of each task spec, and records how often a
+// task run has been retried and when it will next be attempted.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE task_specs ADD COLUMN retry jsonb;
+		ALTER TABLE task_runs
+			ADD COLUMN retries bigint NOT NULL DEFAULT 0,
+			ADD COLUMN retry_at timestamp with time zone;
+	`).Error
+}
@@ -0,0 +1,25 @@
+package migration1591952146
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds a version to job specs and their tasks, so that a job can be
+// updated while runs of its earlier versions finish, and a table keeping a
+// snapshot of each version.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN version bigint NOT NULL DEFAULT 1;
+		ALTER TABLE task_specs ADD COLUMN job_spec_version bigint NOT NULL DEFAULT 1;
+		CREATE INDEX idx_task_specs_job_spec_id_job_spec_version ON task_specs (job_spec_id, job_spec_version);
+
+		CREATE TABLE "job_spec_versions" (
+			"id" bigserial primary key NOT NULL,
+			"job_spec_id" uuid REFERENCES job_specs(id) ON DELETE CASCADE NOT NULL,
+			"version" bigint NOT NULL,
+			"spec" text NOT NULL,
+			"created_at" timestamp with time zone NOT NULL
+		);
+		CREATE UNIQUE INDEX idx_job_spec_versions_job_spec_id_version ON job_spec_versions ("job_spec_id", "version");
+	`).Error
+}
@@ -0,0 +1,16 @@
+package migration1592212450
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the time zone, jitter and catch-up policy of cron initiators,
+// and the time they last fired.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN timezone text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN jitter bigint NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN catch_up text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN last_fired_at timestamp with time zone;
+	`).Error
+}
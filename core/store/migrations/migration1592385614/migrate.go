@@ -0,0 +1,15 @@
+package migration1592385614
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the tip and fee caps of EIP-1559 dynamic fee transactions.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE txes ADD COLUMN gas_tip_cap varchar(78);
+		ALTER TABLE txes ADD COLUMN gas_fee_cap varchar(78);
+		ALTER TABLE tx_attempts ADD COLUMN gas_tip_cap varchar(78);
+		ALTER TABLE tx_attempts ADD COLUMN gas_fee_cap varchar(78);
+	`).Error
+}
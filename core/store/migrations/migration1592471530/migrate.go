@@ -0,0 +1,14 @@
+package migration1592471530
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate records whether a transaction was cancelled or replaced by an
+// operator.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE txes ADD COLUMN cancelled boolean NOT NULL DEFAULT false;
+		ALTER TABLE txes ADD COLUMN replaced boolean NOT NULL DEFAULT false;
+	`).Error
+}
@@ -0,0 +1,18 @@
+package migration1592836612
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the wasm_modules table, holding the modules run by the wasm
+// adapter.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE TABLE wasm_modules (
+			name text PRIMARY KEY,
+			hash text NOT NULL,
+			wasm bytea NOT NULL,
+			created_at timestamp with time zone NOT NULL
+		);
+	`).Error
+}
@@ -0,0 +1,14 @@
+package migration1592918524
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the secret used to sign the requests to, and callbacks from,
+// bridges with signing enabled.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE bridge_types ADD COLUMN IF NOT EXISTS signing_enabled boolean NOT NULL DEFAULT false;
+		ALTER TABLE bridge_types ADD COLUMN IF NOT EXISTS signing_secret text NOT NULL DEFAULT '';
+	`).Error
+}
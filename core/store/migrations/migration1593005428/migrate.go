@@ -0,0 +1,15 @@
+package migration1593005428
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the health check URL, fallbacks and unhealthy behaviour of
+// bridges.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE bridge_types ADD COLUMN IF NOT EXISTS health_url text;
+		ALTER TABLE bridge_types ADD COLUMN IF NOT EXISTS fallbacks text NOT NULL DEFAULT '';
+		ALTER TABLE bridge_types ADD COLUMN IF NOT EXISTS pend_when_unhealthy boolean NOT NULL DEFAULT false;
+	`).Error
+}
@@ -0,0 +1,18 @@
+package migration1593091876
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the secrets table, holding values referenced by task params
+// encrypted with the node's password.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE TABLE secrets (
+			name text PRIMARY KEY,
+			encrypted_value text NOT NULL,
+			created_at timestamp with time zone NOT NULL,
+			updated_at timestamp with time zone NOT NULL
+		);
+	`).Error
+}
@@ -0,0 +1,26 @@
+package migration1593178312
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds a table recording the decisions of the flux monitor about
+// each round, so that one can explain why a round was or was not answered.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE TABLE flux_monitor_round_events (
+			id bigserial PRIMARY KEY,
+			job_spec_id uuid REFERENCES job_specs(id) ON DELETE CASCADE NOT NULL,
+			aggregator bytea NOT NULL,
+			round_id integer NOT NULL,
+			trigger text NOT NULL,
+			outcome text NOT NULL,
+			reason text NOT NULL DEFAULT '',
+			latest_answer numeric,
+			polled_answer numeric,
+			job_run_id uuid,
+			created_at timestamp with time zone NOT NULL
+		);
+		CREATE INDEX idx_flux_monitor_round_events_job_spec_id_created_at ON flux_monitor_round_events (job_spec_id, created_at);
+	`).Error
+}
@@ -0,0 +1,14 @@
+package migration1593264756
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the threshold schedule and heartbeat of flux monitor
+// initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "threshold_schedule" jsonb;
+		ALTER TABLE initiators ADD COLUMN "heartbeat" bigint NOT NULL DEFAULT 0;
+	`).Error
+}
@@ -0,0 +1,15 @@
+package migration1593350572
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the outlier guard of flux monitor initiators, and the operator
+// approvals of the answers it held.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "outlier_guard" jsonb;
+		ALTER TABLE flux_monitor_round_events ADD COLUMN "approved_at" timestamp with time zone;
+		ALTER TABLE flux_monitor_round_events ADD COLUMN "approval_used_at" timestamp with time zone;
+	`).Error
+}
@@ -0,0 +1,12 @@
+package migration1593437512
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the additional aggregators fed by flux monitor initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "aggregators" text NOT NULL DEFAULT '';
+	`).Error
+}
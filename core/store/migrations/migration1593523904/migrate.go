@@ -0,0 +1,13 @@
+package migration1593523904
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate indexes when sleeping task runs are to be retried, so that runs
+// due to be retried can be found without loading every sleeping run.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE INDEX idx_task_runs_retry_at ON task_runs (retry_at) WHERE status = 'pending_sleep';
+	`).Error
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BridgeTimestampHeader holds the unix time at which a signed bridge
+	// request or callback was sent.
+	BridgeTimestampHeader = "X-Chainlink-Timestamp"
+	// BridgeNonceHeader holds a random value unique to each signed bridge
+	// request or callback.
+	BridgeNonceHeader = "X-Chainlink-Nonce"
+	// BridgeSignatureHeader holds the hex encoded HMAC-SHA256 of the method,
+	// request URI, timestamp, nonce and body of a signed bridge request or
+	// callback.
+	BridgeSignatureHeader = "X-Chainlink-Signature"
+	// BridgeSignatureMaxAge is how old the timestamp of a signed callback can
+	// be, or how far in the future, before it is rejected as a replay.
+	BridgeSignatureMaxAge = 5 * time.Minute
+)
+
+// BridgeSignature returns the signature of a bridge request or callback:
+// the HMAC-SHA256 of "<method>\n<request URI>\n<timestamp>\n<nonce>\n<body>"
+// keyed with the bridge's signing secret. The request URI is the path and
+// query the request was sent to, such as "/v2/runs/<run ID>" for a callback,
+// so that a signature is only valid for the run it was made for.
+func BridgeSignature(secret, method, requestURI, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + timestamp + "\n" + nonce + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the signature headers of a request to the bridge, if
+// signing is enabled for it.
+func (bt BridgeType) SignRequest(request *http.Request, body []byte, now time.Time) {
+	if !bt.SigningEnabled {
+		return
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	nonce := utils.NewSecret(16)
+	signature := BridgeSignature(bt.SigningSecret, request.Method, request.URL.RequestURI(), timestamp, nonce, body)
+	request.Header.Set(BridgeTimestampHeader, timestamp)
+	request.Header.Set(BridgeNonceHeader, nonce)
+	request.Header.Set(BridgeSignatureHeader, signature)
+}
+
+// VerifySignature checks the signature headers of a callback from the
+// bridge, if signing is enabled for it, returning its nonce so that the
+// caller can reject the nonce if it is seen again.
+func (bt BridgeType) VerifySignature(request *http.Request, body []byte, now time.Time) (string, error) {
+	if !bt.SigningEnabled {
+		return "", nil
+	}
+	header := request.Header
+	timestamp := header.Get(BridgeTimestampHeader)
+	nonce := header.Get(BridgeNonceHeader)
+	signature := header.Get(BridgeSignatureHeader)
+	if timestamp == "" || nonce == "" || signature == "" {
+		return "", errors.New("bridge callback is not signed")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid bridge callback timestamp")
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > BridgeSignatureMaxAge || age < -BridgeSignatureMaxAge {
+		return "", errors.New("bridge callback timestamp is too old or in the future")
+	}
+
+	expected := BridgeSignature(bt.SigningSecret, request.Method, request.URL.RequestURI(), timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", errors.New("invalid bridge callback signature")
+	}
+	return nonce, nil
+}
@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBridgeRequest(t *testing.T, method, url string, body []byte) *http.Request {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	return request
+}
+
+func TestBridgeType_SignRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1592918524, 0)
+	body := []byte(`{"id":"1","data":{"result":"100"}}`)
+
+	request := newBridgeRequest(t, "POST", "https://bridge.example.com/price?coin=eth", body)
+	models.BridgeType{}.SignRequest(request, body, now)
+	assert.Empty(t, request.Header, "should not sign when signing is disabled")
+
+	bt := models.BridgeType{SigningEnabled: true, SigningSecret: "secret"}
+	bt.SignRequest(request, body, now)
+	assert.Equal(t, "1592918524", request.Header.Get(models.BridgeTimestampHeader))
+	nonce := request.Header.Get(models.BridgeNonceHeader)
+	assert.NotEmpty(t, nonce)
+	assert.Equal(t,
+		models.BridgeSignature("secret", "POST", "/price?coin=eth", "1592918524", nonce, body),
+		request.Header.Get(models.BridgeSignatureHeader))
+
+	other := newBridgeRequest(t, "POST", "https://bridge.example.com/price?coin=eth", body)
+	bt.SignRequest(other, body, now)
+	assert.NotEqual(t, nonce, other.Header.Get(models.BridgeNonceHeader), "nonces should be unique")
+}
+
+func TestBridgeType_VerifySignature(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1592918524, 0)
+	body := []byte(`{"id":"1","data":{"result":"100"}}`)
+	bt := models.BridgeType{SigningEnabled: true, SigningSecret: "secret"}
+
+	signed := func(bt models.BridgeType, method, url string, at time.Time) http.Header {
+		request := newBridgeRequest(t, method, url, body)
+		bt.SignRequest(request, body, at)
+		return request.Header
+	}
+	callbackURL := "http://localhost:6688/v2/runs/1"
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		body      string
+		wantError bool
+	}{
+		{"valid", signed(bt, "PATCH", callbackURL, now), string(body), false},
+		{"recent", signed(bt, "PATCH", callbackURL, now.Add(-time.Minute)), string(body), false},
+		{"unsigned", http.Header{}, string(body), true},
+		{"tampered body", signed(bt, "PATCH", callbackURL, now), `{"id":"1","data":{"result":"999"}}`, true},
+		{"wrong secret", signed(models.BridgeType{SigningEnabled: true, SigningSecret: "other"}, "PATCH", callbackURL, now), string(body), true},
+		{"other method", signed(bt, "POST", callbackURL, now), string(body), true},
+		{"other run", signed(bt, "PATCH", "http://localhost:6688/v2/runs/2", now), string(body), true},
+		{"too old", signed(bt, "PATCH", callbackURL, now.Add(-10*time.Minute)), string(body), true},
+		{"in the future", signed(bt, "PATCH", callbackURL, now.Add(10*time.Minute)), string(body), true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			request := newBridgeRequest(t, "PATCH", callbackURL, []byte(test.body))
+			request.Header = test.header
+			nonce, err := bt.VerifySignature(request, []byte(test.body), now)
+			if test.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.header.Get(models.BridgeNonceHeader), nonce)
+		})
+	}
+
+	request := newBridgeRequest(t, "PATCH", callbackURL, body)
+	nonce, err := models.BridgeType{}.VerifySignature(request, body, now)
+	assert.NoError(t, err, "should accept unsigned callbacks when signing is disabled")
+	assert.Empty(t, nonce)
+}
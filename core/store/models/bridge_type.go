@@ -2,7 +2,9 @@ package models
 
 import (
 	"crypto/subtle"
+	"database/sql/driver"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
@@ -15,6 +17,11 @@ type BridgeTypeRequest struct {
 	URL                    WebURL       `json:"url"`
 	Confirmations          uint32       `json:"confirmations"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	SigningEnabled         bool         `json:"signingEnabled"`
+	HealthURL              *WebURL      `json:"healthURL,omitempty"`
+	Fallbacks              TaskTypes    `json:"fallbacks"`
+	PendWhenUnhealthy      bool         `json:"pendWhenUnhealthy"`
+	RotateSigningSecret    bool         `json:"rotateSigningSecret"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -42,6 +49,11 @@ type BridgeTypeAuthentication struct {
 	IncomingToken          string       `json:"incomingToken"`
 	OutgoingToken          string       `json:"outgoingToken"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	SigningEnabled         bool         `json:"signingEnabled"`
+	SigningSecret          string       `json:"signingSecret,omitempty"`
+	HealthURL              *WebURL      `json:"healthURL,omitempty"`
+	Fallbacks              TaskTypes    `json:"fallbacks"`
+	PendWhenUnhealthy      bool         `json:"pendWhenUnhealthy"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -71,6 +83,11 @@ type BridgeType struct {
 	Salt                   string       `json:"-"`
 	OutgoingToken          string       `json:"outgoingToken"`
 	MinimumContractPayment *assets.Link `json:"minimumContractPayment" gorm:"type:varchar(255)"`
+	SigningEnabled         bool         `json:"signingEnabled"`
+	SigningSecret          string       `json:"-"`
+	HealthURL              *WebURL      `json:"healthURL,omitempty"`
+	Fallbacks              TaskTypes    `json:"fallbacks" gorm:"type:text"`
+	PendWhenUnhealthy      bool         `json:"pendWhenUnhealthy"`
 	CreatedAt              time.Time    `json:"-"`
 	UpdatedAt              time.Time    `json:"-"`
 }
@@ -104,6 +121,10 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 	if err != nil {
 		return nil, nil, err
 	}
+	var signingSecret string
+	if btr.SigningEnabled {
+		signingSecret = utils.NewSecret(32)
+	}
 
 	return &BridgeTypeAuthentication{
 			Name:                   btr.Name,
@@ -112,6 +133,11 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 			IncomingToken:          incomingToken,
 			OutgoingToken:          outgoingToken,
 			MinimumContractPayment: btr.MinimumContractPayment,
+			SigningEnabled:         btr.SigningEnabled,
+			SigningSecret:          signingSecret,
+			HealthURL:              btr.HealthURL,
+			Fallbacks:              btr.Fallbacks,
+			PendWhenUnhealthy:      btr.PendWhenUnhealthy,
 		}, &BridgeType{
 			Name:                   btr.Name,
 			URL:                    btr.URL,
@@ -120,6 +146,11 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 			Salt:                   salt,
 			OutgoingToken:          outgoingToken,
 			MinimumContractPayment: btr.MinimumContractPayment,
+			SigningEnabled:         btr.SigningEnabled,
+			SigningSecret:          signingSecret,
+			HealthURL:              btr.HealthURL,
+			Fallbacks:              btr.Fallbacks,
+			PendWhenUnhealthy:      btr.PendWhenUnhealthy,
 		}, nil
 }
 
@@ -141,3 +172,41 @@ func incomingTokenHash(token, salt string) (string, error) {
 	}
 	return hash, nil
 }
+
+// TaskTypes is an ordered list of task types, such as the fallbacks of a
+// bridge, serializable to and from a database.
+type TaskTypes []TaskType
+
+// Value returns the task types joined by commas for the database.
+func (t TaskTypes) Value() (driver.Value, error) {
+	strs := make([]string, len(t))
+	for i, taskType := range t {
+		strs[i] = taskType.String()
+	}
+	return strings.Join(strs, ","), nil
+}
+
+// Scan parses the task types from the database.
+func (t *TaskTypes) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unable to convert %v of %T to TaskTypes", value, value)
+	}
+
+	*t = nil
+	if len(str) == 0 {
+		return nil
+	}
+	for _, s := range strings.Split(str, ",") {
+		taskType, err := NewTaskType(s)
+		if err != nil {
+			return err
+		}
+		*t = append(*t, taskType)
+	}
+	return nil
+}
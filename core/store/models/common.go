@@ -57,6 +57,9 @@ const (
 	RunStatusCompleted = RunStatus("completed")
 	// RunStatusCancelled is used to indicate a run is no longer desired.
 	RunStatusCancelled = RunStatus("cancelled")
+	// RunStatusSkipped is used for a task run that will not be performed
+	// because its condition was not met or an upstream task was skipped.
+	RunStatusSkipped = RunStatus("skipped")
 )
 
 // Unstarted returns true if the status is the initial state.
@@ -104,6 +107,11 @@ func (s RunStatus) Errored() bool {
 	return s == RunStatusErrored
 }
 
+// Skipped returns true if the status is RunStatusSkipped.
+func (s RunStatus) Skipped() bool {
+	return s == RunStatusSkipped
+}
+
 // Pending returns true if the status is pending external or confirmations.
 func (s RunStatus) Pending() bool {
 	return s.PendingBridge() || s.PendingIncomingConfirmations() || s.PendingOutgoingConfirmations() || s.PendingSleep() || s.PendingConnection()
@@ -111,7 +119,7 @@ func (s RunStatus) Pending() bool {
 
 // Finished returns true if the status is final and can't be changed.
 func (s RunStatus) Finished() bool {
-	return s.Completed() || s.Errored() || s.Cancelled()
+	return s.Completed() || s.Errored() || s.Cancelled() || s.Skipped()
 }
 
 // Runnable returns true if the status is ready to be run.
@@ -428,10 +436,6 @@ func (c *Cron) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
-	if !strings.HasPrefix(s, "CRON_TZ=") {
-		return errors.New("Cron: specs must specify a time zone using CRON_TZ, e.g. 'CRON_TZ=UTC 5 * * * *'")
-	}
-
 	_, err = CronParser.Parse(s)
 	if err != nil {
 		return fmt.Errorf("Cron: %v", err)
@@ -445,6 +449,20 @@ func (c Cron) String() string {
 	return string(c)
 }
 
+// HasTimeZone returns true if the spec specifies a time zone using CRON_TZ.
+func (c Cron) HasTimeZone() bool {
+	return strings.HasPrefix(string(c), "CRON_TZ=")
+}
+
+// InTimeZone returns the spec in the given IANA time zone, unless it
+// already specifies one.
+func (c Cron) InTimeZone(timezone string) Cron {
+	if timezone == "" || c.HasTimeZone() {
+		return c
+	}
+	return Cron(fmt.Sprintf("CRON_TZ=%s %s", timezone, c))
+}
+
 // Duration is a non-negative time duration.
 type Duration struct{ d time.Duration }
 
@@ -368,6 +368,7 @@ func TestCron_UnmarshalJSON_Success(t *testing.T) {
 	}{
 		{"valid 5-field cron", `"CRON_TZ=UTC 0 0/5 * * *"`},
 		{"valid 6-field cron", `"CRON_TZ=UTC 30 0 0/5 * * *"`},
+		{"5-field cron without time zone", `"0 0/5 * * *"`},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -378,6 +379,14 @@ func TestCron_UnmarshalJSON_Success(t *testing.T) {
 	}
 }
 
+func TestCron_InTimeZone(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, models.Cron("CRON_TZ=Europe/Paris 0 9 * * *"), models.Cron("0 9 * * *").InTimeZone("Europe/Paris"))
+	assert.Equal(t, models.Cron("CRON_TZ=UTC 0 9 * * *"), models.Cron("CRON_TZ=UTC 0 9 * * *").InTimeZone("Europe/Paris"))
+	assert.Equal(t, models.Cron("0 9 * * *"), models.Cron("0 9 * * *").InTimeZone(""))
+}
+
 func TestCron_UnmarshalJSON_Invalid(t *testing.T) {
 	t.Parallel()
 
@@ -386,7 +395,8 @@ func TestCron_UnmarshalJSON_Invalid(t *testing.T) {
 		input     string
 		wantError string
 	}{
-		{"5-field cron without time zone", `"0 0/5 * * *"`, "Cron: specs must specify a time zone using CRON_TZ, e.g. 'CRON_TZ=UTC 5 * * * *'"},
+		{"too few fields", `"0 0/5 *"`, "Cron: expected 5 to 6 fields, found 3: [0 0/5 *]"},
+		{"unknown time zone", `"CRON_TZ=Nowhere/Special 0 0/5 * * *"`, "Cron: provided bad location Nowhere/Special: unknown time zone Nowhere/Special"},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -5,9 +5,11 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	null "gopkg.in/guregu/null.v3"
 )
@@ -34,11 +36,18 @@ type Tx struct {
 	// TxAttempt fields manually included; can't embed another primary_key
 	Hash        common.Hash `gorm:"not null"`
 	GasPrice    *utils.Big  `gorm:"not null"`
+	GasTipCap   *utils.Big  `gorm:"type:varchar(78)"`
+	GasFeeCap   *utils.Big  `gorm:"type:varchar(78)"`
 	Confirmed   bool        `gorm:"not null"`
 	SentAt      uint64      `gorm:"not null"`
 	SignedRawTx []byte      `gorm:"not null"`
 	CreatedAt   time.Time   `json:"-"`
 	UpdatedAt   time.Time   `json:"-"`
+
+	// Cancelled and Replaced record whether an operator cancelled the
+	// transaction, or replaced its payload, while it was pending.
+	Cancelled bool `gorm:"not null"`
+	Replaced  bool `gorm:"not null"`
 }
 
 // String implements Stringer for Tx
@@ -64,6 +73,25 @@ func (tx Tx) EthTx(gasPriceWei *big.Int) *types.Transaction {
 	)
 }
 
+// CancelTxRequest represents a request to cancel a pending transaction.
+// GasPrice is optional, and defaults to the bumped gas price of its latest
+// attempt.
+type CancelTxRequest struct {
+	GasPrice *utils.Big `json:"gasPrice"`
+}
+
+// ReplaceTxRequest represents a request to replace the payload of a pending
+// transaction. Fields which are not set keep the values of the original
+// transaction, and GasPrice defaults to the bumped gas price of its latest
+// attempt.
+type ReplaceTxRequest struct {
+	To       *common.Address `json:"to"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Value    *assets.Eth     `json:"value"`
+	GasLimit uint64          `json:"gasLimit"`
+	GasPrice *utils.Big      `json:"gasPrice"`
+}
+
 // TxAttempt is used for keeping track of transactions that
 // have been written to the Ethereum blockchain. This makes
 // it so that if the network is busy, a transaction can be
@@ -82,6 +110,11 @@ type TxAttempt struct {
 	SentAt      uint64      `gorm:"not null"`
 	SignedRawTx []byte      `gorm:"not null"`
 	UpdatedAt   time.Time   `json:"-"`
+
+	// GasTipCap and GasFeeCap are only set for dynamic fee transactions,
+	// in which case GasPrice is their fee cap.
+	GasTipCap *utils.Big `gorm:"type:varchar(78)"`
+	GasFeeCap *utils.Big `gorm:"type:varchar(78)"`
 }
 
 // String implements Stringer for TxAttempt
@@ -94,6 +127,11 @@ func (txa *TxAttempt) String() string {
 		txa.Confirmed)
 }
 
+// IsDynamicFee returns true if the attempt is an EIP-1559 transaction.
+func (txa *TxAttempt) IsDynamicFee() bool {
+	return txa.GasTipCap != nil
+}
+
 // GetID returns the ID of this structure for jsonapi serialization.
 func (txa TxAttempt) GetID() string {
 	return txa.Hash.Hex()
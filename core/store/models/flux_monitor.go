@@ -1,7 +1,17 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	null "gopkg.in/guregu/null.v3"
 )
 
 type FluxMonitorRoundStats struct {
@@ -11,3 +21,345 @@ type FluxMonitorRoundStats struct {
 	NumNewRoundLogs uint64         `gorm:"not null;default 0"`
 	NumSubmissions  uint64         `gorm:"not null;default 0"`
 }
+
+// FluxMonitorTrigger is what made the flux monitor check a round.
+type FluxMonitorTrigger string
+
+const (
+	// FluxMonitorTriggerPoll is a poll of the feeds on the poll timer, or
+	// when the job is started.
+	FluxMonitorTriggerPoll = FluxMonitorTrigger("poll")
+	// FluxMonitorTriggerIdle is a poll when the idle timer fires.
+	FluxMonitorTriggerIdle = FluxMonitorTrigger("idle")
+	// FluxMonitorTriggerRoundTimeout is a poll when the current round times
+	// out.
+	FluxMonitorTriggerRoundTimeout = FluxMonitorTrigger("round_timeout")
+	// FluxMonitorTriggerNewRound is a NewRound log started by another node.
+	FluxMonitorTriggerNewRound = FluxMonitorTrigger("new_round")
+	// FluxMonitorTriggerHeartbeat is a poll when the node has not submitted
+	// an answer for the heartbeat period of the job.
+	FluxMonitorTriggerHeartbeat = FluxMonitorTrigger("heartbeat")
+)
+
+// FluxMonitorOutcome is what the flux monitor decided to do about a round.
+type FluxMonitorOutcome string
+
+const (
+	// FluxMonitorOutcomeSubmitted is a job run submitting an answer.
+	FluxMonitorOutcomeSubmitted = FluxMonitorOutcome("submitted")
+	// FluxMonitorOutcomeBelowThreshold is a poll whose answer did not
+	// deviate enough from the latest answer to start a round.
+	FluxMonitorOutcomeBelowThreshold = FluxMonitorOutcome("below_threshold")
+	// FluxMonitorOutcomeSkipped is a round the node did not answer, such as
+	// one it is not eligible for or would not be paid enough for.
+	FluxMonitorOutcomeSkipped = FluxMonitorOutcome("skipped")
+	// FluxMonitorOutcomeErrored is a round the node could not answer, such
+	// as when its feeds could not be fetched.
+	FluxMonitorOutcomeErrored = FluxMonitorOutcome("errored")
+	// FluxMonitorOutcomeHeld is an answer the outlier guard refused to submit
+	// for jumping too far from the latest answer, until an operator approves
+	// it.
+	FluxMonitorOutcomeHeld = FluxMonitorOutcome("held")
+)
+
+// FluxMonitorRoundEvent records a decision of the flux monitor about a
+// round, so that one can explain why the node did or did not answer it.
+type FluxMonitorRoundEvent struct {
+	ID           int64               `json:"-" gorm:"primary_key;auto_increment"`
+	JobSpecID    *ID                 `json:"jobSpecId" gorm:"not null"`
+	Aggregator   common.Address      `json:"aggregator" gorm:"not null"`
+	RoundID      uint32              `json:"roundId" gorm:"not null"`
+	Trigger      FluxMonitorTrigger  `json:"trigger" gorm:"not null"`
+	Outcome      FluxMonitorOutcome  `json:"outcome" gorm:"not null"`
+	Reason       string              `json:"reason,omitempty"`
+	LatestAnswer decimal.NullDecimal `json:"latestAnswer"`
+	PolledAnswer decimal.NullDecimal `json:"polledAnswer"`
+	JobRunID     *ID                 `json:"jobRunId,omitempty"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	// ApprovedAt is when an operator approved a held answer, letting the
+	// next answer close to it through the circuit breaker of the outlier
+	// guard, and ApprovalUsedAt when that answer was submitted.
+	ApprovedAt     null.Time `json:"approvedAt"`
+	ApprovalUsedAt null.Time `json:"approvalUsedAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (e FluxMonitorRoundEvent) GetID() string {
+	return strconv.FormatInt(e.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (e FluxMonitorRoundEvent) GetName() string {
+	return "fluxRounds"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (e *FluxMonitorRoundEvent) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	e.ID = id
+	return nil
+}
+
+// ThresholdWindow is a period of the week during which the flux monitor uses
+// different deviation thresholds, such as the trading hours of a market.
+//
+// Start and End are times of day formatted as "15:04" in the Timezone, or UTC
+// if it is empty. An empty Start is midnight, and an empty End is the end of
+// the day, so that a window without either covers whole days. A window whose
+// End is before its Start runs overnight, until End on the next day. Days
+// holds the names of the days of the week the window starts on, such as "mon"
+// or "monday", or every day if it is empty.
+type ThresholdWindow struct {
+	Days              []string `json:"days,omitempty"`
+	Start             string   `json:"start,omitempty"`
+	End               string   `json:"end,omitempty"`
+	Timezone          string   `json:"timezone,omitempty"`
+	Threshold         float32  `json:"threshold"`
+	AbsoluteThreshold float32  `json:"absoluteThreshold"`
+}
+
+// Validate returns an error if the window cannot be evaluated, or its
+// thresholds are invalid.
+func (w ThresholdWindow) Validate() error {
+	if _, err := w.parse(); err != nil {
+		return err
+	}
+	if w.Threshold <= 0 {
+		return errors.New("threshold must be positive")
+	}
+	if w.AbsoluteThreshold < 0 {
+		return errors.New("absoluteThreshold must be nonnegative")
+	}
+	return nil
+}
+
+// Contains returns true if the time falls within the window. Invalid windows
+// contain no time.
+func (w ThresholdWindow) Contains(t time.Time) bool {
+	parsed, err := w.parse()
+	if err != nil {
+		return false
+	}
+	return parsed.contains(t)
+}
+
+// parsedThresholdWindow holds the days, times and time zone of a window
+// parsed, so that they are not parsed again for every time it is checked
+// against.
+type parsedThresholdWindow struct {
+	window   *ThresholdWindow
+	location *time.Location
+	weekdays map[time.Weekday]bool
+	start    int
+	end      int
+}
+
+func (w ThresholdWindow) parse() (parsedThresholdWindow, error) {
+	weekdays, err := w.weekdays()
+	if err != nil {
+		return parsedThresholdWindow{}, err
+	}
+	location, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return parsedThresholdWindow{}, errors.Wrapf(err, "invalid timezone %s", w.Timezone)
+	}
+	start, end, err := w.minutes()
+	if err != nil {
+		return parsedThresholdWindow{}, err
+	}
+	if start == end {
+		return parsedThresholdWindow{}, fmt.Errorf("start %s must not be the same as end %s", w.Start, w.End)
+	}
+	return parsedThresholdWindow{
+		window:   &w,
+		location: location,
+		weekdays: weekdays,
+		start:    start,
+		end:      end,
+	}, nil
+}
+
+// contains returns true if the time falls within the window. The part of an
+// overnight window after midnight belongs to the day it started on.
+func (pw parsedThresholdWindow) contains(t time.Time) bool {
+	t = t.In(pw.location)
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if pw.start < pw.end {
+		if minute < pw.start || minute >= pw.end {
+			return false
+		}
+	} else if minute < pw.end {
+		day = (day + 6) % 7
+	} else if minute < pw.start {
+		return false
+	}
+	return len(pw.weekdays) == 0 || pw.weekdays[day]
+}
+
+func (w ThresholdWindow) weekdays() (map[time.Weekday]bool, error) {
+	weekdays := make(map[time.Weekday]bool)
+	for _, day := range w.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return nil, fmt.Errorf("invalid day %s", day)
+		}
+		weekdays[weekday] = true
+	}
+	return weekdays, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(day)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if day == name || day == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// minutes returns the start and end of the window in minutes since midnight.
+func (w ThresholdWindow) minutes() (int, int, error) {
+	start, err := parseTimeOfDay(w.Start, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseTimeOfDay(w.End, 24*60)
+	return start, end, err
+}
+
+func parseTimeOfDay(value string, empty int) (int, error) {
+	switch value {
+	case "":
+		return empty, nil
+	case "24:00":
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %s, must be formatted as 15:04", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ThresholdSchedule holds the windows during which a flux monitor job uses
+// thresholds other than those of its initiator. The first window containing
+// the time of a poll applies.
+type ThresholdSchedule []ThresholdWindow
+
+// Validate returns an error if any window of the schedule is invalid.
+func (ts ThresholdSchedule) Validate() error {
+	for i, window := range ts {
+		if err := window.Validate(); err != nil {
+			return errors.Wrapf(err, "window %d", i)
+		}
+	}
+	return nil
+}
+
+// Window returns the first window containing the time, or nil if none does.
+func (ts ThresholdSchedule) Window(t time.Time) *ThresholdWindow {
+	for i := range ts {
+		if ts[i].Contains(t) {
+			return &ts[i]
+		}
+	}
+	return nil
+}
+
+// Parse returns the schedule with the days, times and time zones of its
+// windows parsed, to be checked against the time of every poll.
+func (ts ThresholdSchedule) Parse() (ParsedThresholdSchedule, error) {
+	parsed := make(ParsedThresholdSchedule, len(ts))
+	for i, window := range ts {
+		pw, err := window.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "window %d", i)
+		}
+		parsed[i] = pw
+	}
+	return parsed, nil
+}
+
+// ParsedThresholdSchedule is a ThresholdSchedule whose windows have been
+// parsed.
+type ParsedThresholdSchedule []parsedThresholdWindow
+
+// Window returns the first window containing the time, or nil if none does.
+func (ps ParsedThresholdSchedule) Window(t time.Time) *ThresholdWindow {
+	for _, pw := range ps {
+		if pw.contains(t) {
+			return pw.window
+		}
+	}
+	return nil
+}
+
+// Value is defined so that we can store ThresholdSchedule as JSONB.
+func (ts ThresholdSchedule) Value() (driver.Value, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	return json.Marshal(ts)
+}
+
+// Scan is defined so that we can read ThresholdSchedule as JSONB.
+func (ts *ThresholdSchedule) Scan(value interface{}) error {
+	if value == nil {
+		*ts = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid Scan Source")
+	}
+	return json.Unmarshal(b, ts)
+}
+
+// OutlierGuardConfig holds the sanity checks of the answers of a flux monitor
+// job before they are submitted. Answers outside of MinAnswer and MaxAnswer
+// are never submitted, while answers which deviate from the latest answer by
+// more than CircuitBreaker percent are held until an operator approves them.
+type OutlierGuardConfig struct {
+	MinAnswer      *decimal.Decimal `json:"minAnswer,omitempty"`
+	MaxAnswer      *decimal.Decimal `json:"maxAnswer,omitempty"`
+	CircuitBreaker float32          `json:"circuitBreaker,omitempty"`
+}
+
+// Validate returns an error if the band or circuit breaker are invalid.
+func (og OutlierGuardConfig) Validate() error {
+	if og.CircuitBreaker < 0 {
+		return errors.New("circuitBreaker must be nonnegative")
+	}
+	if og.MinAnswer != nil && og.MaxAnswer != nil && !og.MinAnswer.LessThan(*og.MaxAnswer) {
+		return fmt.Errorf("minAnswer %s must be less than maxAnswer %s", og.MinAnswer, og.MaxAnswer)
+	}
+	return nil
+}
+
+// Value is defined so that we can store OutlierGuardConfig as JSONB, because
+// of an error with GORM where it has trouble with nested structs as JSONB.
+// See https://github.com/jinzhu/gorm/issues/2704
+func (og OutlierGuardConfig) Value() (driver.Value, error) {
+	return json.Marshal(og)
+}
+
+// Scan is defined so that we can read OutlierGuardConfig as JSONB, because
+// of an error with GORM where it has trouble with nested structs as JSONB.
+// See https://github.com/jinzhu/gorm/issues/2704
+func (og *OutlierGuardConfig) Scan(value interface{}) error {
+	if value == nil {
+		*og = OutlierGuardConfig{}
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid Scan Source")
+	}
+	return json.Unmarshal(b, og)
+}
@@ -0,0 +1,124 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdSchedule_Window(t *testing.T) {
+	t.Parallel()
+
+	schedule := models.ThresholdSchedule{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:30", End: "16:00", Timezone: "America/New_York", Threshold: 0.1},
+		{Days: []string{"fri"}, Start: "22:00", End: "02:00", Threshold: 3},
+		{Days: []string{"Saturday", "Sunday"}, Threshold: 2},
+	}
+	require.NoError(t, schedule.Validate())
+	parsed, err := schedule.Parse()
+	require.NoError(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		time          time.Time
+		wantThreshold float32
+	}{
+		{"trading hours", time.Date(2020, 6, 26, 10, 0, 0, 0, newYork), 0.1},
+		{"trading hours in UTC", time.Date(2020, 6, 26, 19, 59, 0, 0, time.UTC), 0.1},
+		{"market open", time.Date(2020, 6, 26, 9, 30, 0, 0, newYork), 0.1},
+		{"market close", time.Date(2020, 6, 26, 16, 0, 0, 0, newYork), 0},
+		{"before market open", time.Date(2020, 6, 26, 9, 29, 0, 0, newYork), 0},
+		{"friday night", time.Date(2020, 6, 26, 22, 0, 0, 0, time.UTC), 3},
+		{"after midnight on friday night", time.Date(2020, 6, 27, 1, 59, 0, 0, time.UTC), 3},
+		{"saturday", time.Date(2020, 6, 27, 2, 0, 0, 0, time.UTC), 2},
+		{"saturday night", time.Date(2020, 6, 27, 22, 0, 0, 0, time.UTC), 2},
+		{"sunday night", time.Date(2020, 6, 28, 23, 59, 0, 0, time.UTC), 2},
+		{"after midnight on thursday night", time.Date(2020, 6, 26, 1, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for _, window := range []*models.ThresholdWindow{schedule.Window(test.time), parsed.Window(test.time)} {
+				if test.wantThreshold == 0 {
+					assert.Nil(t, window)
+				} else {
+					require.NotNil(t, window)
+					assert.Equal(t, test.wantThreshold, window.Threshold)
+				}
+			}
+		})
+	}
+}
+
+func TestThresholdWindow_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		window    models.ThresholdWindow
+		wantError string
+	}{
+		{"whole day", models.ThresholdWindow{Threshold: 1}, ""},
+		{"until midnight", models.ThresholdWindow{Start: "18:00", End: "24:00", Threshold: 1}, ""},
+		{"invalid day", models.ThresholdWindow{Days: []string{"someday"}, Threshold: 1}, "invalid day someday"},
+		{"invalid start", models.ThresholdWindow{Start: "9am", Threshold: 1}, "invalid time of day 9am"},
+		{"overnight", models.ThresholdWindow{Start: "16:00", End: "09:30", Threshold: 1}, ""},
+		{"empty", models.ThresholdWindow{Start: "09:30", End: "09:30", Threshold: 1}, "start 09:30 must not be the same as end 09:30"},
+		{"invalid timezone", models.ThresholdWindow{Timezone: "Mars/Olympus_Mons", Threshold: 1}, "invalid timezone Mars/Olympus_Mons"},
+		{"no threshold", models.ThresholdWindow{}, "threshold must be positive"},
+		{"negative absolute threshold", models.ThresholdWindow{Threshold: 1, AbsoluteThreshold: -1}, "absoluteThreshold must be nonnegative"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.window.Validate()
+			if test.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantError)
+			}
+		})
+	}
+}
+
+func TestOutlierGuardConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	one, two := decimal.NewFromInt(1), decimal.NewFromInt(2)
+	tests := []struct {
+		name      string
+		guard     models.OutlierGuardConfig
+		wantError string
+	}{
+		{"empty", models.OutlierGuardConfig{}, ""},
+		{"band and circuit breaker", models.OutlierGuardConfig{MinAnswer: &one, MaxAnswer: &two, CircuitBreaker: 20}, ""},
+		{"only minAnswer", models.OutlierGuardConfig{MinAnswer: &two}, ""},
+		{"negative circuit breaker", models.OutlierGuardConfig{CircuitBreaker: -1}, "circuitBreaker must be nonnegative"},
+		{"inverted band", models.OutlierGuardConfig{MinAnswer: &two, MaxAnswer: &one}, "minAnswer 2 must be less than maxAnswer 1"},
+		{"empty band", models.OutlierGuardConfig{MinAnswer: &one, MaxAnswer: &one}, "minAnswer 1 must be less than maxAnswer 1"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.guard.Validate()
+			if test.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, test.wantError, err.Error())
+			}
+		})
+	}
+}
@@ -72,6 +72,17 @@ func MakeJobRun(job *JobSpec, now time.Time, initiator *Initiator, currentHeight
 		}
 	}
 	run.SetStatus(RunStatusInProgress)
+
+	deps, err := job.TaskDependencies()
+	if err != nil {
+		run.SetError(err)
+		return run
+	}
+	for i, parents := range deps {
+		for _, p := range parents {
+			run.TaskRuns[i].ParentIDs = append(run.TaskRuns[i].ParentIDs, *run.TaskRuns[p].ID)
+		}
+	}
 	return run
 }
 
@@ -145,8 +156,15 @@ func (jr JobRun) HasError() bool {
 	return jr.Status.Errored()
 }
 
-// NextTaskRunIndex returns the position of the next unfinished task
+// NextTaskRunIndex returns the position of the next unfinished task. Tasks
+// that are pending take precedence over unstarted ones, since a graph job
+// may have unstarted tasks ahead of the task that paused the run.
 func (jr *JobRun) NextTaskRunIndex() (int, bool) {
+	for index, tr := range jr.TaskRuns {
+		if tr.Status.Pending() {
+			return index, true
+		}
+	}
 	for index, tr := range jr.TaskRuns {
 		if tr.Status.CanStart() {
 			return index, true
@@ -165,6 +183,18 @@ func (jr *JobRun) NextTaskRun() *TaskRun {
 	return nil
 }
 
+// RetryAt returns the earliest time at which one of the run's sleeping
+// tasks is due to be attempted again.
+func (jr *JobRun) RetryAt() (time.Time, bool) {
+	var retryAt time.Time
+	for _, tr := range jr.TaskRuns {
+		if tr.Status.PendingSleep() && tr.RetryAt.Valid && (retryAt.IsZero() || tr.RetryAt.Time.Before(retryAt)) {
+			retryAt = tr.RetryAt.Time
+		}
+	}
+	return retryAt, !retryAt.IsZero()
+}
+
 // PreviousTaskRun returns the last task to be processed, if it exists
 func (jr *JobRun) PreviousTaskRun() *TaskRun {
 	index, runnable := jr.NextTaskRunIndex()
@@ -174,6 +204,104 @@ func (jr *JobRun) PreviousTaskRun() *TaskRun {
 	return nil
 }
 
+// ParentTaskRuns returns the TaskRuns whose output feeds into the TaskRun at
+// the given index. Runs created before task dependencies were recorded have
+// no parent edges, and are treated as a list where each task's parent is the
+// one before it.
+func (jr *JobRun) ParentTaskRuns(index int) []*TaskRun {
+	if !jr.hasParentEdges() {
+		if index > 0 {
+			return []*TaskRun{&jr.TaskRuns[index-1]}
+		}
+		return nil
+	}
+
+	var parents []*TaskRun
+	for _, parentID := range jr.TaskRuns[index].ParentIDs {
+		for i := range jr.TaskRuns {
+			if *jr.TaskRuns[i].ID == parentID {
+				parents = append(parents, &jr.TaskRuns[i])
+			}
+		}
+	}
+	return parents
+}
+
+func (jr *JobRun) hasParentEdges() bool {
+	for _, tr := range jr.TaskRuns {
+		if len(tr.ParentIDs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RunnableTaskRunIndexes returns the positions of the tasks that can start
+// because all of their parents have completed. Unstarted tasks that can never
+// run, either because a parent was skipped or because their condition was not
+// met, are marked as skipped along the way.
+func (jr *JobRun) RunnableTaskRunIndexes() []int {
+	for jr.skipUnreachableTaskRuns() {
+	}
+
+	var runnable []int
+	for index, tr := range jr.TaskRuns {
+		if tr.Status.Finished() || tr.Status.PendingBridge() {
+			continue
+		}
+		if jr.parentsCompleted(index) {
+			runnable = append(runnable, index)
+		}
+	}
+	return runnable
+}
+
+// skipUnreachableTaskRuns marks unstarted tasks as skipped if one of their
+// parents was skipped, or if their parents have completed and their
+// condition was not met. It returns true if any task was marked.
+func (jr *JobRun) skipUnreachableTaskRuns() bool {
+	marked := false
+	for index := range jr.TaskRuns {
+		tr := &jr.TaskRuns[index]
+		if !tr.Status.Unstarted() {
+			continue
+		}
+
+		skip := false
+		for _, parent := range jr.ParentTaskRuns(index) {
+			skip = skip || parent.Status.Skipped()
+		}
+		if !skip && tr.TaskSpec.Condition != "" && jr.parentsCompleted(index) {
+			parent := jr.parentNamed(index, tr.TaskSpec.Condition)
+			skip = parent != nil && !conditionMet(*parent)
+		}
+
+		if skip {
+			tr.Status = RunStatusSkipped
+			marked = true
+		}
+	}
+	return marked
+}
+
+func (jr *JobRun) parentsCompleted(index int) bool {
+	for _, parent := range jr.ParentTaskRuns(index) {
+		if !parent.Status.Completed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (jr *JobRun) parentNamed(index int, name string) *TaskRun {
+	for _, parent := range jr.ParentTaskRuns(index) {
+		if parent.TaskSpec.Name == name {
+			return parent
+		}
+	}
+	return nil
+}
+
 // TasksRemain returns true if there are unfinished tasks left for this job run
 func (jr *JobRun) TasksRemain() bool {
 	_, runnable := jr.NextTaskRunIndex()
@@ -248,6 +376,9 @@ type TaskRun struct {
 	TaskSpecID                       int64         `json:"-"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"minimumConfirmations" gorm:"column:minimum_confirmations"`
 	ObservedIncomingConfirmations    clnull.Uint32 `json:"confirmations" gorm:"column:confirmations"`
+	ParentIDs                        IDCollection  `json:"parentIds,omitempty" gorm:"column:parent_ids;type:text"`
+	Retries                          uint32        `json:"retries"`
+	RetryAt                          null.Time     `json:"retryAt"`
 	CreatedAt                        time.Time     `json:"-"`
 	UpdatedAt                        time.Time     `json:"-"`
 }
@@ -279,9 +410,27 @@ func (tr *TaskRun) ApplyOutput(result RunOutput) {
 		return
 	}
 	tr.Result.Data = result.Data()
+	tr.Result.ErrorMessage = null.String{}
 	tr.Status = result.Status()
 }
 
+// ScheduleRetry records a failed attempt of the TaskRun, keeping the error
+// message, and sets it to sleep until it is attempted again at the given time.
+func (tr *TaskRun) ScheduleRetry(err error, at time.Time) {
+	tr.Result.ErrorMessage = null.StringFrom(err.Error())
+	tr.Retries++
+	tr.RetryAt = null.TimeFrom(at)
+	tr.Status = RunStatusPendingSleep
+}
+
+// Postpone sets the TaskRun to sleep until it is attempted again at the
+// given time, keeping the error message, without counting an attempt.
+func (tr *TaskRun) Postpone(err error, at time.Time) {
+	tr.Result.ErrorMessage = null.StringFrom(err.Error())
+	tr.RetryAt = null.TimeFrom(at)
+	tr.Status = RunStatusPendingSleep
+}
+
 // RunResult keeps track of the outcome of a TaskRun or JobRun. It stores the
 // Data and ErrorMessage.
 type RunResult struct {
@@ -26,6 +26,13 @@ type JobSpecRequest struct {
 	MinPayment *assets.Link       `json:"minPayment,omitempty"`
 }
 
+// JobSpecSimulationRequest represents a schema for requests to simulate a
+// job spec with the given input, without creating the job.
+type JobSpecSimulationRequest struct {
+	Spec  JobSpecRequest `json:"spec"`
+	Input JSON           `json:"input"`
+}
+
 // InitiatorRequest represents a schema for incoming initiator requests as used by the API.
 type InitiatorRequest struct {
 	Type            string `json:"type"`
@@ -35,15 +42,24 @@ type InitiatorRequest struct {
 // TaskSpecRequest represents a schema for incoming TaskSpec requests as used by the API.
 type TaskSpecRequest struct {
 	Type                             TaskType      `json:"type"`
+	Name                             string        `json:"name,omitempty"`
+	DependsOn                        TaskNames     `json:"dependsOn,omitempty"`
+	Condition                        string        `json:"condition,omitempty"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"confirmations"`
 	Params                           JSON          `json:"params"`
+	Retry                            RetryPolicy   `json:"retry"`
 }
 
 // JobSpec is the definition for all the work to be carried out by the node
 // for a given contract. It contains the Initiators, Tasks (which are the
 // individual steps to be carried out), StartAt, EndAt, and CreatedAt fields.
+//
+// Updating a JobSpec replaces its Tasks with a new set and increments its
+// Version, leaving the tasks of earlier versions in place for the runs that
+// were started with them.
 type JobSpec struct {
 	ID         *ID          `json:"id,omitempty" gorm:"primary_key;not null"`
+	Version    uint32       `json:"version" gorm:"not null;default:1"`
 	CreatedAt  time.Time    `json:"createdAt" gorm:"index"`
 	Initiators []Initiator  `json:"initiators"`
 	MinPayment *assets.Link `json:"minPayment,omitempty" gorm:"type:varchar(255)"`
@@ -74,6 +90,7 @@ func (j *JobSpec) SetID(value string) error {
 func NewJob() JobSpec {
 	return JobSpec{
 		ID:        NewID(),
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
 }
@@ -89,9 +106,14 @@ func NewJobFromRequest(jsr JobSpecRequest) JobSpec {
 	for _, task := range jsr.Tasks {
 		jobSpec.Tasks = append(jobSpec.Tasks, TaskSpec{
 			JobSpecID:                        jobSpec.ID,
+			JobSpecVersion:                   jobSpec.Version,
 			Type:                             task.Type,
+			Name:                             task.Name,
+			DependsOn:                        task.DependsOn,
+			Condition:                        task.Condition,
 			MinRequiredIncomingConfirmations: task.MinRequiredIncomingConfirmations,
 			Params:                           task.Params,
+			Retry:                            task.Retry,
 		})
 	}
 
@@ -200,12 +222,32 @@ type Initiator struct {
 	InitiatorParams `json:"params,omitempty"`
 	DeletedAt       null.Time `json:"-" gorm:"index"`
 	UpdatedAt       time.Time `json:"-"`
+
+	// LastFiredAt is when a cron initiator last fired, from which the runs it
+	// missed while the node was down are caught up.
+	LastFiredAt null.Time `json:"lastFiredAt"`
 }
 
+// CatchUpPolicy is how a cron initiator deals with the runs it should have
+// started while the node was down.
+type CatchUpPolicy string
+
+const (
+	// CatchUpSkip drops the missed runs. This is the default.
+	CatchUpSkip = CatchUpPolicy("skip")
+	// CatchUpOnce starts a single run if any were missed.
+	CatchUpOnce = CatchUpPolicy("once")
+	// CatchUpAll starts one run for each one missed.
+	CatchUpAll = CatchUpPolicy("all")
+)
+
 // InitiatorParams is a collection of the possible parameters that different
 // Initiators may require.
 type InitiatorParams struct {
 	Schedule   Cron              `json:"schedule,omitempty"`
+	Timezone   string            `json:"timezone,omitempty"`
+	Jitter     Duration          `json:"jitter,omitempty"`
+	CatchUp    CatchUpPolicy     `json:"catchUp,omitempty"`
 	Time       AnyTime           `json:"time,omitempty"`
 	Ran        bool              `json:"ran,omitempty"`
 	Address    common.Address    `json:"address,omitempty" gorm:"index"`
@@ -226,6 +268,18 @@ type InitiatorParams struct {
 	AbsoluteThreshold float32         `json:"absoluteThreshold" gorm:"type:float;not null"`
 	PollTimer         PollTimerConfig `json:"pollTimer,omitempty" gorm:"type:jsonb"`
 	IdleTimer         IdleTimerConfig `json:"idleTimer,omitempty" gorm:"type:jsonb"`
+	// ThresholdSchedule overrides Threshold and AbsoluteThreshold during its
+	// windows, such as with tighter thresholds during trading hours.
+	ThresholdSchedule ThresholdSchedule `json:"thresholdSchedule,omitempty" gorm:"type:jsonb"`
+	// Heartbeat is the longest time the node may go without submitting an
+	// answer, whatever the deviation, or zero for no heartbeat.
+	Heartbeat Duration `json:"heartbeat,omitempty" gorm:"type:bigint;not null;default:0"`
+	// OutlierGuard refuses to submit answers outside of a band, or which
+	// jump too far from the latest answer.
+	OutlierGuard OutlierGuardConfig `json:"outlierGuard,omitempty" gorm:"type:jsonb"`
+	// Aggregators are the flux aggregators fed by a flux monitor initiator
+	// in addition to the one at Address, from the same poll of its feeds.
+	Aggregators AddressCollection `json:"aggregators,omitempty" gorm:"type:text;not null;default:''"`
 }
 
 type PollTimerConfig struct {
@@ -345,19 +399,74 @@ func (i Initiator) IsLogInitiated() bool {
 	return false
 }
 
+// FluxAggregators returns the addresses of the flux aggregators fed by a flux
+// monitor initiator, starting with its Address.
+func (i Initiator) FluxAggregators() []common.Address {
+	return append([]common.Address{i.Address}, i.Aggregators...)
+}
+
 // Feeds holds the json of the feeds parameter in the job spec. It is an array of
-// URL strings and/or objects containing the names of bridges
+// URL strings, objects containing the names of bridges and/or feed pipelines
 type Feeds = JSON
 
+// FeedPipeline is a feed whose answer is the result of performing its tasks,
+// such as {"tasks": [{"type": "httpget", ...}, {"type": "jsonparse", ...}]},
+// for sources which do not respond in the external adapter format.
+type FeedPipeline struct {
+	Tasks []TaskSpecRequest `json:"tasks"`
+}
+
+// IsFeedPipeline returns true if the feed object is a pipeline rather than a
+// bridge.
+func IsFeedPipeline(feed map[string]interface{}) bool {
+	_, ok := feed["tasks"]
+	return ok
+}
+
+// NewFeedPipeline parses the feed object of a pipeline.
+func NewFeedPipeline(feed map[string]interface{}) (FeedPipeline, error) {
+	var pipeline FeedPipeline
+	if len(feed) != 1 {
+		return pipeline, errors.New("Unsupported keys in feed pipeline JSON")
+	}
+	b, err := json.Marshal(feed)
+	if err != nil {
+		return pipeline, err
+	}
+	if err := json.Unmarshal(b, &pipeline); err != nil {
+		return pipeline, errors.Wrap(err, "invalid feed pipeline")
+	}
+	if len(pipeline.Tasks) == 0 {
+		return pipeline, errors.New("feed pipeline must have at least one task")
+	}
+	return pipeline, nil
+}
+
+// JobSpec returns an unsaved job performing the tasks of the pipeline.
+func (fp FeedPipeline) JobSpec() JobSpec {
+	return NewJobFromRequest(JobSpecRequest{Tasks: fp.Tasks})
+}
+
 // TaskSpec is the definition of work to be carried out. The
 // Type will be an adapter, and the Params will contain any
 // additional information that adapter would need to operate.
+//
+// A TaskSpec may be given a Name so that other tasks in the same job can
+// list it in DependsOn, receiving its output as their input. When Condition
+// names one of those dependencies, the task only runs if that dependency's
+// result is true, and is skipped otherwise. Retry configures how often the
+// task is attempted again if it fails.
 type TaskSpec struct {
 	ID                               int64         `gorm:"primary_key"`
 	JobSpecID                        *ID           `json:"-"`
+	JobSpecVersion                   uint32        `json:"-" gorm:"not null;default:1"`
 	Type                             TaskType      `json:"type" gorm:"index;not null"`
+	Name                             string        `json:"name,omitempty"`
+	DependsOn                        TaskNames     `json:"dependsOn,omitempty" gorm:"type:text"`
+	Condition                        string        `json:"condition,omitempty"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"confirmations" gorm:"column:confirmations"`
 	Params                           JSON          `json:"params" gorm:"type:text"`
+	Retry                            RetryPolicy   `json:"retry" gorm:"type:jsonb"`
 	CreatedAt                        time.Time
 	UpdatedAt                        time.Time
 	DeletedAt                        *time.Time
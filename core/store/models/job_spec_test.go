@@ -315,3 +315,41 @@ func TestNewTaskType(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFeedPipeline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		feed      map[string]interface{}
+		wantTasks int
+		wantError bool
+	}{
+		{"pipeline", map[string]interface{}{"tasks": []interface{}{
+			map[string]interface{}{"type": "httpget", "params": map[string]interface{}{"get": "https://example.com"}},
+			map[string]interface{}{"type": "jsonparse", "params": map[string]interface{}{"path": []interface{}{"last"}}},
+		}}, 2, false},
+		{"no tasks", map[string]interface{}{"tasks": []interface{}{}}, 0, true},
+		{"invalid tasks", map[string]interface{}{"tasks": "httpget"}, 0, true},
+		{"unsupported keys", map[string]interface{}{"tasks": []interface{}{map[string]interface{}{"type": "httpget"}}, "bridge": "testbridge"}, 0, true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.True(t, models.IsFeedPipeline(test.feed))
+			pipeline, err := models.NewFeedPipeline(test.feed)
+			if test.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			job := pipeline.JobSpec()
+			require.Len(t, job.Tasks, test.wantTasks)
+			assert.Equal(t, models.MustNewTaskType("httpget"), job.Tasks[0].Type)
+			assert.Equal(t, "https://example.com", job.Tasks[0].Params.Get("get").String())
+		})
+	}
+
+	assert.False(t, models.IsFeedPipeline(map[string]interface{}{"bridge": "testbridge"}))
+}
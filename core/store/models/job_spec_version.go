@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// JobSpecVersion is a snapshot of a JobSpec as it was defined at a given
+// version, kept so that the history of a job can be inspected and compared
+// after it has been updated.
+type JobSpecVersion struct {
+	ID        int64     `json:"-" gorm:"primary_key;auto_increment"`
+	JobSpecID *ID       `json:"jobSpecId" gorm:"not null"`
+	Version   uint32    `json:"version" gorm:"not null"`
+	Spec      JSON      `json:"spec" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewJobSpecVersion returns a snapshot of the job's current version, in the
+// same format as a JobSpecRequest.
+func NewJobSpecVersion(job JobSpec) (JobSpecVersion, error) {
+	b, err := json.Marshal(NewJobSpecRequestFromJob(job))
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	spec, err := ParseJSON(b)
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	return JobSpecVersion{
+		JobSpecID: job.ID,
+		Version:   job.Version,
+		Spec:      spec,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetID() string {
+	return strconv.FormatInt(v.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetName() string {
+	return "specVersions"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (v *JobSpecVersion) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	v.ID = id
+	return nil
+}
+
+// NewJobSpecRequestFromJob returns the JobSpecRequest that would create a
+// job with the same initiators, tasks and schedule as the given job.
+func NewJobSpecRequestFromJob(job JobSpec) JobSpecRequest {
+	jsr := JobSpecRequest{
+		Initiators: []InitiatorRequest{},
+		Tasks:      []TaskSpecRequest{},
+		StartAt:    job.StartAt,
+		EndAt:      job.EndAt,
+		MinPayment: job.MinPayment,
+	}
+	for _, initr := range job.Initiators {
+		jsr.Initiators = append(jsr.Initiators, InitiatorRequest{
+			Type:            initr.Type,
+			InitiatorParams: initr.InitiatorParams,
+		})
+	}
+	for _, task := range job.Tasks {
+		jsr.Tasks = append(jsr.Tasks, TaskSpecRequest{
+			Type:                             task.Type,
+			Name:                             task.Name,
+			DependsOn:                        task.DependsOn,
+			Condition:                        task.Condition,
+			MinRequiredIncomingConfirmations: task.MinRequiredIncomingConfirmations,
+			Params:                           task.Params,
+			Retry:                            task.Retry,
+		})
+	}
+	return jsr
+}
@@ -0,0 +1,229 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Classes of errors a RetryPolicy can be configured to retry on.
+const (
+	// ErrorClassTimeout is for requests that did not complete in time.
+	ErrorClassTimeout = "timeout"
+	// ErrorClassConnection is for requests that could not reach the remote
+	// host, or whose connection was dropped.
+	ErrorClassConnection = "connection"
+	// ErrorClassServer is for responses with a 5xx status code.
+	ErrorClassServer = "server"
+	// ErrorClassClient is for responses with a 4xx status code.
+	ErrorClassClient = "client"
+	// ErrorClassAny matches every error.
+	ErrorClassAny = "any"
+)
+
+const (
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = time.Minute
+	defaultRetryMultiplier     = 2
+	// maxRetryBackoff bounds the backoff a policy can configure, so that a
+	// sleeping run cannot be held back indefinitely.
+	maxRetryBackoff = time.Hour
+)
+
+// StatusCodeError is implemented by errors caused by a response with an
+// error status code, such as those returned by the HTTP and Bridge adapters.
+type StatusCodeError interface {
+	error
+	StatusCode() int
+}
+
+// RetryPolicy configures how many times a failing task is attempted before
+// its job run errors, and how long to wait between attempts.
+//
+// Unless RetryOnStatusCodes or RetryOnErrors are set, timeouts, connection
+// errors, 429 responses and 5xx responses are retried.
+type RetryPolicy struct {
+	MaxAttempts        uint32   `json:"maxAttempts,omitempty"`
+	InitialBackoff     Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff         Duration `json:"maxBackoff,omitempty"`
+	Multiplier         float64  `json:"multiplier,omitempty"`
+	Jitter             float64  `json:"jitter,omitempty"`
+	RetryOnStatusCodes []int    `json:"retryOnStatusCodes,omitempty"`
+	RetryOnErrors      []string `json:"retryOnErrors,omitempty"`
+}
+
+// Enabled returns true if the policy allows a task to be attempted more
+// than once.
+func (rp RetryPolicy) Enabled() bool {
+	return rp.MaxAttempts > 1
+}
+
+// Validate returns an error if the policy's parameters are out of range.
+func (rp RetryPolicy) Validate() error {
+	if rp.Multiplier != 0 && rp.Multiplier < 1 {
+		return errors.New("retry multiplier must be at least 1")
+	}
+	if rp.Jitter < 0 || rp.Jitter > 1 {
+		return errors.New("retry jitter must be between 0 and 1")
+	}
+	if rp.InitialBackoff.Duration() > maxRetryBackoff || rp.MaxBackoff.Duration() > maxRetryBackoff {
+		return fmt.Errorf("retry backoff must not be longer than %s", maxRetryBackoff)
+	}
+	if !rp.MaxBackoff.IsInstant() && rp.MaxBackoff.Shorter(rp.InitialBackoff) {
+		return errors.New("retry maxBackoff must not be shorter than initialBackoff")
+	}
+	for _, class := range rp.RetryOnErrors {
+		switch class {
+		case ErrorClassTimeout, ErrorClassConnection, ErrorClassServer, ErrorClassClient, ErrorClassAny:
+		default:
+			return fmt.Errorf("retry error class %q is not one of %s, %s, %s, %s or %s", class,
+				ErrorClassTimeout, ErrorClassConnection, ErrorClassServer, ErrorClassClient, ErrorClassAny)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry returns true if a task that has already been retried the given
+// number of times should be attempted again after failing with err.
+func (rp RetryPolicy) ShouldRetry(retries uint32, err error) bool {
+	if err == nil || retries+1 >= rp.MaxAttempts {
+		return false
+	}
+
+	statusCode, class := ClassifyError(err)
+	if len(rp.RetryOnStatusCodes) == 0 && len(rp.RetryOnErrors) == 0 {
+		return class == ErrorClassTimeout ||
+			class == ErrorClassConnection ||
+			class == ErrorClassServer ||
+			statusCode == 429
+	}
+
+	for _, code := range rp.RetryOnStatusCodes {
+		if statusCode != 0 && code == statusCode {
+			return true
+		}
+	}
+	for _, c := range rp.RetryOnErrors {
+		if c == ErrorClassAny || (class != "" && c == class) {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns how long to wait before the next attempt of a task that
+// has already been retried the given number of times. The delay grows
+// exponentially from InitialBackoff up to MaxBackoff, and is then reduced by
+// a random fraction of up to Jitter.
+func (rp RetryPolicy) Backoff(retries uint32) time.Duration {
+	initial := rp.InitialBackoff.Duration()
+	if initial == 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	max := rp.MaxBackoff.Duration()
+	if max == 0 {
+		max = defaultRetryMaxBackoff
+	}
+	multiplier := rp.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(retries))
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+	if rp.Jitter > 0 {
+		delay -= delay * rp.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// Value is defined so that we can store RetryPolicy as JSONB, because
+// of an error with GORM where it has trouble with nested structs as JSONB.
+// See https://github.com/jinzhu/gorm/issues/2704
+func (rp RetryPolicy) Value() (driver.Value, error) {
+	b, err := json.Marshal(rp)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan is defined so that we can read RetryPolicy as JSONB, because
+// of an error with GORM where it has trouble with nested structs as JSONB.
+// See https://github.com/jinzhu/gorm/issues/2704
+func (rp *RetryPolicy) Scan(value interface{}) error {
+	if value == nil {
+		*rp = RetryPolicy{}
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid Scan Source")
+	}
+	return json.Unmarshal(b, rp)
+}
+
+// ClassifyError returns the status code of the response that caused err, if
+// any, along with one of the ErrorClass constants, or an empty class if the
+// error does not fit one.
+func ClassifyError(err error) (int, string) {
+	err = lastWrappedError(err)
+
+	var statusErr StatusCodeError
+	if errors.As(err, &statusErr) {
+		code := statusErr.StatusCode()
+		switch {
+		case code >= 500:
+			return code, ErrorClassServer
+		case code >= 400:
+			return code, ErrorClassClient
+		default:
+			return code, ""
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return 0, ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return 0, ErrorClassTimeout
+	}
+	var urlErr *url.Error
+	var opErr *net.OpError
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) || netErr != nil {
+		return 0, ErrorClassConnection
+	}
+	return 0, ""
+}
+
+// lastWrappedError returns the final attempt's error from errors that
+// aggregate several attempts, such as those returned by retry-go.
+func lastWrappedError(err error) error {
+	for {
+		multi, ok := err.(interface{ WrappedErrors() []error })
+		if !ok {
+			return err
+		}
+		var last error
+		for _, e := range multi.WrappedErrors() {
+			if e != nil {
+				last = e
+			}
+		}
+		if last == nil {
+			return err
+		}
+		err = last
+	}
+}
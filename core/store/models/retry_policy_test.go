@@ -0,0 +1,114 @@
+package models_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	retry "github.com/avast/retry-go"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statusCodeError int
+
+func (e statusCodeError) Error() string   { return "status code error" }
+func (e statusCodeError) StatusCode() int { return int(e) }
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	connErr := &url.Error{Op: "Post", URL: "http://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name    string
+		policy  string
+		retries uint32
+		err     error
+		want    bool
+	}{
+		{"disabled", `{}`, 0, statusCodeError(500), false},
+		{"server error", `{"maxAttempts":3}`, 0, statusCodeError(500), true},
+		{"too many requests", `{"maxAttempts":3}`, 0, statusCodeError(429), true},
+		{"client error", `{"maxAttempts":3}`, 0, statusCodeError(400), false},
+		{"timeout", `{"maxAttempts":3}`, 0, context.DeadlineExceeded, true},
+		{"connection", `{"maxAttempts":3}`, 0, connErr, true},
+		{"unclassified", `{"maxAttempts":3}`, 0, errors.New("boom"), false},
+		{"attempts exhausted", `{"maxAttempts":3}`, 2, statusCodeError(500), false},
+		{"wrapped", `{"maxAttempts":3}`, 0, pkgerrors.Wrap(statusCodeError(503), "POST request"), true},
+		{"last of several attempts", `{"maxAttempts":3}`, 0, retry.Error{errors.New("boom"), statusCodeError(502)}, true},
+		{"listed status code", `{"maxAttempts":3,"retryOnStatusCodes":[404]}`, 0, statusCodeError(404), true},
+		{"unlisted status code", `{"maxAttempts":3,"retryOnStatusCodes":[404]}`, 0, statusCodeError(500), false},
+		{"listed error class", `{"maxAttempts":3,"retryOnErrors":["client"]}`, 0, statusCodeError(401), true},
+		{"unlisted error class", `{"maxAttempts":3,"retryOnErrors":["timeout"]}`, 0, connErr, false},
+		{"any error", `{"maxAttempts":3,"retryOnErrors":["any"]}`, 0, errors.New("boom"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var policy models.RetryPolicy
+			require.NoError(t, json.Unmarshal([]byte(test.policy), &policy))
+			assert.Equal(t, test.want, policy.ShouldRetry(test.retries, test.err))
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := models.RetryPolicy{
+		InitialBackoff: models.MustMakeDuration(time.Second),
+		MaxBackoff:     models.MustMakeDuration(5 * time.Second),
+		Multiplier:     2,
+	}
+	assert.Equal(t, time.Second, policy.Backoff(0))
+	assert.Equal(t, 2*time.Second, policy.Backoff(1))
+	assert.Equal(t, 4*time.Second, policy.Backoff(2))
+	assert.Equal(t, 5*time.Second, policy.Backoff(3))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		backoff := policy.Backoff(1)
+		assert.True(t, backoff > time.Second && backoff <= 2*time.Second, backoff.String())
+	}
+
+	assert.Equal(t, time.Second, models.RetryPolicy{}.Backoff(0))
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{"empty", `{}`, false},
+		{"valid", `{"maxAttempts":5,"initialBackoff":"1s","maxBackoff":"30s","multiplier":1.5,"jitter":0.2,"retryOnErrors":["server","timeout"]}`, false},
+		{"multiplier below one", `{"multiplier":0.5}`, true},
+		{"jitter above one", `{"jitter":1.5}`, true},
+		{"max shorter than initial", `{"initialBackoff":"10s","maxBackoff":"1s"}`, true},
+		{"max backoff too long", `{"maxBackoff":"2h"}`, true},
+		{"initial backoff too long", `{"initialBackoff":"2h"}`, true},
+		{"unknown error class", `{"retryOnErrors":["flaky"]}`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var policy models.RetryPolicy
+			require.NoError(t, json.Unmarshal([]byte(test.policy), &policy))
+			if test.wantErr {
+				assert.Error(t, policy.Validate())
+			} else {
+				assert.NoError(t, policy.Validate())
+			}
+		})
+	}
+}
@@ -8,10 +8,11 @@ import (
 
 // RunInput represents the input for performing a Task
 type RunInput struct {
-	jobRunID  ID
-	taskRunID ID
-	data      JSON
-	status    RunStatus
+	jobRunID      ID
+	taskRunID     ID
+	data          JSON
+	status        RunStatus
+	parentResults []gjson.Result
 }
 
 // NewRunInput creates a new RunInput with arbitrary data
@@ -72,6 +73,19 @@ func (ri RunInput) TaskRunID() ID {
 	return ri.taskRunID
 }
 
+// ParentResults returns the result of each parent of a task with several
+// parents, in the order of its dependencies.
+func (ri RunInput) ParentResults() []gjson.Result {
+	return ri.parentResults
+}
+
+// WithParentResults returns a copy of the RunInput holding the given results
+// of the parents of its task.
+func (ri RunInput) WithParentResults(results []gjson.Result) RunInput {
+	ri.parentResults = results
+	return ri
+}
+
 func (ri RunInput) CloneWithData(data JSON) RunInput {
 	ri.data = data
 	return ri
@@ -2,15 +2,17 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/tidwall/gjson"
 )
 
 // RunOutput represents the result of performing a Task
 type RunOutput struct {
-	data   JSON
-	status RunStatus
-	err    error
+	data    JSON
+	status  RunStatus
+	err     error
+	retryAt time.Time
 }
 
 // NewRunOutputError returns a new RunOutput with an error
@@ -69,6 +71,13 @@ func NewRunOutputPendingBridge() RunOutput {
 	return RunOutput{status: RunStatusPendingBridge}
 }
 
+// NewRunOutputPendingRetry returns a new RunOutput that indicates the task
+// could not be performed for the given reason yet, and should be attempted
+// again at the given time without counting against its retry policy.
+func NewRunOutputPendingRetry(err error, at time.Time) RunOutput {
+	return RunOutput{status: RunStatusPendingSleep, err: err, retryAt: at}
+}
+
 // HasError returns true if the status is errored or the error message is set
 func (ro RunOutput) HasError() bool {
 	return ro.status == RunStatusErrored
@@ -98,3 +107,43 @@ func (ro RunOutput) Data() JSON {
 func (ro RunOutput) Status() RunStatus {
 	return ro.status
 }
+
+// RetryAt returns the time at which a task pending retry should be attempted
+// again, and whether it is pending retry.
+func (ro RunOutput) RetryAt() (time.Time, bool) {
+	return ro.retryAt, ro.status == RunStatusPendingSleep && !ro.retryAt.IsZero()
+}
+
+// Redact returns the output with secrets replaced in its data and error by
+// the given function. The redacted error wraps the original one, so that it
+// is still classified the same way by retry policies.
+func (ro RunOutput) Redact(redact func(string) string) RunOutput {
+	if text := ro.data.String(); text != "" {
+		if redacted := redact(text); redacted != text {
+			data, err := ParseJSON([]byte(redacted))
+			if err != nil {
+				return NewRunOutputError(fmt.Errorf("unable to redact secrets from task output: %v", err))
+			}
+			ro.data = data
+		}
+	}
+	if ro.err != nil {
+		if redacted := redact(ro.err.Error()); redacted != ro.err.Error() {
+			ro.err = &redactedError{message: redacted, cause: ro.err}
+		}
+	}
+	return ro
+}
+
+type redactedError struct {
+	message string
+	cause   error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// SecretRequest is the incoming record used to create or update a Secret.
+type SecretRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Secret is a value, such as an API key, stored encrypted with the node's
+// password, and referenced in task params as {{secret:NAME}} so that it is
+// not stored or shown in plain text.
+type Secret struct {
+	Name           string    `json:"name" gorm:"primary_key"`
+	EncryptedValue string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (s Secret) GetID() string {
+	return s.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (s Secret) GetName() string {
+	return "secrets"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (s *Secret) SetID(value string) error {
+	s.Name = value
+	return nil
+}
+
+var secretNameFormat = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SecretReferenceFormat matches the references to secrets in task params,
+// capturing the name of the secret.
+var SecretReferenceFormat = regexp.MustCompile(`\{\{secret:([A-Za-z0-9_-]+)\}\}`)
+
+// ValidateSecretName returns an error if the name cannot be used in a
+// reference to a secret.
+func ValidateSecretName(name string) error {
+	if !secretNameFormat.MatchString(name) {
+		return fmt.Errorf("secret name %q must only contain letters, digits, dashes and underscores", name)
+	}
+	return nil
+}
+
+// MinSecretLength is the minimum length of the value of a secret. Values are
+// redacted wherever they appear in run outputs and logs, so shorter values
+// would redact unrelated text which happens to contain them.
+const MinSecretLength = 8
+
+// ValidateSecretValue returns an error if the value is too short to be
+// redacted.
+func ValidateSecretValue(value string) error {
+	if len(value) < MinSecretLength {
+		return fmt.Errorf("secret value must be at least %d characters long", MinSecretLength)
+	}
+	return nil
+}
+
+// SecretReference returns the reference to the secret used in task params.
+func SecretReference(name string) string {
+	return fmt.Sprintf("{{secret:%s}}", name)
+}
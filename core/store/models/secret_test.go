@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecretName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"API_KEY", "api-key", "key1"} {
+		assert.NoError(t, models.ValidateSecretName(name), name)
+	}
+	for _, name := range []string{"", "api key", "key}}", "ключ"} {
+		assert.Error(t, models.ValidateSecretName(name), name)
+	}
+	assert.Equal(t, "{{secret:API_KEY}}", models.SecretReference("API_KEY"))
+}
+
+func TestValidateSecretValue(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, models.ValidateSecretValue("12345678"))
+	assert.EqualError(t, models.ValidateSecretValue("1234567"), "secret value must be at least 8 characters long")
+	assert.Error(t, models.ValidateSecretValue(""))
+}
+
+func TestRunOutput_Redact(t *testing.T) {
+	t.Parallel()
+
+	redact := strings.NewReplacer("s3cr3t", "{{secret:KEY}}").Replace
+
+	output := models.NewRunOutputCompleteWithResult("token s3cr3t").Redact(redact)
+	assert.Equal(t, "token {{secret:KEY}}", output.Result().String())
+
+	unchanged := models.NewRunOutputCompleteWithResult("public")
+	assert.Equal(t, unchanged, unchanged.Redact(redact))
+
+	cause := errors.New("request to https://example.com?key=s3cr3t failed")
+	output = models.NewRunOutputError(cause).Redact(redact)
+	require.True(t, output.HasError())
+	assert.Equal(t, "request to https://example.com?key={{secret:KEY}} failed", output.Error().Error())
+	assert.True(t, errors.Is(output.Error(), cause))
+}
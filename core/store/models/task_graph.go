@@ -0,0 +1,218 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// TaskNames is a list of TaskSpec names serializable to and from the
+// database, used to declare the upstream tasks a TaskSpec depends on.
+type TaskNames []string
+
+// Value returns this instance serialized for database storage.
+func (t TaskNames) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+// Scan reads the database value and returns an instance.
+func (t *TaskNames) Scan(value interface{}) error {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case nil:
+		*t = nil
+		return nil
+	default:
+		return fmt.Errorf("unable to convert %v of %T to TaskNames", value, value)
+	}
+
+	if len(str) == 0 {
+		*t = nil
+		return nil
+	}
+	*t = strings.Split(str, ",")
+	return nil
+}
+
+// IDCollection is an array of IDs serializable to and from the database.
+type IDCollection []ID
+
+// ToStrings returns this ID collection as an array of strings.
+func (c IDCollection) ToStrings() []string {
+	converted := make([]string, len(c))
+	for i := range c {
+		converted[i] = c[i].String()
+	}
+	return converted
+}
+
+// Contains returns true if the given ID is present in the collection.
+func (c IDCollection) Contains(id *ID) bool {
+	if id == nil {
+		return false
+	}
+	for i := range c {
+		if c[i] == *id {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns this instance serialized for database storage.
+func (c IDCollection) Value() (driver.Value, error) {
+	return strings.Join(c.ToStrings(), ","), nil
+}
+
+// Scan reads the database value and returns an instance.
+func (c *IDCollection) Scan(value interface{}) error {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return fmt.Errorf("unable to convert %v of %T to IDCollection", value, value)
+	}
+
+	if len(str) == 0 {
+		*c = nil
+		return nil
+	}
+
+	arr := strings.Split(str, ",")
+	collection := make(IDCollection, len(arr))
+	for i, s := range arr {
+		if err := collection[i].UnmarshalString(s); err != nil {
+			return errors.Wrapf(err, "unable to convert %v to IDCollection", s)
+		}
+	}
+	*c = collection
+	return nil
+}
+
+// IsGraph returns true if any of the job's tasks declare dependencies, in
+// which case the tasks form a directed acyclic graph rather than a list
+// executed in order.
+func (j JobSpec) IsGraph() bool {
+	for _, task := range j.Tasks {
+		if len(task.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskDependencies returns, for each task of the job, the indexes of the
+// tasks it depends on. Jobs that don't declare any dependencies are linear:
+// each task depends on the one before it.
+func (j JobSpec) TaskDependencies() ([][]int, error) {
+	deps := make([][]int, len(j.Tasks))
+	if !j.IsGraph() {
+		for i := 1; i < len(j.Tasks); i++ {
+			deps[i] = []int{i - 1}
+		}
+		return deps, nil
+	}
+
+	indexes := map[string]int{}
+	for i, task := range j.Tasks {
+		if task.Name == "" {
+			continue
+		}
+		if _, exists := indexes[task.Name]; exists {
+			return nil, fmt.Errorf("task name %s is used more than once", task.Name)
+		}
+		indexes[task.Name] = i
+	}
+
+	for i, task := range j.Tasks {
+		for _, name := range task.DependsOn {
+			parent, ok := indexes[name]
+			if !ok {
+				return nil, fmt.Errorf("task %d depends on unknown task %s", i, name)
+			} else if parent == i {
+				return nil, fmt.Errorf("task %s cannot depend on itself", name)
+			}
+			deps[i] = append(deps[i], parent)
+		}
+		if task.Condition != "" && !task.DependsOn.contains(task.Condition) {
+			return nil, fmt.Errorf("task %d has condition %s which is not one of its dependencies", i, task.Condition)
+		}
+	}
+
+	if err := checkAcyclic(deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+func (t TaskNames) contains(name string) bool {
+	for _, n := range t {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAcyclic performs a depth first search over the dependency lists,
+// returning an error if any task transitively depends on itself.
+func checkAcyclic(deps [][]int) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(deps))
+
+	var visit func(int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return errors.New("task dependencies must not contain cycles")
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, parent := range deps[i] {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+
+	for i := range deps {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conditionMet returns true if the result of a TaskRun used as the condition
+// of a downstream task allows that task to run.
+func conditionMet(tr TaskRun) bool {
+	result := tr.Result.Data.Get("result")
+	switch result.Type {
+	case gjson.True:
+		return true
+	case gjson.String:
+		return strings.EqualFold(result.String(), "true")
+	default:
+		return false
+	}
+}
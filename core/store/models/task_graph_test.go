@@ -0,0 +1,138 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func namedTask(t *testing.T, name string, dependsOn ...string) models.TaskSpec {
+	task := cltest.NewTask(t, "noop")
+	task.Name = name
+	task.DependsOn = dependsOn
+	return task
+}
+
+func TestJobSpec_TaskDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tasks   []models.TaskSpec
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			"linear",
+			[]models.TaskSpec{cltest.NewTask(t, "noop"), cltest.NewTask(t, "noop"), cltest.NewTask(t, "noop")},
+			[][]int{nil, {0}, {1}},
+			false,
+		},
+		{
+			"fan in",
+			[]models.TaskSpec{namedTask(t, "a"), namedTask(t, "b"), namedTask(t, "c", "a", "b")},
+			[][]int{nil, nil, {0, 1}},
+			false,
+		},
+		{
+			"declared out of order",
+			[]models.TaskSpec{namedTask(t, "c", "b"), namedTask(t, "b", "a"), namedTask(t, "a")},
+			[][]int{{1}, {2}, nil},
+			false,
+		},
+		{"unknown dependency", []models.TaskSpec{namedTask(t, "a", "b")}, nil, true},
+		{"self dependency", []models.TaskSpec{namedTask(t, "a", "a")}, nil, true},
+		{"duplicate name", []models.TaskSpec{namedTask(t, "a"), namedTask(t, "a"), namedTask(t, "b", "a")}, nil, true},
+		{"cycle", []models.TaskSpec{namedTask(t, "a", "c"), namedTask(t, "b", "a"), namedTask(t, "c", "b")}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := cltest.NewJobWithWebInitiator()
+			job.Tasks = test.tasks
+
+			deps, err := job.TaskDependencies()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, deps)
+			}
+		})
+	}
+}
+
+func TestJobSpec_TaskDependencies_ConditionMustBeADependency(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	ethtx := namedTask(t, "tx", "a")
+	ethtx.Condition = "b"
+	job.Tasks = []models.TaskSpec{namedTask(t, "a"), namedTask(t, "b"), ethtx}
+
+	_, err := job.TaskDependencies()
+	assert.Error(t, err)
+}
+
+func TestJobRun_RunnableTaskRunIndexes(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{
+		namedTask(t, "a"),
+		namedTask(t, "b"),
+		namedTask(t, "c", "a", "b"),
+	}
+	run := cltest.NewJobRun(job)
+
+	assert.Equal(t, []int{0, 1}, run.RunnableTaskRunIndexes())
+
+	run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("1"))
+	assert.Equal(t, []int{1}, run.RunnableTaskRunIndexes())
+
+	run.TaskRuns[1].ApplyOutput(models.NewRunOutputCompleteWithResult("2"))
+	assert.Equal(t, []int{2}, run.RunnableTaskRunIndexes())
+
+	parents := run.ParentTaskRuns(2)
+	require.Len(t, parents, 2)
+	assert.Equal(t, run.TaskRuns[0].ID, parents[0].ID)
+	assert.Equal(t, run.TaskRuns[1].ID, parents[1].ID)
+}
+
+func TestJobRun_RunnableTaskRunIndexes_SkipsUnmetConditions(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	compare := namedTask(t, "compare")
+	ethtx := namedTask(t, "ethtx", "compare")
+	ethtx.Condition = "compare"
+	after := namedTask(t, "after", "ethtx")
+	job.Tasks = []models.TaskSpec{compare, ethtx, after}
+	run := cltest.NewJobRun(job)
+
+	run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult(false))
+	assert.Empty(t, run.RunnableTaskRunIndexes())
+	assert.Equal(t, models.RunStatusSkipped, run.TaskRuns[1].Status)
+	assert.Equal(t, models.RunStatusSkipped, run.TaskRuns[2].Status)
+	assert.False(t, run.TasksRemain())
+}
+
+func TestJobRun_ParentTaskRuns_WithoutEdges(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop"), cltest.NewTask(t, "noop")}
+	run := cltest.NewJobRun(job)
+	for i := range run.TaskRuns {
+		run.TaskRuns[i].ParentIDs = nil
+	}
+
+	assert.Empty(t, run.ParentTaskRuns(0))
+	parents := run.ParentTaskRuns(1)
+	require.Len(t, parents, 1)
+	assert.Equal(t, run.TaskRuns[0].ID, parents[0].ID)
+}
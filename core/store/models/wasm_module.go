@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// WasmModuleRequest is the incoming record used to register a WasmModule.
+// The module binary is base64 encoded.
+type WasmModuleRequest struct {
+	Name TaskType `json:"name"`
+	Wasm []byte   `json:"wasm"`
+}
+
+// WasmModule is a WebAssembly module run by the wasm adapter, identified in
+// job specs by its name.
+type WasmModule struct {
+	Name      TaskType  `json:"name" gorm:"primary_key"`
+	Hash      string    `json:"hash"`
+	Wasm      []byte    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (wm WasmModule) GetID() string {
+	return wm.Name.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (wm WasmModule) GetName() string {
+	return "wasmModules"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (wm *WasmModule) SetID(value string) error {
+	name, err := NewTaskType(value)
+	wm.Name = name
+	return err
+}
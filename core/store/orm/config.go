@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -93,6 +94,17 @@ func (c *Config) Validate() error {
 			ethCore.DefaultTxPoolConfig.PriceBump,
 		)
 	}
+	ethGasTipCapBumpPercent := c.EthGasTipCapBumpPercent()
+	if c.EthTxType() == EthTxTypeDynamicFee && uint64(ethGasTipCapBumpPercent) < ethCore.DefaultTxPoolConfig.PriceBump {
+		logger.Warnf(
+			"ETH_GAS_TIP_CAP_BUMP_PERCENT of %v is less than Geth's default of %v, transactions may fail with underpriced replacement errors",
+			ethGasTipCapBumpPercent,
+			ethCore.DefaultTxPoolConfig.PriceBump,
+		)
+	}
+	if quorum, nodes := int(c.EthRPCQuorum()), 1+len(c.EthereumSecondaryURLs()); quorum > nodes {
+		return fmt.Errorf("ETH_RPC_QUORUM of %v is more than the %v configured Ethereum nodes", quorum, nodes)
+	}
 	return nil
 }
 
@@ -145,6 +157,24 @@ func (c Config) BlockBackfillDepth() uint64 {
 	return c.viper.GetUint64(EnvVarName("BlockBackfillDepth"))
 }
 
+// BridgeCircuitBreakerCooldown is how long a bridge is skipped for after
+// its circuit breaker opens, before requests are let through to it again.
+func (c Config) BridgeCircuitBreakerCooldown() models.Duration {
+	return c.getDuration("BridgeCircuitBreakerCooldown")
+}
+
+// BridgeCircuitBreakerThreshold is the number of consecutive failed requests
+// to a bridge which open its circuit breaker.
+func (c Config) BridgeCircuitBreakerThreshold() uint16 {
+	return c.getWithFallback("BridgeCircuitBreakerThreshold", parseUint16).(uint16)
+}
+
+// BridgeHealthCheckInterval is how often the health URLs of bridges are
+// checked.
+func (c Config) BridgeHealthCheckInterval() models.Duration {
+	return c.getDuration("BridgeHealthCheckInterval")
+}
+
 // BridgeResponseURL represents the URL for bridges to send a response to.
 func (c Config) BridgeResponseURL() *url.URL {
 	return c.getWithFallback("BridgeResponseURL", parseURL).(*url.URL)
@@ -220,6 +250,12 @@ func (c Config) FeatureFluxMonitor() bool {
 	return c.viper.GetBool(EnvVarName("FeatureFluxMonitor"))
 }
 
+// FluxMonitorRoundHistory is how long the decisions of the flux monitor
+// are kept for, or zero to keep them forever.
+func (c Config) FluxMonitorRoundHistory() models.Duration {
+	return c.getDuration("FluxMonitorRoundHistory")
+}
+
 // MaxRPCCallsPerSecond returns the rate at which RPC calls can be fired
 func (c Config) MaxRPCCallsPerSecond() uint64 {
 	return c.viper.GetUint64(EnvVarName("MaxRPCCallsPerSecond"))
@@ -285,11 +321,91 @@ func (c Config) SetEthGasPriceDefault(value *big.Int) error {
 	return c.runtimeStore.SetConfigValue("EthGasPriceDefault", value)
 }
 
+// EthTxType is the type of the transactions sent by the node, which depends
+// on what the chain it is connected to supports.
+func (c Config) EthTxType() EthTxType {
+	return c.getWithFallback("EthTxType", parseEthTxType).(EthTxType)
+}
+
+// EthSignerURL is the URL of an external signer, such as Clef, holding the
+// node's Ethereum keys. The keys in the node's key store are used when it is
+// not set.
+func (c Config) EthSignerURL() string {
+	return c.viper.GetString(EnvVarName("EthSignerURL"))
+}
+
+// EthGasFeeCapDefault is the most a dynamic fee transaction pays per unit
+// of gas, base fee and tip included, before being bumped.
+func (c Config) EthGasFeeCapDefault() *big.Int {
+	return c.getWithFallback("EthGasFeeCapDefault", parseBigInt).(*big.Int)
+}
+
+// EthGasTipCapDefault is the starting tip paid to miners by every dynamic
+// fee transaction.
+func (c Config) EthGasTipCapDefault() *big.Int {
+	if c.runtimeStore != nil {
+		var value big.Int
+		if err := c.runtimeStore.GetConfigValue("EthGasTipCapDefault", &value); err != nil && errors.Cause(err) != ErrorNotFound {
+			logger.Warnw("Error while trying to fetch EthGasTipCapDefault.", "error", err)
+		} else if err == nil {
+			return &value
+		}
+	}
+	return c.getWithFallback("EthGasTipCapDefault", parseBigInt).(*big.Int)
+}
+
+// SetEthGasTipCapDefault saves a runtime value for the default tip of
+// dynamic fee transactions
+func (c Config) SetEthGasTipCapDefault(value *big.Int) error {
+	if c.runtimeStore == nil {
+		return errors.New("No runtime store installed")
+	}
+	return c.runtimeStore.SetConfigValue("EthGasTipCapDefault", value)
+}
+
+// EthGasTipCapBumpPercent is the minimum percentage by which the tip and fee
+// cap of dynamic fee transactions are bumped on each transaction attempt
+func (c Config) EthGasTipCapBumpPercent() uint16 {
+	return c.getWithFallback("EthGasTipCapBumpPercent", parseUint16).(uint16)
+}
+
+// EthGasTipCapBumpWei is the minimum fixed amount of wei by which the tip of
+// dynamic fee transactions is bumped on each transaction attempt
+func (c Config) EthGasTipCapBumpWei() *big.Int {
+	return c.getWithFallback("EthGasTipCapBumpWei", parseBigInt).(*big.Int)
+}
+
 // EthereumURL represents the URL of the Ethereum node to connect Chainlink to.
 func (c Config) EthereumURL() string {
 	return c.viper.GetString(EnvVarName("EthereumURL"))
 }
 
+// EthereumSecondaryURLs are the URLs of the Ethereum nodes Chainlink falls
+// back to when the node at EthereumURL is unhealthy, given as a comma
+// separated list.
+func (c Config) EthereumSecondaryURLs() []string {
+	var urls []string
+	for _, nodeURL := range strings.Split(c.viper.GetString(EnvVarName("EthereumSecondaryURLs")), ",") {
+		if nodeURL = strings.TrimSpace(nodeURL); nodeURL != "" {
+			urls = append(urls, nodeURL)
+		}
+	}
+	return urls
+}
+
+// EthHealthCheckInterval is how often the health of each Ethereum node is
+// checked, when secondary nodes are configured. Zero disables health checks.
+func (c Config) EthHealthCheckInterval() models.Duration {
+	return c.getDuration("EthHealthCheckInterval")
+}
+
+// EthRPCQuorum is the number of Ethereum nodes which must agree on the
+// result of critical calls, such as fetching transaction receipts, for it
+// to be used.
+func (c Config) EthRPCQuorum() uint16 {
+	return c.getWithFallback("EthRPCQuorum", parseUint16).(uint16)
+}
+
 // EthereumDisabled shows whether Ethereum interactions are supported.
 func (c Config) EthereumDisabled() bool {
 	return c.viper.GetBool(EnvVarName("EthereumDisabled"))
@@ -325,6 +441,24 @@ func (c Config) GasUpdaterEnabled() bool {
 	return c.viper.GetBool(EnvVarName("GasUpdaterEnabled"))
 }
 
+// HTTPHostLimits are the limits of the requests sent to particular hosts,
+// which override HTTPHostMaxInFlight and HTTPHostRequestsPerSecond.
+func (c Config) HTTPHostLimits() HTTPHostLimits {
+	return c.getWithFallback("HTTPHostLimits", parseHTTPHostLimits).(HTTPHostLimits)
+}
+
+// HTTPHostMaxInFlight is the maximum number of requests sent by adapters
+// and the flux monitor to a host at the same time, or 0 for no limit.
+func (c Config) HTTPHostMaxInFlight() uint64 {
+	return c.viper.GetUint64(EnvVarName("HTTPHostMaxInFlight"))
+}
+
+// HTTPHostRequestsPerSecond is the maximum rate of the requests sent by
+// adapters and the flux monitor to a host, or 0 for no limit.
+func (c Config) HTTPHostRequestsPerSecond() float64 {
+	return c.viper.GetFloat64(EnvVarName("HTTPHostRequestsPerSecond"))
+}
+
 // JSONConsole enables the JSON console.
 func (c Config) JSONConsole() bool {
 	return c.viper.GetBool(EnvVarName("JSONConsole"))
@@ -426,6 +560,18 @@ func (c Config) ReplayFromBlock() int64 {
 	return c.viper.GetInt64(EnvVarName("ReplayFromBlock"))
 }
 
+// ResponseCacheMaxEntrySize is the size in bytes of the largest adapter
+// response which is cached.
+func (c Config) ResponseCacheMaxEntrySize() int64 {
+	return c.viper.GetInt64(EnvVarName("ResponseCacheMaxEntrySize"))
+}
+
+// ResponseCacheMaxSize is the total size in bytes of the cached adapter
+// responses, above which the least recently used are evicted.
+func (c Config) ResponseCacheMaxSize() int64 {
+	return c.viper.GetInt64(EnvVarName("ResponseCacheMaxSize"))
+}
+
 // RootDir represents the location on the file system where Chainlink should
 // keep its files.
 func (c Config) RootDir() string {
@@ -465,6 +611,12 @@ func (c Config) TLSPort() uint16 {
 	return c.getWithFallback("TLSPort", parseUint16).(uint16)
 }
 
+// TaskRetryPollInterval is how often runs with tasks waiting to be retried
+// are checked for retries that are due, and so bounds how late a retry can be.
+func (c Config) TaskRetryPollInterval() models.Duration {
+	return c.getDuration("TaskRetryPollInterval")
+}
+
 // TxAttemptLimit is the maximum number of transaction attempts (gas bumps)
 // that will occur before giving a transaction up as errored
 // NOTE: That initial transactions are retried forever until they succeed
@@ -477,6 +629,23 @@ func (c Config) TLSRedirect() bool {
 	return c.viper.GetBool(EnvVarName("TLSRedirect"))
 }
 
+// WasmFuelLimit is the number of instructions a wasm module can run in one
+// run of the wasm adapter.
+func (c Config) WasmFuelLimit() uint64 {
+	return c.viper.GetUint64(EnvVarName("WasmFuelLimit"))
+}
+
+// WasmMemoryLimitPages is the number of 64KiB pages of memory a wasm module
+// can use.
+func (c Config) WasmMemoryLimitPages() uint32 {
+	return c.viper.GetUint32(EnvVarName("WasmMemoryLimitPages"))
+}
+
+// WasmTimeout is the time one run of a wasm module can take.
+func (c Config) WasmTimeout() models.Duration {
+	return c.getDuration("WasmTimeout")
+}
+
 // KeysDir returns the path of the keys directory (used for keystore files).
 func (c Config) KeysDir() string {
 	return filepath.Join(c.RootDir(), "tempkeys")
@@ -627,6 +796,67 @@ func parseHomeDir(str string) (interface{}, error) {
 	return filepath.ToSlash(exp), nil
 }
 
+// EthTxType is the type of Ethereum transaction sent by the node.
+type EthTxType string
+
+const (
+	// EthTxTypeLegacy transactions pay a fixed gas price.
+	EthTxTypeLegacy = EthTxType("legacy")
+	// EthTxTypeDynamicFee transactions are EIP-1559 transactions, which pay
+	// the block's base fee plus a tip, up to a fee cap.
+	EthTxTypeDynamicFee = EthTxType("dynamicfee")
+)
+
+// HTTPHostLimit limits the requests sent to a host. A limit of 0 means no
+// limit.
+type HTTPHostLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	MaxInFlight       uint64  `json:"maxInFlight"`
+}
+
+// HTTPHostLimits maps host names to the limits of the requests sent to them.
+type HTTPHostLimits map[string]HTTPHostLimit
+
+// parseHTTPHostLimits parses a comma separated list of limits of the form
+// host=requestsPerSecond/maxInFlight, such as
+// "api.example.com=10/4,other.example.com=0.5/1".
+func parseHTTPHostLimits(str string) (interface{}, error) {
+	limits := HTTPHostLimits{}
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return HTTPHostLimits{}, fmt.Errorf("invalid host limit %q, must be host=requestsPerSecond/maxInFlight", entry)
+		}
+		values := strings.Split(parts[1], "/")
+		if len(values) != 2 {
+			return HTTPHostLimits{}, fmt.Errorf("invalid host limit %q, must be host=requestsPerSecond/maxInFlight", entry)
+		}
+		rps, err := strconv.ParseFloat(values[0], 64)
+		if err != nil || rps < 0 {
+			return HTTPHostLimits{}, fmt.Errorf("invalid requests per second in host limit %q", entry)
+		}
+		maxInFlight, err := strconv.ParseUint(values[1], 10, 64)
+		if err != nil {
+			return HTTPHostLimits{}, fmt.Errorf("invalid max in flight in host limit %q", entry)
+		}
+		limits[strings.ToLower(parts[0])] = HTTPHostLimit{RequestsPerSecond: rps, MaxInFlight: maxInFlight}
+	}
+	return limits, nil
+}
+
+func parseEthTxType(str string) (interface{}, error) {
+	switch t := EthTxType(str); t {
+	case EthTxTypeLegacy, EthTxTypeDynamicFee:
+		return t, nil
+	default:
+		return EthTxTypeLegacy, fmt.Errorf("unknown transaction type %q, must be %q or %q", str, EthTxTypeLegacy, EthTxTypeDynamicFee)
+	}
+}
+
 // LogLevel determines the verbosity of the events to be logged.
 type LogLevel struct {
 	zapcore.Level
@@ -16,6 +16,9 @@ import (
 type ConfigReader interface {
 	AllowOrigins() string
 	BlockBackfillDepth() uint64
+	BridgeCircuitBreakerCooldown() models.Duration
+	BridgeCircuitBreakerThreshold() uint16
+	BridgeHealthCheckInterval() models.Duration
 	BridgeResponseURL() *url.URL
 	ChainID() *big.Int
 	ClientNodeURL() string
@@ -27,6 +30,7 @@ type ConfigReader interface {
 	Dev() bool
 	FeatureExternalInitiators() bool
 	FeatureFluxMonitor() bool
+	FluxMonitorRoundHistory() models.Duration
 	MaximumServiceDuration() models.Duration
 	MinimumServiceDuration() models.Duration
 	EnableExperimentalAdapters() bool
@@ -37,10 +41,23 @@ type ConfigReader interface {
 	EthGasPriceDefault() *big.Int
 	EthMaxGasPriceWei() *big.Int
 	SetEthGasPriceDefault(value *big.Int) error
+	EthGasFeeCapDefault() *big.Int
+	EthGasTipCapBumpPercent() uint16
+	EthGasTipCapBumpWei() *big.Int
+	EthGasTipCapDefault() *big.Int
+	SetEthGasTipCapDefault(value *big.Int) error
+	EthTxType() EthTxType
+	EthSignerURL() string
 	EthereumURL() string
+	EthereumSecondaryURLs() []string
+	EthHealthCheckInterval() models.Duration
+	EthRPCQuorum() uint16
 	GasUpdaterBlockDelay() uint16
 	GasUpdaterBlockHistorySize() uint16
 	GasUpdaterTransactionPercentile() uint16
+	HTTPHostLimits() HTTPHostLimits
+	HTTPHostMaxInFlight() uint64
+	HTTPHostRequestsPerSecond() float64
 	JSONConsole() bool
 	LinkContractAddress() string
 	ExplorerURL() *url.URL
@@ -57,6 +74,8 @@ type ConfigReader interface {
 	MigrateDatabase() bool
 	Port() uint16
 	ReaperExpiration() models.Duration
+	ResponseCacheMaxEntrySize() int64
+	ResponseCacheMaxSize() int64
 	RootDir() string
 	SecureCookies() bool
 	SessionTimeout() models.Duration
@@ -65,7 +84,11 @@ type ConfigReader interface {
 	TLSKeyPath() string
 	TLSPort() uint16
 	TLSRedirect() bool
+	TaskRetryPollInterval() models.Duration
 	TxAttemptLimit() uint16
+	WasmFuelLimit() uint64
+	WasmMemoryLimitPages() uint32
+	WasmTimeout() models.Duration
 	KeysDir() string
 	tlsDir() string
 	KeyFile() string
@@ -164,3 +164,40 @@ func TestStore_urlParser(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_httpHostLimitsParser(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      HTTPHostLimits
+		wantError bool
+	}{
+		{"empty", "", HTTPHostLimits{}, false},
+		{"one host", "api.example.com=10/4", HTTPHostLimits{
+			"api.example.com": {RequestsPerSecond: 10, MaxInFlight: 4},
+		}, false},
+		{"several hosts", " API.example.com=0.5/0, other.example.com=0/2 ", HTTPHostLimits{
+			"api.example.com":   {RequestsPerSecond: 0.5},
+			"other.example.com": {MaxInFlight: 2},
+		}, false},
+		{"no limits", "api.example.com", nil, true},
+		{"one limit", "api.example.com=10", nil, true},
+		{"no host", "=10/4", nil, true},
+		{"invalid rate", "api.example.com=fast/4", nil, true},
+		{"negative rate", "api.example.com=-1/4", nil, true},
+		{"invalid max in flight", "api.example.com=10/-4", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limits, err := parseHTTPHostLimits(test.input)
+
+			if test.wantError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, limits)
+			}
+		})
+	}
+}
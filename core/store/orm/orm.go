@@ -24,6 +24,7 @@ import (
 	_ "github.com/jinzhu/gorm/dialects/postgres" // http://doc.gorm.io/database.html#connecting-to-a-database
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
+	null "gopkg.in/guregu/null.v3"
 )
 
 var (
@@ -182,7 +183,9 @@ func (orm *ORM) preloadJobs() *gorm.DB {
 			return db.Unscoped().Order(`"id" asc`)
 		}).
 		Preload("Tasks", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped().Order("id asc")
+			return db.Unscoped().
+				Where("job_spec_version = (SELECT version FROM job_specs WHERE job_specs.id = task_specs.job_spec_id)").
+				Order("id asc")
 		})
 }
 
@@ -466,11 +469,94 @@ func (orm *ORM) CreateJob(job *models.JobSpec) error {
 
 func (orm *ORM) createJob(tx *gorm.DB, job *models.JobSpec) error {
 	orm.MustEnsureAdvisoryLock()
+	if job.Version == 0 {
+		job.Version = 1
+	}
 	for i := range job.Initiators {
 		job.Initiators[i].JobSpecID = job.ID
 	}
+	for i := range job.Tasks {
+		job.Tasks[i].JobSpecVersion = job.Version
+	}
 
-	return tx.Create(job).Error
+	if err := tx.Create(job).Error; err != nil {
+		return err
+	}
+	return createJobSpecVersion(tx, *job)
+}
+
+func createJobSpecVersion(tx *gorm.DB, job models.JobSpec) error {
+	version, err := models.NewJobSpecVersion(job)
+	if err != nil {
+		return errors.Wrap(err, "failed to snapshot job spec version")
+	}
+	return tx.
+		Where(models.JobSpecVersion{JobSpecID: job.ID, Version: job.Version}).
+		FirstOrCreate(&version).Error
+}
+
+// UpdateJob saves the tasks, schedule and minimum payment of the given job
+// as a new version of the existing job with the same ID. The tasks of the
+// previous version are soft deleted, but remain available to the runs that
+// were started with them.
+func (orm *ORM) UpdateJob(job *models.JobSpec) error {
+	orm.MustEnsureAdvisoryLock()
+	current, err := orm.FindJob(job.ID)
+	if err != nil {
+		return err
+	}
+
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		// Jobs created before versioning have no snapshot of their first version
+		if err := createJobSpecVersion(dbtx, current); err != nil {
+			return err
+		}
+
+		result := dbtx.Model(&models.JobSpec{}).
+			Where("id = ? AND version = ?", job.ID, current.Version).
+			Updates(map[string]interface{}{
+				"version":     current.Version + 1,
+				"start_at":    job.StartAt,
+				"end_at":      job.EndAt,
+				"min_payment": job.MinPayment,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOptimisticUpdateConflict
+		}
+
+		err := dbtx.Exec("UPDATE task_specs SET deleted_at = NOW() WHERE job_spec_id = ? AND deleted_at IS NULL", job.ID).Error
+		if err != nil {
+			return err
+		}
+
+		job.Version = current.Version + 1
+		job.CreatedAt = current.CreatedAt
+		job.Initiators = current.Initiators
+		for i := range job.Tasks {
+			job.Tasks[i].ID = 0
+			job.Tasks[i].JobSpecID = job.ID
+			job.Tasks[i].JobSpecVersion = job.Version
+			if err := dbtx.Create(&job.Tasks[i]).Error; err != nil {
+				return err
+			}
+		}
+		return createJobSpecVersion(dbtx, *job)
+	})
+}
+
+// JobSpecVersions returns the snapshots of every version of a job, oldest
+// first.
+func (orm *ORM) JobSpecVersions(jobSpecID *models.ID) ([]models.JobSpecVersion, error) {
+	orm.MustEnsureAdvisoryLock()
+	var versions []models.JobSpecVersion
+	err := orm.db.
+		Where("job_spec_id = ?", jobSpecID).
+		Order("version asc").
+		Find(&versions).Error
+	return versions, err
 }
 
 // ArchiveJob soft deletes the job, job_runs and its initiator.
@@ -519,6 +605,34 @@ func (orm *ORM) UnscopedJobRunsWithStatus(cb func(*models.JobRun), statuses ...m
 		return errors.Wrap(err, "finding job ids")
 	}
 
+	return orm.unscopedJobRunsInBatches(runIDs, cb)
+}
+
+// UnscopedJobRunsDueForRetry passes the sleeping JobRuns with a task due to
+// be retried by the given time to a callback, one by one, including those
+// that were soft deleted.
+func (orm *ORM) UnscopedJobRunsDueForRetry(cb func(*models.JobRun), now time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	var runIDs []string
+	err := orm.db.Unscoped().
+		Table("job_runs").
+		Where("job_runs.status = ?", models.RunStatusPendingSleep).
+		Where(`EXISTS (
+			SELECT 1 FROM task_runs
+			WHERE task_runs.job_run_id = job_runs.id
+			AND task_runs.status = ?
+			AND task_runs.retry_at <= ?
+		)`, models.RunStatusPendingSleep, now).
+		Order("created_at asc").
+		Pluck("ID", &runIDs).Error
+	if err != nil {
+		return errors.Wrap(err, "finding job ids")
+	}
+
+	return orm.unscopedJobRunsInBatches(runIDs, cb)
+}
+
+func (orm *ORM) unscopedJobRunsInBatches(runIDs []string, cb func(*models.JobRun)) error {
 	return Batch(BatchSize, func(offset, limit uint) (uint, error) {
 		batchIDs := runIDs[offset:utils.MinUint(limit, uint(len(runIDs)))]
 		var runs []models.JobRun
@@ -588,12 +702,16 @@ func (orm *ORM) AddTxAttempt(tx *models.Tx, newTxAttempt *models.Tx) (*models.Tx
 	tx.From = newTxAttempt.From
 	tx.Nonce = newTxAttempt.Nonce
 	tx.GasPrice = newTxAttempt.GasPrice
+	tx.GasTipCap = newTxAttempt.GasTipCap
+	tx.GasFeeCap = newTxAttempt.GasFeeCap
 	tx.Hash = newTxAttempt.Hash
 	tx.SentAt = newTxAttempt.SentAt
 	tx.SignedRawTx = newTxAttempt.SignedRawTx
 	txAttempt := &models.TxAttempt{
 		Hash:        newTxAttempt.Hash,
 		GasPrice:    newTxAttempt.GasPrice,
+		GasTipCap:   newTxAttempt.GasTipCap,
+		GasFeeCap:   newTxAttempt.GasFeeCap,
 		SentAt:      newTxAttempt.SentAt,
 		SignedRawTx: newTxAttempt.SignedRawTx,
 	}
@@ -611,6 +729,8 @@ func (orm *ORM) MarkTxSafe(tx *models.Tx, txAttempt *models.TxAttempt) error {
 	txAttempt.Confirmed = true
 	tx.Hash = txAttempt.Hash
 	tx.GasPrice = txAttempt.GasPrice
+	tx.GasTipCap = txAttempt.GasTipCap
+	tx.GasFeeCap = txAttempt.GasFeeCap
 	tx.Confirmed = txAttempt.Confirmed
 	tx.SentAt = txAttempt.SentAt
 	tx.SignedRawTx = txAttempt.SignedRawTx
@@ -706,6 +826,12 @@ func (orm *ORM) MarkRan(i *models.Initiator, ran bool) error {
 	})
 }
 
+// MarkFired records the time at which a cron initiator last fired.
+func (orm *ORM) MarkFired(i *models.Initiator, at time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Model(&models.Initiator{}).Where("id = ?", i.ID).UpdateColumn("last_fired_at", at).Error
+}
+
 // FindUser will return the one API user, or an error.
 func (orm *ORM) FindUser() (models.User, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -946,18 +1072,109 @@ func (orm *ORM) SaveTx(tx *models.Tx) error {
 	return orm.db.Save(tx).Error
 }
 
+// HealthCheckedBridgeTypes returns the bridge types with a health URL.
+func (orm *ORM) HealthCheckedBridgeTypes() ([]models.BridgeType, error) {
+	orm.MustEnsureAdvisoryLock()
+	var bridges []models.BridgeType
+	return bridges, orm.db.Where("health_url IS NOT NULL AND health_url != ''").Order("name asc").Find(&bridges).Error
+}
+
 // CreateBridgeType saves the bridge type.
 func (orm *ORM) CreateBridgeType(bt *models.BridgeType) error {
 	orm.MustEnsureAdvisoryLock()
 	return orm.db.Create(bt).Error
 }
 
+// CreateWasmModule saves the wasm module.
+func (orm *ORM) CreateWasmModule(module *models.WasmModule) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(module).Error
+}
+
+// FindWasmModule looks up a wasm module by its name.
+func (orm *ORM) FindWasmModule(name models.TaskType) (models.WasmModule, error) {
+	orm.MustEnsureAdvisoryLock()
+	var module models.WasmModule
+	return module, orm.db.First(&module, "name = ?", name.String()).Error
+}
+
+// WasmModules returns a page of wasm modules, and the total count.
+func (orm *ORM) WasmModules(offset int, limit int) ([]models.WasmModule, int, error) {
+	orm.MustEnsureAdvisoryLock()
+	count, err := orm.CountOf(&models.WasmModule{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var modules []models.WasmModule
+	err = orm.getRecords(&modules, "name asc", offset, limit)
+	return modules, count, err
+}
+
+// DeleteWasmModule removes the wasm module.
+func (orm *ORM) DeleteWasmModule(module *models.WasmModule) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Delete(module).Error
+}
+
+// CreateSecret saves the secret.
+func (orm *ORM) CreateSecret(secret *models.Secret) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(secret).Error
+}
+
+// UpdateSecret saves the new encrypted value of the secret.
+func (orm *ORM) UpdateSecret(secret *models.Secret) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Save(secret).Error
+}
+
+// FindSecret looks up a secret by its name.
+func (orm *ORM) FindSecret(name string) (models.Secret, error) {
+	orm.MustEnsureAdvisoryLock()
+	var secret models.Secret
+	return secret, orm.db.First(&secret, "name = ?", name).Error
+}
+
+// Secrets returns a page of secrets, and the total count.
+func (orm *ORM) Secrets(offset int, limit int) ([]models.Secret, int, error) {
+	orm.MustEnsureAdvisoryLock()
+	count, err := orm.CountOf(&models.Secret{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var secrets []models.Secret
+	err = orm.getRecords(&secrets, "name asc", offset, limit)
+	return secrets, count, err
+}
+
+// AllSecrets returns all of the secrets.
+func (orm *ORM) AllSecrets() ([]models.Secret, error) {
+	orm.MustEnsureAdvisoryLock()
+	var secrets []models.Secret
+	return secrets, orm.db.Order("name asc").Find(&secrets).Error
+}
+
+// DeleteSecret removes the secret.
+func (orm *ORM) DeleteSecret(secret *models.Secret) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Delete(secret).Error
+}
+
 // UpdateBridgeType updates the bridge type.
 func (orm *ORM) UpdateBridgeType(bt *models.BridgeType, btr *models.BridgeTypeRequest) error {
 	orm.MustEnsureAdvisoryLock()
 	bt.URL = btr.URL
 	bt.Confirmations = btr.Confirmations
 	bt.MinimumContractPayment = btr.MinimumContractPayment
+	bt.SigningEnabled = btr.SigningEnabled
+	bt.HealthURL = btr.HealthURL
+	bt.Fallbacks = btr.Fallbacks
+	bt.PendWhenUnhealthy = btr.PendWhenUnhealthy
+	if bt.SigningEnabled && (bt.SigningSecret == "" || btr.RotateSigningSecret) {
+		bt.SigningSecret = utils.NewSecret(32)
+	}
 	return orm.db.Save(bt).Error
 }
 
@@ -1199,6 +1416,87 @@ func (orm *ORM) IncrFluxMonitorRoundSubmissions(aggregator common.Address, round
     `, aggregator, roundID).Error
 }
 
+// CreateFluxMonitorRoundEvent records a decision of the flux monitor.
+func (orm *ORM) CreateFluxMonitorRoundEvent(event *models.FluxMonitorRoundEvent) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(event).Error
+}
+
+// FluxMonitorRoundEvents returns a page of the decisions of the flux monitor
+// for a job, newest first, and their total count. A non-zero aggregator only
+// returns the decisions about its rounds, and a non-zero roundID only those
+// about that round.
+func (orm *ORM) FluxMonitorRoundEvents(jobSpecID *models.ID, aggregator common.Address, roundID uint32, offset, limit int) ([]models.FluxMonitorRoundEvent, int, error) {
+	orm.MustEnsureAdvisoryLock()
+	scope := orm.db.Model(&models.FluxMonitorRoundEvent{}).Where("job_spec_id = ?", jobSpecID)
+	if aggregator != utils.ZeroAddress {
+		scope = scope.Where("aggregator = ?", aggregator)
+	}
+	if roundID != 0 {
+		scope = scope.Where("round_id = ?", roundID)
+	}
+
+	var count int
+	if err := scope.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []models.FluxMonitorRoundEvent
+	err := scope.
+		Order("created_at desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error
+	return events, count, err
+}
+
+// FindFluxMonitorRoundEvent looks up a decision of the flux monitor for a job
+// by its ID.
+func (orm *ORM) FindFluxMonitorRoundEvent(jobSpecID *models.ID, id int64) (models.FluxMonitorRoundEvent, error) {
+	orm.MustEnsureAdvisoryLock()
+	var event models.FluxMonitorRoundEvent
+	return event, orm.db.First(&event, "id = ? AND job_spec_id = ?", id, jobSpecID).Error
+}
+
+// ApproveFluxMonitorRoundEvent records the approval of a held answer by an
+// operator.
+func (orm *ORM) ApproveFluxMonitorRoundEvent(event *models.FluxMonitorRoundEvent) error {
+	orm.MustEnsureAdvisoryLock()
+	event.ApprovedAt = null.TimeFrom(time.Now())
+	return orm.db.Model(event).UpdateColumn("approved_at", event.ApprovedAt).Error
+}
+
+// FluxMonitorApprovals returns the held answers of a job for an aggregator
+// approved since the given time which have not been used to submit an answer
+// yet, newest first.
+func (orm *ORM) FluxMonitorApprovals(jobSpecID *models.ID, aggregator common.Address, approvedSince time.Time) ([]models.FluxMonitorRoundEvent, error) {
+	orm.MustEnsureAdvisoryLock()
+	var events []models.FluxMonitorRoundEvent
+	err := orm.db.
+		Where("job_spec_id = ? AND aggregator = ? AND approved_at >= ? AND approval_used_at IS NULL", jobSpecID, aggregator, approvedSince).
+		Order("approved_at desc, id desc").
+		Find(&events).Error
+	return events, err
+}
+
+// UseFluxMonitorApproval records that an answer was submitted thanks to the
+// approval of the held answer with the ID, so that it is not used again.
+func (orm *ORM) UseFluxMonitorApproval(id int64) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Model(&models.FluxMonitorRoundEvent{}).
+		Where("id = ?", id).
+		UpdateColumn("approval_used_at", time.Now()).Error
+}
+
+// DeleteFluxMonitorRoundEventsBefore removes the decisions of the flux
+// monitor for a job recorded before the given time.
+func (orm *ORM) DeleteFluxMonitorRoundEventsBefore(jobSpecID *models.ID, before time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.
+		Where("job_spec_id = ? AND created_at < ?", jobSpecID, before).
+		Delete(&models.FluxMonitorRoundEvent{}).Error
+}
+
 // ClobberDiskKeyStoreWithDBKeys writes all keys stored in the orm to
 // the keys folder on disk, deleting anything there prior.
 func (orm *ORM) ClobberDiskKeyStoreWithDBKeys(keysDir string) error {
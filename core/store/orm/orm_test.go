@@ -1,6 +1,7 @@
 package orm_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -487,6 +488,35 @@ func TestORM_UnscopedJobRunsWithStatus_OrdersByCreatedAt(t *testing.T) {
 	assert.Equal(t, runs[1].ID, newPending.ID)
 }
 
+func TestORM_UnscopedJobRunsDueForRetry(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	j := cltest.NewJobWithWebInitiator()
+	assert.NoError(t, store.CreateJob(&j))
+
+	now := time.Now()
+	createSleeping := func(retryAt time.Time) models.JobRun {
+		run := cltest.NewJobRun(j)
+		run.TaskRuns[0].ScheduleRetry(errors.New("server error"), retryAt)
+		run.SetStatus(models.RunStatusPendingSleep)
+		require.NoError(t, store.CreateJobRun(&run))
+		return run
+	}
+	due := createSleeping(now.Add(-time.Second))
+	createSleeping(now.Add(time.Hour))
+	inProgress := cltest.NewJobRun(j)
+	require.NoError(t, store.CreateJobRun(&inProgress))
+
+	var runIDs []*models.ID
+	err := store.UnscopedJobRunsDueForRetry(func(run *models.JobRun) {
+		runIDs = append(runIDs, run.ID)
+	}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.ID{due.ID}, runIDs)
+}
+
 func TestORM_AnyJobWithType(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,9 @@ import (
 type ConfigSchema struct {
 	AllowOrigins                    string          `env:"ALLOW_ORIGINS" default:"http://localhost:3000,http://localhost:6688"`
 	BlockBackfillDepth              string          `env:"BLOCK_BACKFILL_DEPTH" default:"10"`
+	BridgeCircuitBreakerCooldown    models.Duration `env:"BRIDGE_CIRCUIT_BREAKER_COOLDOWN" default:"1m"`
+	BridgeCircuitBreakerThreshold   uint16          `env:"BRIDGE_CIRCUIT_BREAKER_THRESHOLD" default:"5"`
+	BridgeHealthCheckInterval       models.Duration `env:"BRIDGE_HEALTH_CHECK_INTERVAL" default:"30s"`
 	BridgeResponseURL               url.URL         `env:"BRIDGE_RESPONSE_URL"`
 	ChainID                         big.Int         `env:"ETH_CHAIN_ID" default:"1"`
 	ClientNodeURL                   string          `env:"CLIENT_NODE_URL" default:"http://localhost:6688"`
@@ -27,6 +30,7 @@ type ConfigSchema struct {
 	EnableExperimentalAdapters      bool            `env:"ENABLE_EXPERIMENTAL_ADAPTERS" default:"false"`
 	FeatureExternalInitiators       bool            `env:"FEATURE_EXTERNAL_INITIATORS" default:"false"`
 	FeatureFluxMonitor              bool            `env:"FEATURE_FLUX_MONITOR" default:"false"`
+	FluxMonitorRoundHistory         models.Duration `env:"FLUX_MONITOR_ROUND_HISTORY" default:"168h"`
 	MaximumServiceDuration          models.Duration `env:"MAXIMUM_SERVICE_DURATION" default:"8760h" `
 	MinimumServiceDuration          models.Duration `env:"MINIMUM_SERVICE_DURATION" default:"0s" `
 	EthGasBumpThreshold             uint64          `env:"ETH_GAS_BUMP_THRESHOLD" default:"12" `
@@ -35,12 +39,24 @@ type ConfigSchema struct {
 	EthGasLimitDefault              uint64          `env:"ETH_GAS_LIMIT_DEFAULT" default:"500000"`
 	EthGasPriceDefault              big.Int         `env:"ETH_GAS_PRICE_DEFAULT" default:"20000000000"`
 	EthMaxGasPriceWei               uint64          `env:"ETH_MAX_GAS_PRICE_WEI" default:"500000000000"`
+	EthGasFeeCapDefault             big.Int         `env:"ETH_GAS_FEE_CAP_DEFAULT" default:"100000000000"`
+	EthGasTipCapBumpPercent         uint16          `env:"ETH_GAS_TIP_CAP_BUMP_PERCENT" default:"10"`
+	EthGasTipCapBumpWei             big.Int         `env:"ETH_GAS_TIP_CAP_BUMP_WEI" default:"1000000000"`
+	EthGasTipCapDefault             big.Int         `env:"ETH_GAS_TIP_CAP_DEFAULT" default:"1000000000"`
+	EthTxType                       EthTxType       `env:"ETH_TX_TYPE" default:"legacy"`
+	EthSignerURL                    string          `env:"ETH_SIGNER_URL"`
 	EthereumURL                     string          `env:"ETH_URL" default:"ws://localhost:8546"`
+	EthereumSecondaryURLs           string          `env:"ETH_SECONDARY_URLS"`
+	EthHealthCheckInterval          models.Duration `env:"ETH_HEALTH_CHECK_INTERVAL" default:"15s"`
+	EthRPCQuorum                    uint16          `env:"ETH_RPC_QUORUM" default:"1"`
 	EthereumDisabled                bool            `env:"ETH_DISABLED" default:"false"`
 	GasUpdaterBlockDelay            uint16          `env:"GAS_UPDATER_BLOCK_DELAY" default:"3"`
 	GasUpdaterBlockHistorySize      uint16          `env:"GAS_UPDATER_BLOCK_HISTORY_SIZE" default:"24"`
 	GasUpdaterTransactionPercentile uint16          `env:"GAS_UPDATER_TRANSACTION_PERCENTILE" default:"60"`
 	GasUpdaterEnabled               bool            `env:"GAS_UPDATER_ENABLED" default:"false"`
+	HTTPHostLimits                  HTTPHostLimits  `env:"HTTP_HOST_LIMITS" default:""`
+	HTTPHostMaxInFlight             uint64          `env:"HTTP_HOST_MAX_IN_FLIGHT" default:"0"`
+	HTTPHostRequestsPerSecond       float64         `env:"HTTP_HOST_REQUESTS_PER_SECOND" default:"0"`
 	JSONConsole                     bool            `env:"JSON_CONSOLE" default:"false"`
 	LinkContractAddress             string          `env:"LINK_CONTRACT_ADDRESS" default:"0x514910771AF9Ca656af840dff83E8264EcF986CA"`
 	ExplorerURL                     *url.URL        `env:"EXPLORER_URL"`
@@ -61,6 +77,8 @@ type ConfigSchema struct {
 	Port                            uint16          `env:"CHAINLINK_PORT" default:"6688"`
 	ReaperExpiration                models.Duration `env:"REAPER_EXPIRATION" default:"240h"`
 	ReplayFromBlock                 int64           `env:"REPLAY_FROM_BLOCK" default:"-1"`
+	ResponseCacheMaxEntrySize       int64           `env:"RESPONSE_CACHE_MAX_ENTRY_SIZE" default:"32768"`
+	ResponseCacheMaxSize            int64           `env:"RESPONSE_CACHE_MAX_SIZE" default:"10485760"`
 	RootDir                         string          `env:"ROOT" default:"~/.chainlink"`
 	SecureCookies                   bool            `env:"SECURE_COOKIES" default:"true"`
 	SessionTimeout                  models.Duration `env:"SESSION_TIMEOUT" default:"15m"`
@@ -69,7 +87,11 @@ type ConfigSchema struct {
 	TLSKeyPath                      string          `env:"TLS_KEY_PATH" `
 	TLSPort                         uint16          `env:"CHAINLINK_TLS_PORT" default:"6689"`
 	TLSRedirect                     bool            `env:"CHAINLINK_TLS_REDIRECT" default:"false"`
+	TaskRetryPollInterval           models.Duration `env:"TASK_RETRY_POLL_INTERVAL" default:"1s"`
 	TxAttemptLimit                  uint16          `env:"CHAINLINK_TX_ATTEMPT_LIMIT" default:"10"`
+	WasmFuelLimit                   uint64          `env:"WASM_FUEL_LIMIT" default:"10000000"`
+	WasmMemoryLimitPages            uint32          `env:"WASM_MEMORY_LIMIT_PAGES" default:"16"`
+	WasmTimeout                     models.Duration `env:"WASM_TIMEOUT" default:"1s"`
 }
 
 // EnvVarName gets the environment variable name for a config schema field
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/auth"
@@ -125,39 +127,53 @@ type ConfigWhitelist struct {
 
 // Whitelist contains the supported environment variables
 type Whitelist struct {
-	AllowOrigins             string          `json:"allowOrigins"`
-	BlockBackfillDepth       uint64          `json:"blockBackfillDepth"`
-	BridgeResponseURL        string          `json:"bridgeResponseURL,omitempty"`
-	ChainID                  *big.Int        `json:"ethChainId"`
-	ClientNodeURL            string          `json:"clientNodeUrl"`
-	DatabaseTimeout          models.Duration `json:"databaseTimeout"`
-	Dev                      bool            `json:"chainlinkDev"`
-	EthereumURL              string          `json:"ethUrl"`
-	EthGasBumpThreshold      uint64          `json:"ethGasBumpThreshold"`
-	EthGasBumpWei            *big.Int        `json:"ethGasBumpWei"`
-	EthGasPriceDefault       *big.Int        `json:"ethGasPriceDefault"`
-	ExplorerURL              string          `json:"explorerUrl"`
-	JSONConsole              bool            `json:"jsonConsole"`
-	LinkContractAddress      string          `json:"linkContractAddress"`
-	LogLevel                 orm.LogLevel    `json:"logLevel"`
-	LogSQLMigrations         bool            `json:"logSqlMigrations"`
-	LogSQLStatements         bool            `json:"logSqlStatements"`
-	LogToDisk                bool            `json:"logToDisk"`
-	MaxRPCCallsPerSecond     uint64          `json:"maxRPCCallsPerSecond"`
-	MinimumContractPayment   *assets.Link    `json:"minimumContractPayment"`
-	MinimumRequestExpiration uint64          `json:"minimumRequestExpiration"`
-	MinIncomingConfirmations uint32          `json:"minIncomingConfirmations"`
-	MinOutgoingConfirmations uint64          `json:"minOutgoingConfirmations"`
-	OracleContractAddress    *common.Address `json:"oracleContractAddress"`
-	Port                     uint16          `json:"chainlinkPort"`
-	ReaperExpiration         models.Duration `json:"reaperExpiration"`
-	ReplayFromBlock          int64           `json:"replayFromBlock"`
-	RootDir                  string          `json:"root"`
-	SessionTimeout           models.Duration `json:"sessionTimeout"`
-	TLSHost                  string          `json:"chainlinkTLSHost"`
-	TLSPort                  uint16          `json:"chainlinkTLSPort"`
-	TLSRedirect              bool            `json:"chainlinkTLSRedirect"`
-	TxAttemptLimit           uint16          `json:"txAttemptLimit"`
+	AllowOrigins              string             `json:"allowOrigins"`
+	BlockBackfillDepth        uint64             `json:"blockBackfillDepth"`
+	BridgeResponseURL         string             `json:"bridgeResponseURL,omitempty"`
+	ChainID                   *big.Int           `json:"ethChainId"`
+	ClientNodeURL             string             `json:"clientNodeUrl"`
+	DatabaseTimeout           models.Duration    `json:"databaseTimeout"`
+	Dev                       bool               `json:"chainlinkDev"`
+	EthereumURL               string             `json:"ethUrl"`
+	EthGasBumpThreshold       uint64             `json:"ethGasBumpThreshold"`
+	EthGasBumpWei             *big.Int           `json:"ethGasBumpWei"`
+	EthGasPriceDefault        *big.Int           `json:"ethGasPriceDefault"`
+	EthGasFeeCapDefault       *big.Int           `json:"ethGasFeeCapDefault"`
+	EthGasTipCapDefault       *big.Int           `json:"ethGasTipCapDefault"`
+	EthTxType                 orm.EthTxType      `json:"ethTxType"`
+	EthSignerURL              string             `json:"ethSignerUrl,omitempty"`
+	ExplorerURL               string             `json:"explorerUrl"`
+	FluxMonitorRoundHistory   models.Duration    `json:"fluxMonitorRoundHistory"`
+	HTTPHostLimits            orm.HTTPHostLimits `json:"httpHostLimits"`
+	HTTPHostMaxInFlight       uint64             `json:"httpHostMaxInFlight"`
+	HTTPHostRequestsPerSecond float64            `json:"httpHostRequestsPerSecond"`
+	JSONConsole               bool               `json:"jsonConsole"`
+	LinkContractAddress       string             `json:"linkContractAddress"`
+	LogLevel                  orm.LogLevel       `json:"logLevel"`
+	LogSQLMigrations          bool               `json:"logSqlMigrations"`
+	LogSQLStatements          bool               `json:"logSqlStatements"`
+	LogToDisk                 bool               `json:"logToDisk"`
+	MaxRPCCallsPerSecond      uint64             `json:"maxRPCCallsPerSecond"`
+	MinimumContractPayment    *assets.Link       `json:"minimumContractPayment"`
+	MinimumRequestExpiration  uint64             `json:"minimumRequestExpiration"`
+	MinIncomingConfirmations  uint32             `json:"minIncomingConfirmations"`
+	MinOutgoingConfirmations  uint64             `json:"minOutgoingConfirmations"`
+	OracleContractAddress     *common.Address    `json:"oracleContractAddress"`
+	Port                      uint16             `json:"chainlinkPort"`
+	ReaperExpiration          models.Duration    `json:"reaperExpiration"`
+	ReplayFromBlock           int64              `json:"replayFromBlock"`
+	ResponseCacheMaxEntrySize int64              `json:"responseCacheMaxEntrySize"`
+	ResponseCacheMaxSize      int64              `json:"responseCacheMaxSize"`
+	RootDir                   string             `json:"root"`
+	SessionTimeout            models.Duration    `json:"sessionTimeout"`
+	TLSHost                   string             `json:"chainlinkTLSHost"`
+	TLSPort                   uint16             `json:"chainlinkTLSPort"`
+	TLSRedirect               bool               `json:"chainlinkTLSRedirect"`
+	TaskRetryPollInterval     models.Duration    `json:"taskRetryPollInterval"`
+	TxAttemptLimit            uint16             `json:"txAttemptLimit"`
+	WasmFuelLimit             uint64             `json:"wasmFuelLimit"`
+	WasmMemoryLimitPages      uint32             `json:"wasmMemoryLimitPages"`
+	WasmTimeout               models.Duration    `json:"wasmTimeout"`
 }
 
 // NewConfigWhitelist creates an instance of ConfigWhitelist
@@ -175,39 +191,53 @@ func NewConfigWhitelist(store *store.Store) (ConfigWhitelist, error) {
 	return ConfigWhitelist{
 		AccountAddress: account.Address.Hex(),
 		Whitelist: Whitelist{
-			AllowOrigins:             config.AllowOrigins(),
-			BlockBackfillDepth:       config.BlockBackfillDepth(),
-			BridgeResponseURL:        config.BridgeResponseURL().String(),
-			ChainID:                  config.ChainID(),
-			ClientNodeURL:            config.ClientNodeURL(),
-			Dev:                      config.Dev(),
-			DatabaseTimeout:          config.DatabaseTimeout(),
-			EthereumURL:              config.EthereumURL(),
-			EthGasBumpThreshold:      config.EthGasBumpThreshold(),
-			EthGasBumpWei:            config.EthGasBumpWei(),
-			EthGasPriceDefault:       config.EthGasPriceDefault(),
-			JSONConsole:              config.JSONConsole(),
-			LinkContractAddress:      config.LinkContractAddress(),
-			ExplorerURL:              explorerURL,
-			LogLevel:                 config.LogLevel(),
-			LogToDisk:                config.LogToDisk(),
-			LogSQLStatements:         config.LogSQLStatements(),
-			LogSQLMigrations:         config.LogSQLMigrations(),
-			MaxRPCCallsPerSecond:     config.MaxRPCCallsPerSecond(),
-			MinimumContractPayment:   config.MinimumContractPayment(),
-			MinimumRequestExpiration: config.MinimumRequestExpiration(),
-			MinIncomingConfirmations: config.MinIncomingConfirmations(),
-			MinOutgoingConfirmations: config.MinOutgoingConfirmations(),
-			OracleContractAddress:    config.OracleContractAddress(),
-			Port:                     config.Port(),
-			ReaperExpiration:         config.ReaperExpiration(),
-			ReplayFromBlock:          config.ReplayFromBlock(),
-			RootDir:                  config.RootDir(),
-			SessionTimeout:           config.SessionTimeout(),
-			TLSHost:                  config.TLSHost(),
-			TLSPort:                  config.TLSPort(),
-			TLSRedirect:              config.TLSRedirect(),
-			TxAttemptLimit:           config.TxAttemptLimit(),
+			AllowOrigins:              config.AllowOrigins(),
+			BlockBackfillDepth:        config.BlockBackfillDepth(),
+			BridgeResponseURL:         config.BridgeResponseURL().String(),
+			ChainID:                   config.ChainID(),
+			ClientNodeURL:             config.ClientNodeURL(),
+			Dev:                       config.Dev(),
+			DatabaseTimeout:           config.DatabaseTimeout(),
+			EthereumURL:               config.EthereumURL(),
+			EthGasBumpThreshold:       config.EthGasBumpThreshold(),
+			EthGasBumpWei:             config.EthGasBumpWei(),
+			EthGasPriceDefault:        config.EthGasPriceDefault(),
+			EthGasFeeCapDefault:       config.EthGasFeeCapDefault(),
+			EthGasTipCapDefault:       config.EthGasTipCapDefault(),
+			EthTxType:                 config.EthTxType(),
+			EthSignerURL:              config.EthSignerURL(),
+			HTTPHostLimits:            config.HTTPHostLimits(),
+			HTTPHostMaxInFlight:       config.HTTPHostMaxInFlight(),
+			HTTPHostRequestsPerSecond: config.HTTPHostRequestsPerSecond(),
+			JSONConsole:               config.JSONConsole(),
+			LinkContractAddress:       config.LinkContractAddress(),
+			ExplorerURL:               explorerURL,
+			FluxMonitorRoundHistory:   config.FluxMonitorRoundHistory(),
+			LogLevel:                  config.LogLevel(),
+			LogToDisk:                 config.LogToDisk(),
+			LogSQLStatements:          config.LogSQLStatements(),
+			LogSQLMigrations:          config.LogSQLMigrations(),
+			MaxRPCCallsPerSecond:      config.MaxRPCCallsPerSecond(),
+			MinimumContractPayment:    config.MinimumContractPayment(),
+			MinimumRequestExpiration:  config.MinimumRequestExpiration(),
+			MinIncomingConfirmations:  config.MinIncomingConfirmations(),
+			MinOutgoingConfirmations:  config.MinOutgoingConfirmations(),
+			OracleContractAddress:     config.OracleContractAddress(),
+			Port:                      config.Port(),
+			ReaperExpiration:          config.ReaperExpiration(),
+			ReplayFromBlock:           config.ReplayFromBlock(),
+			ResponseCacheMaxEntrySize: config.ResponseCacheMaxEntrySize(),
+			ResponseCacheMaxSize:      config.ResponseCacheMaxSize(),
+			RootDir:                   config.RootDir(),
+			SessionTimeout:            config.SessionTimeout(),
+			TLSHost:                   config.TLSHost(),
+			TLSPort:                   config.TLSPort(),
+			TLSRedirect:               config.TLSRedirect(),
+			TaskRetryPollInterval:     config.TaskRetryPollInterval(),
+			TxAttemptLimit:            config.TxAttemptLimit(),
+			WasmFuelLimit:             config.WasmFuelLimit(),
+			WasmMemoryLimitPages:      config.WasmMemoryLimitPages(),
+			WasmTimeout:               config.WasmTimeout(),
 		},
 	}, nil
 }
@@ -335,6 +365,75 @@ func (job JobSpec) FriendlyTasks() string {
 	return strings.Join(tasks, "\n")
 }
 
+// JobSpecDiff lists the differences between two versions of a JobSpec.
+type JobSpecDiff struct {
+	From    uint32          `json:"from"`
+	To      uint32          `json:"to"`
+	Changes []JobSpecChange `json:"changes"`
+}
+
+// JobSpecChange is a value that differs between two versions of a JobSpec,
+// identified by its path in the spec, e.g. "tasks.0.params.get". The value
+// is empty on the side of the version where it is absent.
+type JobSpecChange struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NewJobSpecDiff compares the specs of two versions of a job.
+func NewJobSpecDiff(from, to models.JobSpecVersion) JobSpecDiff {
+	before := map[string]string{}
+	flattenJSON("", from.Spec.Result, before)
+	after := map[string]string{}
+	flattenJSON("", to.Spec.Result, after)
+
+	paths := []string{}
+	for path := range before {
+		paths = append(paths, path)
+	}
+	for path := range after {
+		if _, ok := before[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := JobSpecDiff{From: from.Version, To: to.Version, Changes: []JobSpecChange{}}
+	for _, path := range paths {
+		if before[path] != after[path] {
+			diff.Changes = append(diff.Changes, JobSpecChange{path, before[path], after[path]})
+		}
+	}
+	return diff
+}
+
+// flattenJSON adds every scalar in value to values, keyed by its path.
+func flattenJSON(prefix string, value gjson.Result, values map[string]string) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch {
+	case value.IsArray() && len(value.Array()) > 0:
+		for i, element := range value.Array() {
+			flattenJSON(join(strconv.Itoa(i)), element, values)
+		}
+	case value.IsObject() && len(value.Map()) > 0:
+		value.ForEach(func(key, element gjson.Result) bool {
+			flattenJSON(join(key.String()), element, values)
+			return true
+		})
+	case value.Type == gjson.Null && prefix != "":
+		return
+	default:
+		values[prefix] = value.Raw
+	}
+}
+
 // Initiator holds the Job definition's Initiator.
 type Initiator struct {
 	models.Initiator
@@ -362,8 +461,11 @@ func initiatorParams(i Initiator) (interface{}, error) {
 		return struct{}{}, nil
 	case models.InitiatorCron:
 		return struct {
-			Schedule models.Cron `json:"schedule"`
-		}{i.Schedule}, nil
+			Schedule models.Cron          `json:"schedule"`
+			Timezone string               `json:"timezone,omitempty"`
+			Jitter   *models.Duration     `json:"jitter,omitempty"`
+			CatchUp  models.CatchUpPolicy `json:"catchUp,omitempty"`
+		}{i.Schedule, i.Timezone, friendlyJitter(i.Jitter), i.CatchUp}, nil
 	case models.InitiatorRunAt:
 		return struct {
 			Time models.AnyTime `json:"time"`
@@ -397,6 +499,13 @@ func initiatorParams(i Initiator) (interface{}, error) {
 	}
 }
 
+func friendlyJitter(jitter models.Duration) *models.Duration {
+	if jitter.IsInstant() {
+		return nil
+	}
+	return &jitter
+}
+
 // FriendlyRunAt returns a human-readable string for Cron Initiator types.
 func (i Initiator) FriendlyRunAt() string {
 	if i.Type == models.InitiatorRunAt {
@@ -431,6 +540,73 @@ func (jr JobRun) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// JobSimulation presents the outcome of simulating a job spec.
+type JobSimulation struct {
+	ID     *models.ID       `json:"-"`
+	Status models.RunStatus `json:"status"`
+	Result models.JSON      `json:"result"`
+	Error  string           `json:"error,omitempty"`
+	Tasks  []TaskSimulation `json:"tasks"`
+}
+
+// TaskSimulation presents the outcome of a task performed while simulating
+// a job spec.
+type TaskSimulation struct {
+	Type    models.TaskType  `json:"type"`
+	Name    string           `json:"name,omitempty"`
+	Status  models.RunStatus `json:"status"`
+	Result  models.JSON      `json:"result"`
+	Error   string           `json:"error,omitempty"`
+	Elapsed models.Duration  `json:"elapsed"`
+	RetryAt *time.Time       `json:"retryAt,omitempty"`
+}
+
+// NewJobSimulation returns the outcome of a simulated run, given the time
+// spent performing each of its tasks.
+func NewJobSimulation(run models.JobRun, elapsed []time.Duration) JobSimulation {
+	js := JobSimulation{
+		ID:     run.ID,
+		Status: run.GetStatus(),
+		Result: run.Result.Data,
+		Error:  run.ErrorString(),
+		Tasks:  make([]TaskSimulation, len(run.TaskRuns)),
+	}
+	for i, tr := range run.TaskRuns {
+		js.Tasks[i] = TaskSimulation{
+			Type:   tr.TaskSpec.Type,
+			Name:   tr.TaskSpec.Name,
+			Status: tr.Status,
+			Result: tr.Result.Data,
+			Error:  tr.Result.ErrorMessage.String,
+		}
+		if tr.Status.PendingSleep() && tr.RetryAt.Valid {
+			retryAt := tr.RetryAt.Time
+			js.Tasks[i].RetryAt = &retryAt
+		}
+		if i < len(elapsed) {
+			js.Tasks[i].Elapsed = models.MustMakeDuration(elapsed[i])
+		}
+	}
+	return js
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (js JobSimulation) GetID() string {
+	return js.ID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (js JobSimulation) GetName() string {
+	return "simulations"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (js *JobSimulation) SetID(value string) error {
+	id, err := models.NewIDFromString(value)
+	js.ID = id
+	return err
+}
+
 // TaskSpec holds a task specified in the Job definition.
 type TaskSpec struct {
 	models.TaskSpec
@@ -567,6 +743,7 @@ func (a NewAccount) GetName() string {
 
 // Tx is a jsonapi wrapper for an Ethereum Transaction.
 type Tx struct {
+	Cancelled bool            `json:"cancelled,omitempty"`
 	Confirmed bool            `json:"confirmed,omitempty"`
 	Data      hexutil.Bytes   `json:"data,omitempty"`
 	From      *common.Address `json:"from,omitempty"`
@@ -575,6 +752,7 @@ type Tx struct {
 	Hash      common.Hash     `json:"hash,omitempty"`
 	Hex       string          `json:"rawHex,omitempty"`
 	Nonce     string          `json:"nonce,omitempty"`
+	Replaced  bool            `json:"replaced,omitempty"`
 	SentAt    string          `json:"sentAt,omitempty"`
 	To        *common.Address `json:"to,omitempty"`
 	Value     string          `json:"value,omitempty"`
@@ -583,6 +761,7 @@ type Tx struct {
 // NewTx builds a transaction presenter.
 func NewTx(tx *models.Tx) Tx {
 	return Tx{
+		Cancelled: tx.Cancelled,
 		Confirmed: tx.Confirmed,
 		Data:      hexutil.Bytes(tx.Data),
 		From:      &tx.From,
@@ -591,6 +770,7 @@ func NewTx(tx *models.Tx) Tx {
 		Hash:      tx.Hash,
 		Hex:       hexutil.Encode(tx.SignedRawTx),
 		Nonce:     strconv.FormatUint(tx.Nonce, 10),
+		Replaced:  tx.Replaced,
 		SentAt:    strconv.FormatUint(tx.SentAt, 10),
 		To:        &tx.To,
 		Value:     tx.Value.String(),
@@ -636,6 +816,20 @@ func (t *Tx) SetID(hex string) error {
 	return nil
 }
 
+// BridgeType presents a bridge with the health of its circuit breaker.
+// SigningSecret is only set when the secret has just been generated, so
+// that it is shown to the operator once.
+type BridgeType struct {
+	models.BridgeType
+	Health        store.BridgeHealth `json:"health"`
+	SigningSecret string             `json:"signingSecret,omitempty"`
+}
+
+// NewBridgeType returns the bridge with its health.
+func NewBridgeType(bt models.BridgeType, store *store.Store) BridgeType {
+	return BridgeType{BridgeType: bt, Health: store.BridgeHealth.Health(bt.Name)}
+}
+
 // ExternalInitiatorAuthentication includes initiator and authentication details.
 type ExternalInitiatorAuthentication struct {
 	Name           string        `json:"name,omitempty"`
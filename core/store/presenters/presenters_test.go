@@ -35,3 +35,25 @@ func TestTx_MarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, string(b))
 }
+
+func TestNewJobSpecDiff(t *testing.T) {
+	t.Parallel()
+
+	from, err := models.ParseJSON([]byte(`{"initiators":[{"type":"web"}],"tasks":[{"type":"httpget","params":{"get":"https://a.com"}},{"type":"jsonparse","params":{"path":["last"]}}],"startAt":null}`))
+	assert.NoError(t, err)
+	to, err := models.ParseJSON([]byte(`{"initiators":[{"type":"web"}],"tasks":[{"type":"httpget","params":{"get":"https://b.com"}}],"startAt":"2020-06-01T00:00:00Z"}`))
+	assert.NoError(t, err)
+
+	diff := NewJobSpecDiff(
+		models.JobSpecVersion{Version: 1, Spec: from},
+		models.JobSpecVersion{Version: 2, Spec: to},
+	)
+	assert.Equal(t, uint32(1), diff.From)
+	assert.Equal(t, uint32(2), diff.To)
+	assert.Equal(t, []JobSpecChange{
+		{"startAt", "", `"2020-06-01T00:00:00Z"`},
+		{"tasks.0.params.get", `"https://a.com"`, `"https://b.com"`},
+		{"tasks.1.params.path.0", `"last"`, ""},
+		{"tasks.1.type", `"jsonparse"`, ""},
+	}, diff.Changes)
+}
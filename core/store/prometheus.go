@@ -23,6 +23,23 @@ var promETHBalance = promauto.NewGaugeVec(
 	[]string{"account"},
 )
 
+var (
+	promResponseCacheHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "adapter_response_cache_hits",
+			Help: "The number of adapter requests answered from the response cache, or by a coalesced request",
+		},
+		[]string{"adapter"},
+	)
+	promResponseCacheMisses = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "adapter_response_cache_misses",
+			Help: "The number of cacheable adapter requests sent because no cached response was found",
+		},
+		[]string{"adapter"},
+	)
+)
+
 func promUpdateEthBalance(balance *assets.Eth, from common.Address) {
 	balanceFloat, err := approximateFloat64(balance)
 
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// ResponseCache caches the responses of adapters to external requests for a
+// TTL chosen by each task, so that jobs requesting the same endpoint within
+// seconds of each other share one request. Concurrent fetches of the same
+// request are coalesced into one, whose response is shared by all callers
+// if it is cacheable. Otherwise, such as for a bridge answering that it will
+// respond asynchronously, each caller fetches the response itself.
+type ResponseCache struct {
+	maxEntrySize int64
+	maxSize      int64
+	clock        utils.Nower
+	size         int64
+	entries      map[string]*list.Element
+	lru          *list.List
+	inflight     map[string]*responseCacheCall
+	mutex        sync.Mutex
+}
+
+type responseCacheEntry struct {
+	key       string
+	body      []byte
+	expiresAt time.Time
+}
+
+type responseCacheCall struct {
+	done   chan struct{}
+	body   []byte
+	shared bool
+}
+
+// ResponseFetcher fetches a response, returning whether it can be cached.
+type ResponseFetcher func() (body []byte, cacheable bool, err error)
+
+// NewResponseCache returns a cache which skips responses larger than
+// maxEntrySize bytes, and evicts the least recently used responses once
+// the cached responses add up to more than maxSize bytes.
+func NewResponseCache(maxEntrySize, maxSize int64, clock utils.Nower) *ResponseCache {
+	return &ResponseCache{
+		maxEntrySize: maxEntrySize,
+		maxSize:      maxSize,
+		clock:        clock,
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+		inflight:     make(map[string]*responseCacheCall),
+	}
+}
+
+// ResponseCacheKey returns the key of a request, which is the same for
+// requests with the same method, URL, headers and body, regardless of the
+// order of their headers.
+func ResponseCacheKey(method, url string, header http.Header, body []byte) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return http.CanonicalHeaderKey(names[i]) < http.CanonicalHeaderKey(names[j])
+	})
+
+	hash := sha256.New()
+	write := func(s string) {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+	write(method)
+	write(url)
+	for _, name := range names {
+		write(http.CanonicalHeaderKey(name))
+		for _, value := range header[name] {
+			write(value)
+		}
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Fetch returns the cached response for the key if it has not expired, and
+// otherwise fetches it, caching it for ttl if it is cacheable. A ttl of
+// zero, or a nil cache, disables caching, and the response is always
+// fetched. The adapter is used to label the hit and miss counters.
+func (c *ResponseCache) Fetch(adapter, key string, ttl time.Duration, fetch ResponseFetcher) ([]byte, error) {
+	if c == nil || ttl <= 0 {
+		body, _, err := fetch()
+		return body, err
+	}
+
+	c.mutex.Lock()
+	if body, ok := c.get(key); ok {
+		c.mutex.Unlock()
+		promResponseCacheHits.WithLabelValues(adapter).Inc()
+		return body, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		if call.shared {
+			promResponseCacheHits.WithLabelValues(adapter).Inc()
+			return call.body, nil
+		}
+		promResponseCacheMisses.WithLabelValues(adapter).Inc()
+		body, _, err := fetch()
+		return body, err
+	}
+	call := &responseCacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mutex.Unlock()
+	promResponseCacheMisses.WithLabelValues(adapter).Inc()
+
+	body, cacheable, err := fetch()
+	call.body, call.shared = body, err == nil && cacheable
+
+	c.mutex.Lock()
+	delete(c.inflight, key)
+	if err == nil && cacheable {
+		c.put(key, body, c.clock.Now().Add(ttl))
+	}
+	c.mutex.Unlock()
+	close(call.done)
+
+	return body, err
+}
+
+// Size returns the total size in bytes of the cached responses.
+func (c *ResponseCache) Size() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.size
+}
+
+func (c *ResponseCache) get(key string) ([]byte, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*responseCacheEntry)
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.body, true
+}
+
+func (c *ResponseCache) put(key string, body []byte, expiresAt time.Time) {
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	size := int64(len(body))
+	if size > c.maxEntrySize || size > c.maxSize {
+		return
+	}
+	for c.size+size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	entry := &responseCacheEntry{key: key, body: body, expiresAt: expiresAt}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += size
+}
+
+func (c *ResponseCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*responseCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.body))
+}
@@ -0,0 +1,197 @@
+package store_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{"Content-Type": {"application/json"}, "X-Api-Key": {"key"}}
+	key := strpkg.ResponseCacheKey("POST", "https://example.com", header, []byte(`{}`))
+
+	reordered := http.Header{}
+	reordered.Set("X-Api-Key", "key")
+	reordered.Set("Content-Type", "application/json")
+	assert.Equal(t, key, strpkg.ResponseCacheKey("POST", "https://example.com", reordered, []byte(`{}`)))
+
+	assert.NotEqual(t, key, strpkg.ResponseCacheKey("GET", "https://example.com", header, []byte(`{}`)))
+	assert.NotEqual(t, key, strpkg.ResponseCacheKey("POST", "https://example.org", header, []byte(`{}`)))
+	assert.NotEqual(t, key, strpkg.ResponseCacheKey("POST", "https://example.com", nil, []byte(`{}`)))
+	assert.NotEqual(t, key, strpkg.ResponseCacheKey("POST", "https://example.com", header, []byte(`{"a":1}`)))
+}
+
+func TestResponseCache_Fetch(t *testing.T) {
+	t.Parallel()
+
+	clock := &fixedClock{now: time.Unix(1593005428, 0)}
+	cache := strpkg.NewResponseCache(1024, 1024, clock)
+
+	var fetches int
+	fetch := func(body string, cacheable bool, err error) strpkg.ResponseFetcher {
+		return func() ([]byte, bool, error) {
+			fetches++
+			return []byte(body), cacheable, err
+		}
+	}
+
+	body, err := cache.Fetch("httpget", "uncached", 0, fetch("a", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(body))
+	_, err = cache.Fetch("httpget", "uncached", 0, fetch("a", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches, "a zero ttl should not cache")
+
+	fetches = 0
+	body, err = cache.Fetch("httpget", "cached", time.Minute, fetch("b", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(body))
+	body, err = cache.Fetch("httpget", "cached", time.Minute, fetch("c", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(body))
+	assert.Equal(t, 1, fetches)
+
+	clock.now = clock.now.Add(time.Minute)
+	body, err = cache.Fetch("httpget", "cached", time.Minute, fetch("c", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "c", string(body), "should fetch again once expired")
+	assert.Equal(t, 2, fetches)
+
+	fetches = 0
+	_, err = cache.Fetch("httpget", "failed", time.Minute, fetch("", true, errors.New("boom")))
+	require.Error(t, err)
+	_, err = cache.Fetch("httpget", "uncacheable", time.Minute, fetch("d", false, nil))
+	require.NoError(t, err)
+	_, err = cache.Fetch("httpget", "failed", time.Minute, fetch("e", true, nil))
+	require.NoError(t, err)
+	_, err = cache.Fetch("httpget", "uncacheable", time.Minute, fetch("f", true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 4, fetches, "errors and uncacheable responses should not be cached")
+}
+
+func TestResponseCache_SizeLimits(t *testing.T) {
+	t.Parallel()
+
+	clock := &fixedClock{now: time.Unix(1593005428, 0)}
+	cache := strpkg.NewResponseCache(4, 8, clock)
+	respond := func(body string) strpkg.ResponseFetcher {
+		return func() ([]byte, bool, error) {
+			return []byte(body), true, nil
+		}
+	}
+
+	_, err := cache.Fetch("bridge", "large", time.Minute, respond("12345"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), cache.Size(), "should not cache responses larger than an entry")
+
+	for _, key := range []string{"a", "b"} {
+		_, err = cache.Fetch("bridge", key, time.Minute, respond("1234"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(8), cache.Size())
+
+	// Use a so that b is the least recently used
+	body, err := cache.Fetch("bridge", "a", time.Minute, respond("miss"))
+	require.NoError(t, err)
+	assert.Equal(t, "1234", string(body))
+
+	_, err = cache.Fetch("bridge", "c", time.Minute, respond("1234"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), cache.Size())
+
+	body, err = cache.Fetch("bridge", "a", time.Minute, respond("miss"))
+	require.NoError(t, err)
+	assert.Equal(t, "1234", string(body))
+	body, err = cache.Fetch("bridge", "b", time.Minute, respond("miss"))
+	require.NoError(t, err)
+	assert.Equal(t, "miss", string(body), "should evict the least recently used")
+}
+
+func TestResponseCache_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	cache := strpkg.NewResponseCache(1024, 1024, &fixedClock{now: time.Unix(1593005428, 0)})
+
+	var fetches int32
+	release := make(chan struct{})
+	fetch := func() ([]byte, bool, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return []byte("coalesced"), true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, err := cache.Fetch("httpget", "key", time.Minute, fetch)
+			assert.NoError(t, err)
+			results <- string(body)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	for body := range results {
+		assert.Equal(t, "coalesced", body)
+	}
+}
+
+func TestResponseCache_Coalescing_NotCacheable(t *testing.T) {
+	t.Parallel()
+
+	cache := strpkg.NewResponseCache(1024, 1024, &fixedClock{now: time.Unix(1593005428, 0)})
+
+	var fetches int32
+	release := make(chan struct{})
+	fetch := func() ([]byte, bool, error) {
+		n := atomic.AddInt32(&fetches, 1)
+		if n == 1 {
+			<-release
+			return []byte("pending"), false, nil
+		}
+		return []byte("own"), false, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, err := cache.Fetch("bridge", "key", time.Minute, fetch)
+			assert.NoError(t, err)
+			results <- string(body)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(10), atomic.LoadInt32(&fetches))
+	pending := 0
+	for body := range results {
+		if body == "pending" {
+			pending++
+		}
+	}
+	assert.Equal(t, 1, pending, "should only give the uncacheable response to the caller that fetched it")
+}
@@ -0,0 +1,356 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/tevino/abool"
+	"go.uber.org/multierr"
+)
+
+// pendingStateMethods depend on the pending state of a node, which can
+// differ between nodes, so they are always sent to the same node.
+var pendingStateMethods = map[string]bool{
+	"eth_getTransactionCount": true,
+	"eth_sendRawTransaction":  true,
+}
+
+// quorumMethods are critical calls, whose result is only used once enough
+// nodes agree on it.
+var quorumMethods = map[string]bool{
+	"eth_getTransactionReceipt": true,
+}
+
+// RPCNode is an Ethereum node of an RPCPool.
+type RPCNode struct {
+	URL string
+	eth.CallerSubscriber
+}
+
+type rpcPoolNode struct {
+	RPCNode
+	healthy *abool.AtomicBool
+}
+
+// RPCPool is a CallerSubscriber spreading calls over several Ethereum nodes,
+// the first of which is the primary node, and the others secondary nodes.
+//
+// Subscriptions, and calls depending on the pending state of a node, go to
+// the first healthy node. Other calls are sent round-robin to the healthy
+// nodes. A node failing to answer is marked unhealthy, and the call is sent
+// to the next node. Unhealthy nodes are used again once they pass a health
+// check, and subscriptions to secondary nodes are ended once the primary
+// node does, so that they are made again with the primary node. Calls to
+// quorum methods are sent to every healthy node, and only succeed if at
+// least quorum of them agree on the result.
+type RPCPool struct {
+	nodes               []*rpcPoolNode
+	quorum              int
+	healthCheckInterval time.Duration
+	next                uint32
+	done                chan struct{}
+	wg                  sync.WaitGroup
+
+	subscriptionsMutex     sync.Mutex
+	secondarySubscriptions map[*rpcPoolSubscription]struct{}
+}
+
+// NewRPCPool returns a pool of the given nodes, the first of which is the
+// primary node.
+func NewRPCPool(nodes []RPCNode, quorum uint16, healthCheckInterval time.Duration) *RPCPool {
+	pool := &RPCPool{
+		quorum:                 int(quorum),
+		healthCheckInterval:    healthCheckInterval,
+		secondarySubscriptions: make(map[*rpcPoolSubscription]struct{}),
+	}
+	for _, node := range nodes {
+		pool.nodes = append(pool.nodes, &rpcPoolNode{RPCNode: node, healthy: abool.NewBool(true)})
+	}
+	return pool
+}
+
+// Start checks the health of the nodes every health check interval. Health
+// checks are disabled if the interval is not positive, in which case
+// unhealthy nodes are only used as a last resort.
+func (p *RPCPool) Start() {
+	if p.healthCheckInterval <= 0 {
+		return
+	}
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go p.checkHealthPeriodically()
+}
+
+// Stop stops checking the health of the nodes.
+func (p *RPCPool) Stop() {
+	if p.done != nil {
+		close(p.done)
+		p.wg.Wait()
+	}
+}
+
+func (p *RPCPool) checkHealthPeriodically() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.CheckHealth()
+		}
+	}
+}
+
+// CheckHealth checks that each node answers eth_blockNumber, and updates
+// its health accordingly. Subscriptions to secondary nodes are ended if the
+// primary node is healthy.
+func (p *RPCPool) CheckHealth() {
+	var wg sync.WaitGroup
+	for _, node := range p.nodes {
+		wg.Add(1)
+		go func(node *rpcPoolNode) {
+			defer wg.Done()
+			var height hexutil.Uint64
+			err := node.Call(&height, "eth_blockNumber")
+			if node.failed(err) {
+				return
+			}
+			if node.healthy.SetToIf(false, true) {
+				logger.Infow("Ethereum node is healthy again", "url", node.URL, "blockHeight", uint64(height))
+			}
+		}(node)
+	}
+	wg.Wait()
+
+	if p.nodes[0].healthy.IsSet() {
+		p.failBack()
+	}
+}
+
+// failBack ends the subscriptions to secondary nodes, so that their
+// subscribers subscribe again with the primary node.
+func (p *RPCPool) failBack() {
+	p.subscriptionsMutex.Lock()
+	defer p.subscriptionsMutex.Unlock()
+	for sub := range p.secondarySubscriptions {
+		logger.Infow("Ending subscription to secondary Ethereum node, as the primary node is healthy", "url", sub.node.URL)
+		sub.failBack()
+		delete(p.secondarySubscriptions, sub)
+	}
+}
+
+// failed returns true if err shows that the node failed to answer, rather
+// than answering with an error, and marks the node unhealthy if so.
+func (node *rpcPoolNode) failed(err error) bool {
+	if err == nil || err == rpc.ErrNoResult {
+		return false
+	}
+	if _, ok := err.(rpc.Error); ok {
+		return false
+	}
+	if node.healthy.SetToIf(true, false) {
+		logger.Warnw("Ethereum node is unhealthy", "url", node.URL, "error", err)
+	}
+	return true
+}
+
+// byPriority returns the healthy nodes, followed by the unhealthy ones as a
+// last resort, in order of priority.
+func (p *RPCPool) byPriority() []*rpcPoolNode {
+	return p.ordered(0)
+}
+
+// roundRobin returns the healthy nodes, followed by the unhealthy ones as a
+// last resort, starting from the next node in turn.
+func (p *RPCPool) roundRobin() []*rpcPoolNode {
+	return p.ordered(int(atomic.AddUint32(&p.next, 1)-1) % len(p.nodes))
+}
+
+func (p *RPCPool) ordered(start int) []*rpcPoolNode {
+	healthy := make([]*rpcPoolNode, 0, len(p.nodes))
+	var unhealthy []*rpcPoolNode
+	for i := range p.nodes {
+		node := p.nodes[(start+i)%len(p.nodes)]
+		if node.healthy.IsSet() {
+			healthy = append(healthy, node)
+		} else {
+			unhealthy = append(unhealthy, node)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// Call sends the call to the nodes of the pool, until one of them answers.
+func (p *RPCPool) Call(result interface{}, method string, args ...interface{}) error {
+	if quorumMethods[method] && p.quorum > 1 {
+		return p.quorumCall(result, method, args...)
+	}
+
+	nodes := p.roundRobin()
+	if pendingStateMethods[method] {
+		nodes = p.byPriority()
+	}
+
+	var merr error
+	for _, node := range nodes {
+		err := node.Call(result, method, args...)
+		if !node.failed(err) {
+			return err
+		}
+		merr = multierr.Append(merr, errors.Wrap(err, node.URL))
+	}
+	return errors.Wrapf(merr, "no Ethereum node answered %s", method)
+}
+
+func (p *RPCPool) quorumCall(result interface{}, method string, args ...interface{}) error {
+	var nodes []*rpcPoolNode
+	for _, node := range p.nodes {
+		if node.healthy.IsSet() {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) < p.quorum {
+		return fmt.Errorf("only %d healthy Ethereum nodes for a quorum of %d on %s", len(nodes), p.quorum, method)
+	}
+
+	answers := make([]json.RawMessage, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *rpcPoolNode) {
+			defer wg.Done()
+			errs[i] = node.Call(&answers[i], method, args...)
+			node.failed(errs[i])
+		}(i, node)
+	}
+	wg.Wait()
+
+	var merr error
+	var agreed []interface{}
+	var votes []int
+	for i, answer := range answers {
+		if errs[i] != nil {
+			merr = multierr.Append(merr, errors.Wrap(errs[i], nodes[i].URL))
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(answer, &decoded); err != nil {
+			merr = multierr.Append(merr, errors.Wrap(err, nodes[i].URL))
+			continue
+		}
+
+		vote := -1
+		for j := range agreed {
+			if reflect.DeepEqual(agreed[j], decoded) {
+				vote = j
+				break
+			}
+		}
+		if vote == -1 {
+			agreed = append(agreed, decoded)
+			votes = append(votes, 0)
+			vote = len(agreed) - 1
+		}
+		votes[vote]++
+		if votes[vote] >= p.quorum {
+			return json.Unmarshal(answer, result)
+		}
+	}
+	if merr != nil {
+		return errors.Wrapf(merr, "no quorum of %d Ethereum nodes agreeing on %s", p.quorum, method)
+	}
+	return fmt.Errorf("no quorum of %d Ethereum nodes agreeing on %s", p.quorum, method)
+}
+
+// Subscribe subscribes with the first healthy node. If the subscription
+// fails, the node is marked unhealthy, so that subscribing again fails over
+// to the next node.
+func (p *RPCPool) Subscribe(ctx context.Context, channel interface{}, args ...interface{}) (eth.Subscription, error) {
+	var merr error
+	for _, node := range p.byPriority() {
+		sub, err := node.Subscribe(ctx, channel, args...)
+		if err != nil {
+			node.failed(err)
+			merr = multierr.Append(merr, errors.Wrap(err, node.URL))
+			continue
+		}
+		poolSub := newRPCPoolSubscription(p, node, sub)
+		if node != p.nodes[0] {
+			logger.Warnw("Subscribed to secondary Ethereum node", "url", node.URL)
+			p.subscriptionsMutex.Lock()
+			p.secondarySubscriptions[poolSub] = struct{}{}
+			p.subscriptionsMutex.Unlock()
+		}
+		return poolSub, nil
+	}
+	return nil, errors.Wrap(merr, "no Ethereum node accepted the subscription")
+}
+
+func (p *RPCPool) forgetSubscription(sub *rpcPoolSubscription) {
+	p.subscriptionsMutex.Lock()
+	defer p.subscriptionsMutex.Unlock()
+	delete(p.secondarySubscriptions, sub)
+}
+
+// errFailBack ends subscriptions to secondary nodes once the primary node
+// is healthy again.
+var errFailBack = errors.New("primary Ethereum node is healthy again")
+
+// rpcPoolSubscription marks the node of a subscription unhealthy if the
+// subscription fails.
+type rpcPoolSubscription struct {
+	eth.Subscription
+	node         *rpcPoolNode
+	errors       chan error
+	failBackOnce sync.Once
+	failedBack   chan struct{}
+}
+
+func newRPCPoolSubscription(pool *RPCPool, node *rpcPoolNode, sub eth.Subscription) *rpcPoolSubscription {
+	s := &rpcPoolSubscription{
+		Subscription: sub,
+		node:         node,
+		errors:       make(chan error, 1),
+		failedBack:   make(chan struct{}),
+	}
+	go func() {
+		defer close(s.errors)
+		defer pool.forgetSubscription(s)
+		select {
+		case err, open := <-sub.Err():
+			if open && err != nil {
+				node.failed(err)
+				s.errors <- err
+			}
+		case <-s.failedBack:
+			sub.Unsubscribe()
+			s.errors <- errFailBack
+		}
+	}()
+	return s
+}
+
+func (s *rpcPoolSubscription) failBack() {
+	s.failBackOnce.Do(func() { close(s.failedBack) })
+}
+
+// Err returns the errors of the subscription.
+func (s *rpcPoolSubscription) Err() <-chan error {
+	return s.errors
+}
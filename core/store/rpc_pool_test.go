@@ -0,0 +1,243 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonRPCError is an error answered by an Ethereum node.
+type jsonRPCError struct{}
+
+func (jsonRPCError) Error() string  { return "execution reverted" }
+func (jsonRPCError) ErrorCode() int { return -32000 }
+
+func newRPCPool(quorum uint16, nodes ...*mocks.CallerSubscriber) *strpkg.RPCPool {
+	rpcNodes := make([]strpkg.RPCNode, len(nodes))
+	for i, node := range nodes {
+		rpcNodes[i] = strpkg.RPCNode{URL: fmt.Sprintf("ws://node%d", i), CallerSubscriber: node}
+	}
+	return strpkg.NewRPCPool(rpcNodes, quorum, time.Minute)
+}
+
+func answer(result string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		*args.Get(0).(*json.RawMessage) = json.RawMessage(result)
+	}
+}
+
+func TestRPCPool_Call_RoundRobin(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+
+	primary.On("Call", mock.Anything, "eth_blockNumber").Return(nil).Once()
+	secondary.On("Call", mock.Anything, "eth_blockNumber").Return(nil).Once()
+
+	var result string
+	require.NoError(t, pool.Call(&result, "eth_blockNumber"))
+	require.NoError(t, pool.Call(&result, "eth_blockNumber"))
+
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+}
+
+func TestRPCPool_Call_PendingStateOnPrimary(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+
+	primary.On("Call", mock.Anything, "eth_sendRawTransaction", "0xcafe").Return(nil).Twice()
+
+	var result string
+	require.NoError(t, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+	require.NoError(t, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+
+	primary.AssertExpectations(t)
+	secondary.AssertNotCalled(t, "Call", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRPCPool_Call_Failover(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+
+	primary.On("Call", mock.Anything, "eth_sendRawTransaction", "0xcafe").Return(errors.New("connection refused")).Once()
+	secondary.On("Call", mock.Anything, "eth_sendRawTransaction", "0xcafe").Return(nil).Twice()
+
+	var result string
+	require.NoError(t, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+	// The unhealthy primary is skipped
+	require.NoError(t, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+
+	// Until it passes a health check
+	primary.On("Call", mock.Anything, "eth_blockNumber").Return(nil)
+	secondary.On("Call", mock.Anything, "eth_blockNumber").Return(nil)
+	pool.CheckHealth()
+	primary.On("Call", mock.Anything, "eth_sendRawTransaction", "0xcafe").Return(nil).Once()
+	require.NoError(t, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+}
+
+func TestRPCPool_Call_NodeErrorsDoNotFailOver(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+
+	primary.On("Call", mock.Anything, "eth_sendRawTransaction", "0xcafe").Return(jsonRPCError{}).Once()
+
+	var result string
+	assert.Equal(t, jsonRPCError{}, pool.Call(&result, "eth_sendRawTransaction", "0xcafe"))
+
+	primary.AssertExpectations(t)
+	secondary.AssertNotCalled(t, "Call", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRPCPool_Call_Quorum(t *testing.T) {
+	t.Parallel()
+
+	receipt := `{"transactionHash":"0x01","blockNumber":"0x10"}`
+	nodes := []*mocks.CallerSubscriber{new(mocks.CallerSubscriber), new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)}
+	pool := newRPCPool(2, nodes...)
+
+	nodes[0].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(receipt)).Return(nil)
+	nodes[1].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(`null`)).Return(nil)
+	nodes[2].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(`{"blockNumber":"0x10","transactionHash":"0x01"}`)).Return(nil)
+
+	var result map[string]string
+	require.NoError(t, pool.Call(&result, "eth_getTransactionReceipt", "0x01"))
+	assert.Equal(t, "0x10", result["blockNumber"])
+}
+
+func TestRPCPool_Call_NoQuorum(t *testing.T) {
+	t.Parallel()
+
+	nodes := []*mocks.CallerSubscriber{new(mocks.CallerSubscriber), new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)}
+	pool := newRPCPool(2, nodes...)
+
+	nodes[0].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(`{"blockNumber":"0x10"}`)).Return(nil)
+	nodes[1].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(`null`)).Return(nil)
+	nodes[2].On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Return(errors.New("connection refused")).Once()
+
+	var result map[string]string
+	assert.Error(t, pool.Call(&result, "eth_getTransactionReceipt", "0x01"))
+	assert.Nil(t, result)
+
+	// The third node is now unhealthy, and no longer asked
+	assert.Error(t, pool.Call(&result, "eth_getTransactionReceipt", "0x01"))
+	nodes[2].AssertExpectations(t)
+}
+
+func TestRPCPool_Call_TooFewHealthyNodesForQuorum(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(2, primary, secondary)
+
+	primary.On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Run(answer(`null`)).Return(nil).Once()
+	secondary.On("Call", mock.Anything, "eth_getTransactionReceipt", "0x01").Return(errors.New("connection refused")).Once()
+
+	var result map[string]string
+	assert.Error(t, pool.Call(&result, "eth_getTransactionReceipt", "0x01"))
+
+	err := pool.Call(&result, "eth_getTransactionReceipt", "0x01")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only 1 healthy Ethereum nodes")
+}
+
+func TestRPCPool_Subscribe_Failover(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+	ctx := context.Background()
+	headers := make(chan struct{})
+
+	primarySub := new(mocks.Subscription)
+	primaryErrs := make(chan error, 1)
+	primarySub.On("Err").Return((<-chan error)(primaryErrs))
+	primary.On("Subscribe", ctx, headers, "newHeads").Return(primarySub, nil).Once()
+
+	sub, err := pool.Subscribe(ctx, headers, "newHeads")
+	require.NoError(t, err)
+
+	// The primary's subscription fails, marking it unhealthy
+	primaryErrs <- errors.New("websocket: close 1006")
+	assert.Error(t, <-sub.Err())
+
+	secondarySub := new(mocks.Subscription)
+	secondarySub.On("Err").Return((<-chan error)(make(chan error)))
+	secondary.On("Subscribe", ctx, headers, "newHeads").Return(secondarySub, nil).Once()
+
+	_, err = pool.Subscribe(ctx, headers, "newHeads")
+	require.NoError(t, err)
+
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+}
+
+func TestRPCPool_Subscribe_FailBack(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := newRPCPool(1, primary, secondary)
+	ctx := context.Background()
+	headers := make(chan struct{})
+
+	primary.On("Subscribe", ctx, headers, "newHeads").Return(nil, errors.New("connection refused")).Once()
+	secondarySub := new(mocks.Subscription)
+	secondarySub.On("Err").Return((<-chan error)(make(chan error)))
+	secondarySub.On("Unsubscribe").Once()
+	secondary.On("Subscribe", ctx, headers, "newHeads").Return(secondarySub, nil).Once()
+
+	sub, err := pool.Subscribe(ctx, headers, "newHeads")
+	require.NoError(t, err)
+
+	// The subscription to the secondary is ended once the primary is healthy
+	primary.On("Call", mock.Anything, "eth_blockNumber").Return(nil)
+	secondary.On("Call", mock.Anything, "eth_blockNumber").Return(nil)
+	pool.CheckHealth()
+
+	select {
+	case err := <-sub.Err():
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the subscription to end")
+	}
+
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+	secondarySub.AssertExpectations(t)
+}
+
+func TestRPCPool_Start_NoHealthCheckInterval(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := new(mocks.CallerSubscriber), new(mocks.CallerSubscriber)
+	pool := strpkg.NewRPCPool([]strpkg.RPCNode{
+		{URL: "ws://node0", CallerSubscriber: primary},
+		{URL: "ws://node1", CallerSubscriber: secondary},
+	}, 1, 0)
+
+	pool.Start()
+	pool.Stop()
+
+	primary.AssertNotCalled(t, "Call", mock.Anything, mock.Anything)
+	secondary.AssertNotCalled(t, "Call", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
+)
+
+// secretPasswordPrefix is added to the beginning of the password secrets are
+// encrypted with, so that they are not encrypted with the same key as the
+// node's Ethereum and VRF keys.
+const secretPasswordPrefix = "chainlink secrets store:"
+
+// ErrSecretsLocked is returned when secrets are used before the secret store
+// has been unlocked with the node's password.
+var ErrSecretsLocked = errors.New("secrets store is locked, unlock it with the node's password")
+
+// SecretStore keeps secrets, such as API keys, encrypted in the database
+// with the node's password, and resolves the references to them in task
+// params when a task is performed. Once unlocked, it keeps the decrypted
+// secrets in memory, and redacts them from run outputs and logs.
+type SecretStore struct {
+	orm      *orm.ORM
+	scryptN  int
+	scryptP  int
+	password *string
+	values   map[string]string
+	replacer *strings.Replacer
+	lock     sync.RWMutex
+}
+
+// NewSecretStore returns a locked secret store deriving the key secrets are
+// encrypted with using the given scrypt parameters.
+func NewSecretStore(orm *orm.ORM, scryptN, scryptP int) *SecretStore {
+	return &SecretStore{
+		orm:      orm,
+		scryptN:  scryptN,
+		scryptP:  scryptP,
+		values:   make(map[string]string),
+		replacer: strings.NewReplacer(),
+	}
+}
+
+// Unlock decrypts the secrets in the database with the password, which is
+// then used to encrypt new secrets, and starts redacting the secrets from
+// the logs.
+func (ss *SecretStore) Unlock(password string) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	secrets, err := ss.orm.AllSecrets()
+	if err != nil {
+		return errors.Wrap(err, "while loading secrets")
+	}
+	values := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		value, err := ss.decrypt(secret, password)
+		if err != nil {
+			return err
+		}
+		values[secret.Name] = value
+	}
+
+	ss.password = &password
+	ss.values = values
+	ss.buildReplacer()
+	return nil
+}
+
+// Create encrypts and saves a new secret.
+func (ss *SecretStore) Create(request models.SecretRequest) (models.Secret, error) {
+	if err := models.ValidateSecretName(request.Name); err != nil {
+		return models.Secret{}, err
+	} else if err := models.ValidateSecretValue(request.Value); err != nil {
+		return models.Secret{}, err
+	}
+
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	secret := models.Secret{Name: request.Name}
+	if err := ss.encrypt(&secret, request.Value); err != nil {
+		return secret, err
+	}
+	if err := ss.orm.CreateSecret(&secret); err != nil {
+		return secret, err
+	}
+	ss.values[secret.Name] = request.Value
+	ss.buildReplacer()
+	return secret, nil
+}
+
+// Update encrypts and saves the new value of the secret.
+func (ss *SecretStore) Update(secret *models.Secret, value string) error {
+	if err := models.ValidateSecretValue(value); err != nil {
+		return err
+	}
+
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	if err := ss.encrypt(secret, value); err != nil {
+		return err
+	}
+	if err := ss.orm.UpdateSecret(secret); err != nil {
+		return err
+	}
+	ss.values[secret.Name] = value
+	ss.buildReplacer()
+	return nil
+}
+
+// Delete removes the secret. Tasks referencing it fail from then on.
+func (ss *SecretStore) Delete(secret *models.Secret) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	if err := ss.orm.DeleteSecret(secret); err != nil {
+		return err
+	}
+	delete(ss.values, secret.Name)
+	ss.buildReplacer()
+	return nil
+}
+
+// Resolve returns the params with each {{secret:NAME}} reference replaced
+// by the value of the secret.
+func (ss *SecretStore) Resolve(params models.JSON) (models.JSON, error) {
+	text := params.String()
+	if !models.SecretReferenceFormat.MatchString(text) {
+		return params, nil
+	}
+
+	ss.lock.RLock()
+	defer ss.lock.RUnlock()
+	if ss.password == nil {
+		return params, ErrSecretsLocked
+	}
+
+	var missing []string
+	resolved := models.SecretReferenceFormat.ReplaceAllStringFunc(text, func(reference string) string {
+		name := models.SecretReferenceFormat.FindStringSubmatch(reference)[1]
+		value, ok := ss.values[name]
+		if !ok {
+			missing = append(missing, name)
+			return reference
+		}
+		return jsonEscape(value)
+	})
+	if len(missing) > 0 {
+		return params, fmt.Errorf("unable to resolve unknown secrets: %s", strings.Join(missing, ", "))
+	}
+	return models.ParseJSON([]byte(resolved))
+}
+
+// Redact returns the text with the values of secrets replaced by their
+// references. Values are also replaced in the encoded forms they take in
+// JSON strings and URL query parameters. Since values are at least
+// models.MinSecretLength long, other text is unlikely to contain them.
+func (ss *SecretStore) Redact(text string) string {
+	ss.lock.RLock()
+	defer ss.lock.RUnlock()
+	return ss.replacer.Replace(text)
+}
+
+func (ss *SecretStore) buildReplacer() {
+	type replacement struct{ value, reference string }
+	var replacements []replacement
+	for name, value := range ss.values {
+		if value == "" {
+			continue
+		}
+		reference := models.SecretReference(name)
+		forms := map[string]bool{value: true, jsonEscape(value): true, url.QueryEscape(value): true}
+		for form := range forms {
+			replacements = append(replacements, replacement{form, reference})
+		}
+	}
+
+	// Replace longer values first, so that a secret containing another is
+	// not partly replaced by the other's reference.
+	sort.Slice(replacements, func(i, j int) bool {
+		return len(replacements[i].value) > len(replacements[j].value)
+	})
+	pairs := make([]string, 0, 2*len(replacements))
+	for _, r := range replacements {
+		pairs = append(pairs, r.value, r.reference)
+	}
+	ss.replacer = strings.NewReplacer(pairs...)
+
+	// Log entries are only redacted while there are secrets to redact, so
+	// that their fields are not encoded for nothing.
+	if len(pairs) > 0 {
+		logger.SetRedactor(ss)
+	} else {
+		logger.SetRedactor(nil)
+	}
+}
+
+func (ss *SecretStore) encrypt(secret *models.Secret, value string) error {
+	if ss.password == nil {
+		return ErrSecretsLocked
+	}
+	crypto, err := keystore.EncryptDataV3([]byte(value), []byte(secretPasswordPrefix+*ss.password), ss.scryptN, ss.scryptP)
+	if err != nil {
+		return errors.Wrapf(err, "could not encrypt secret %s", secret.Name)
+	}
+	encrypted, err := json.Marshal(crypto)
+	if err != nil {
+		return errors.Wrapf(err, "could not encrypt secret %s", secret.Name)
+	}
+	secret.EncryptedValue = string(encrypted)
+	return nil
+}
+
+func (ss *SecretStore) decrypt(secret models.Secret, password string) (string, error) {
+	var crypto keystore.CryptoJSON
+	if err := json.Unmarshal([]byte(secret.EncryptedValue), &crypto); err != nil {
+		return "", errors.Wrapf(err, "could not parse encrypted secret %s", secret.Name)
+	}
+	value, err := keystore.DecryptDataV3(crypto, secretPasswordPrefix+password)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not decrypt secret %s", secret.Name)
+	}
+	return string(value), nil
+}
+
+// jsonEscape returns the value as it appears inside a JSON string.
+func jsonEscape(value string) string {
+	b, _ := json.Marshal(value)
+	return string(b[1 : len(b)-1])
+}
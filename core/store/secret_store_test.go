@@ -0,0 +1,86 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretStore_Resolve(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	secrets := store.SecretStore
+
+	params := cltest.JSONFromString(t, `{"url":"https://example.com?key={{secret:API_KEY}}"}`)
+	_, err := secrets.Resolve(params)
+	assert.Equal(t, strpkg.ErrSecretsLocked, err)
+
+	plain := cltest.JSONFromString(t, `{"url":"https://example.com"}`)
+	resolved, err := secrets.Resolve(plain)
+	require.NoError(t, err, "params without references should not need the store to be unlocked")
+	assert.Equal(t, plain, resolved)
+
+	require.NoError(t, secrets.Unlock(cltest.Password))
+	_, err = secrets.Create(models.SecretRequest{Name: "API_KEY", Value: `a"bcdefgh`})
+	require.NoError(t, err)
+
+	resolved, err = secrets.Resolve(params)
+	require.NoError(t, err)
+	assert.Equal(t, `https://example.com?key=a"bcdefgh`, resolved.Get("url").String())
+
+	_, err = secrets.Resolve(cltest.JSONFromString(t, `{"key":"{{secret:MISSING}}"}`))
+	assert.EqualError(t, err, "unable to resolve unknown secrets: MISSING")
+}
+
+func TestSecretStore_Redact(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	secrets := store.SecretStore
+	require.NoError(t, secrets.Unlock(cltest.Password))
+
+	_, err := secrets.Create(models.SecretRequest{Name: "SHORT", Value: "abcdefgh"})
+	require.NoError(t, err)
+	_, err = secrets.Create(models.SecretRequest{Name: "LONG", Value: "abcdefgh ij&"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "{{secret:LONG}} and {{secret:SHORT}}", secrets.Redact("abcdefgh ij& and abcdefgh"))
+	assert.Equal(t, "?key={{secret:LONG}}", secrets.Redact("?key=abcdefgh+ij%26"))
+	assert.Equal(t, `{"key":"&"}`, secrets.Redact(`{"key":"&"}`))
+
+	_, err = secrets.Create(models.SecretRequest{Name: "TINY", Value: "abc"})
+	assert.EqualError(t, err, "secret value must be at least 8 characters long")
+	assert.Equal(t, "abc", secrets.Redact("abc"))
+}
+
+func TestSecretStore_Unlock(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.SecretStore.Unlock(cltest.Password))
+
+	secret, err := store.SecretStore.Create(models.SecretRequest{Name: "API_KEY", Value: "s3cr3t-value"})
+	require.NoError(t, err)
+	assert.NotContains(t, secret.EncryptedValue, "s3cr3t-value")
+
+	reopened := strpkg.NewSecretStore(store.ORM, keystore.LightScryptN, keystore.LightScryptP)
+	assert.Error(t, reopened.Unlock("wrong password"))
+	assert.Equal(t, "s3cr3t-value", reopened.Redact("s3cr3t-value"), "a failed unlock should not load secrets")
+
+	require.NoError(t, reopened.Unlock(cltest.Password))
+	assert.Equal(t, "{{secret:API_KEY}}", reopened.Redact("s3cr3t-value"))
+
+	require.NoError(t, reopened.Delete(&secret))
+	_, err = reopened.Resolve(cltest.JSONFromString(t, `{"key":"{{secret:API_KEY}}"}`))
+	assert.Error(t, err)
+}
@@ -12,11 +12,13 @@ import (
 	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/gracefulpanic"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/wasm"
 	"github.com/smartcontractkit/chainlink/core/store/migrations"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -34,12 +36,19 @@ const (
 // for keeping the application state in sync with the database.
 type Store struct {
 	*orm.ORM
-	Config      *orm.Config
-	Clock       utils.AfterNower
-	KeyStore    KeyStoreInterface
-	VRFKeyStore *VRFKeyStore
-	TxManager   TxManager
-	closeOnce   *sync.Once
+	Config       *orm.Config
+	Clock        utils.AfterNower
+	KeyStore     KeyStoreInterface
+	VRFKeyStore  *VRFKeyStore
+	TxManager    TxManager
+	WasmRuntime  *wasm.Runtime
+	BridgeHealth *BridgeHealthMonitor
+	Responses    *ResponseCache
+	HostLimiter  *HostLimiter
+	WebSockets   *WebSocketPool
+	SecretStore  *SecretStore
+	closeOnce    *sync.Once
+	rpcPool      *RPCPool
 }
 
 type lazyRPCWrapper struct {
@@ -138,7 +147,16 @@ func (ed *EthDialer) Dial(urlString string) (eth.CallerSubscriber, error) {
 
 // NewStore will create a new store using the Eth dialer
 func NewStore(config *orm.Config, shutdownSignal gracefulpanic.Signal) *Store {
-	keyStore := func() *KeyStore { return NewKeyStore(config.KeysDir()) }
+	keyStore := func() KeyStoreInterface { return NewKeyStore(config.KeysDir()) }
+	if url := config.EthSignerURL(); url != "" {
+		keyStore = func() KeyStoreInterface {
+			signer, err := NewExternalSigner(url)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Unable to connect to external signer: %+v", err))
+			}
+			return signer
+		}
+	}
 	dialer := NewEthDialer(config.MaxRPCCallsPerSecond())
 	return newStoreWithDialerAndKeyStore(config, dialer, keyStore, shutdownSignal)
 }
@@ -148,14 +166,16 @@ func NewStore(config *orm.Config, shutdownSignal gracefulpanic.Signal) *Store {
 // NOTE: Should only be used for testing!
 func NewInsecureStore(config *orm.Config, shutdownSignal gracefulpanic.Signal) *Store {
 	dialer := NewEthDialer(config.MaxRPCCallsPerSecond())
-	keyStore := func() *KeyStore { return NewInsecureKeyStore(config.KeysDir()) }
-	return newStoreWithDialerAndKeyStore(config, dialer, keyStore, shutdownSignal)
+	keyStore := func() KeyStoreInterface { return NewInsecureKeyStore(config.KeysDir()) }
+	store := newStoreWithDialerAndKeyStore(config, dialer, keyStore, shutdownSignal)
+	store.SecretStore = NewSecretStore(store.ORM, keystore.LightScryptN, keystore.LightScryptP)
+	return store
 }
 
 func newStoreWithDialerAndKeyStore(
 	config *orm.Config,
 	dialer Dialer,
-	keyStoreGenerator func() *KeyStore,
+	keyStoreGenerator func() KeyStoreInterface,
 	shutdownSignal gracefulpanic.Signal,
 ) *Store {
 
@@ -171,27 +191,66 @@ func newStoreWithDialerAndKeyStore(
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to dial ETH RPC port: %+v", err))
 	}
+	var rpcPool *RPCPool
+	if secondaryURLs := config.EthereumSecondaryURLs(); len(secondaryURLs) > 0 {
+		nodes := []RPCNode{{URL: config.EthereumURL(), CallerSubscriber: ethrpc}}
+		for _, secondaryURL := range secondaryURLs {
+			secondary, err := dialer.Dial(secondaryURL)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Unable to dial secondary ETH RPC port: %+v", err))
+			}
+			nodes = append(nodes, RPCNode{URL: secondaryURL, CallerSubscriber: secondary})
+		}
+		rpcPool = NewRPCPool(nodes, config.EthRPCQuorum(), config.EthHealthCheckInterval().Duration())
+		ethrpc = rpcPool
+	}
 	if err := orm.ClobberDiskKeyStoreWithDBKeys(config.KeysDir()); err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to migrate key store to disk: %+v", err))
 	}
 
+	wasmRuntime, err := wasm.NewRuntime(config.WasmMemoryLimitPages(), config.WasmTimeout().Duration(), config.WasmFuelLimit())
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Unable to create wasm runtime: %+v", err))
+	}
+
 	keyStore := keyStoreGenerator()
 	callerSubscriberClient := &eth.CallerSubscriberClient{CallerSubscriber: ethrpc}
 	txManager := NewEthTxManager(callerSubscriberClient, config, keyStore, orm)
 	store := &Store{
-		Clock:     utils.Clock{},
-		Config:    config,
-		KeyStore:  keyStore,
-		ORM:       orm,
-		TxManager: txManager,
-		closeOnce: &sync.Once{},
+		Clock:       utils.Clock{},
+		Config:      config,
+		KeyStore:    keyStore,
+		ORM:         orm,
+		TxManager:   txManager,
+		WasmRuntime: wasmRuntime,
+		closeOnce:   &sync.Once{},
+		rpcPool:     rpcPool,
 	}
+	store.BridgeHealth = NewBridgeHealthMonitor(
+		orm.HealthCheckedBridgeTypes,
+		config.BridgeCircuitBreakerThreshold(),
+		config.BridgeCircuitBreakerCooldown().Duration(),
+		config.BridgeHealthCheckInterval().Duration(),
+		store.Clock,
+	)
+	store.Responses = NewResponseCache(
+		config.ResponseCacheMaxEntrySize(),
+		config.ResponseCacheMaxSize(),
+		store.Clock,
+	)
+	store.HostLimiter = newHostLimiterFromConfig(config)
+	store.WebSockets = NewWebSocketPool()
+	store.SecretStore = NewSecretStore(orm, keystore.StandardScryptN, keystore.StandardScryptP)
 	store.VRFKeyStore = NewVRFKeyStore(store)
 	return store
 }
 
 // Start initiates all of Store's dependencies including the TxManager.
 func (s *Store) Start() error {
+	if s.rpcPool != nil {
+		s.rpcPool.Start()
+	}
+	s.BridgeHealth.Start()
 	s.TxManager.Register(s.KeyStore.Accounts())
 	return s.SyncDiskKeyStoreToDB()
 }
@@ -200,7 +259,12 @@ func (s *Store) Start() error {
 func (s *Store) Close() error {
 	var err error
 	s.closeOnce.Do(func() {
-		err = s.ORM.Close()
+		if s.rpcPool != nil {
+			s.rpcPool.Stop()
+		}
+		s.BridgeHealth.Stop()
+		s.WebSockets.Close()
+		err = multierr.Append(s.WasmRuntime.Close(), s.ORM.Close())
 	})
 	return err
 }
@@ -71,6 +71,8 @@ type TxManager interface {
 	CreateTx(to common.Address, data []byte) (*models.Tx, error)
 	CreateTxWithGas(surrogateID null.String, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error)
 	CreateTxWithEth(from, to common.Address, value *assets.Eth) (*models.Tx, error)
+	CancelTx(hash common.Hash, gasPriceWei *big.Int) (*models.Tx, error)
+	ReplaceTx(hash common.Hash, rtr models.ReplaceTxRequest) (*models.Tx, error)
 	CheckAttempt(txAttempt *models.TxAttempt, blockHeight uint64) (*eth.TxReceipt, AttemptState, error)
 
 	BumpGasUntilSafe(hash common.Hash) (*eth.TxReceipt, AttemptState, error)
@@ -204,8 +206,8 @@ func (txm *EthTxManager) CreateTxWithGas(surrogateID null.String, to common.Addr
 		return nil, err
 	}
 
-	gasPriceWei, gasLimit = normalizeGasParams(gasPriceWei, gasLimit, txm.config)
-	return txm.createTx(surrogateID, ma, to, data, gasPriceWei, gasLimit, nil)
+	fee, gasLimit := normalizeGasParams(gasPriceWei, gasLimit, txm.config)
+	return txm.createTx(surrogateID, ma, to, data, fee, gasLimit, nil)
 }
 
 // CreateTxWithEth signs and sends a transaction with some ETH to transfer.
@@ -215,7 +217,92 @@ func (txm *EthTxManager) CreateTxWithEth(from, to common.Address, value *assets.
 		return nil, errors.New("account does not exist")
 	}
 
-	return txm.createTx(null.String{}, ma, to, []byte{}, txm.config.EthGasPriceDefault(), txm.config.EthGasLimitDefault(), value)
+	fee, gasLimit := normalizeGasParams(nil, 0, txm.config)
+	return txm.createTx(null.String{}, ma, to, []byte{}, fee, gasLimit, value)
+}
+
+// CancelTx cancels the pending transaction with an attempt of the given
+// hash, by sending a zero value transfer to its sender at the same nonce
+// with a bumped gas price.
+func (txm *EthTxManager) CancelTx(hash common.Hash, gasPriceWei *big.Int) (*models.Tx, error) {
+	return txm.replaceTx(hash, gasPriceWei, func(tx *models.Tx) {
+		tx.To = tx.From
+		tx.Data = []byte{}
+		tx.Value = utils.NewBig(big.NewInt(0))
+		tx.Cancelled = true
+	})
+}
+
+// ReplaceTx replaces the payload of the pending transaction with an attempt
+// of the given hash, by sending the new payload at the same nonce with a
+// bumped gas price.
+func (txm *EthTxManager) ReplaceTx(hash common.Hash, rtr models.ReplaceTxRequest) (*models.Tx, error) {
+	return txm.replaceTx(hash, rtr.GasPrice.ToInt(), func(tx *models.Tx) {
+		if rtr.To != nil {
+			tx.To = *rtr.To
+		}
+		if rtr.Data != nil {
+			tx.Data = *rtr.Data
+		}
+		if rtr.Value != nil {
+			tx.Value = utils.NewBig(rtr.Value.ToInt())
+		}
+		if rtr.GasLimit != 0 {
+			tx.GasLimit = rtr.GasLimit
+		}
+		tx.Replaced = true
+	})
+}
+
+// replaceTx updates a pending transaction, and sends the update as a new
+// attempt. Later gas bumps keep the update, since they are attempts of the
+// same Tx.
+func (txm *EthTxManager) replaceTx(hash common.Hash, gasPriceWei *big.Int, update func(*models.Tx)) (*models.Tx, error) {
+	if !txm.Connected() {
+		return nil, errors.Wrap(ErrPendingConnection, "EthTxManager#replaceTx")
+	}
+
+	tx, _, err := txm.orm.FindTxByAttempt(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "replaceTx FindTxByAttempt")
+	}
+	if tx.Confirmed {
+		return nil, fmt.Errorf("transaction %s is already confirmed", hash.Hex())
+	}
+	if len(tx.Attempts) == 0 {
+		return nil, fmt.Errorf("transaction %s has no attempts to replace", hash.Hex())
+	}
+
+	fee := txm.bumpGasFee(attemptGasFee(tx.Attempts[len(tx.Attempts)-1]))
+	if gasPriceWei != nil {
+		if gasPriceWei.Cmp(fee.GasPrice) < 0 {
+			return nil, fmt.Errorf("gas price of %v is too low to replace transaction, must be at least %v", gasPriceWei, fee.GasPrice)
+		}
+		fee.GasPrice = gasPriceWei
+		if fee.isDynamic() {
+			fee.GasFeeCap = gasPriceWei
+		}
+	}
+	if fee.GasPrice.Cmp(txm.config.EthMaxGasPriceWei()) > 0 {
+		return nil, fmt.Errorf("gas price of %v would exceed maximum configured limit of %v, set by ETH_MAX_GAS_PRICE_WEI", fee.GasPrice, txm.config.EthMaxGasPriceWei())
+	}
+
+	update(tx)
+	txAttempt, err := txm.createAttempt(tx, fee, uint64(txm.currentHead.Number))
+	if err != nil {
+		return nil, errors.Wrap(err, "replaceTx createAttempt")
+	}
+
+	logger.Infow(
+		fmt.Sprintf("Tx #%d replaces earlier attempts", len(tx.Attempts)-1),
+		"txID", tx.ID,
+		"originalTxHash", hash,
+		"newTxHash", txAttempt.Hash,
+		"cancelled", tx.Cancelled,
+		"gasPrice", fee.GasPrice,
+	)
+
+	return tx, nil
 }
 
 func (txm *EthTxManager) nextAccount() (*ManagedAccount, error) {
@@ -231,20 +318,49 @@ func (txm *EthTxManager) nextAccount() (*ManagedAccount, error) {
 	return ma, nil
 }
 
-func normalizeGasParams(gasPriceWei *big.Int, gasLimit uint64, config orm.ConfigReader) (*big.Int, uint64) {
-	if !config.Dev() {
-		return config.EthGasPriceDefault(), config.EthGasLimitDefault()
-	}
+// gasFee is what a transaction offers to pay for its gas: a gas price for
+// legacy transactions, or a tip and fee cap for dynamic fee transactions, in
+// which case GasPrice is the fee cap.
+type gasFee struct {
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+}
 
-	if gasPriceWei == nil {
-		gasPriceWei = config.EthGasPriceDefault()
+func (fee gasFee) isDynamic() bool {
+	return fee.GasTipCap != nil
+}
+
+// normalizeGasParams returns the gas fee and limit of a new transaction of
+// the configured type. Outside of dev mode, or if they are not given, they
+// are the configured defaults. The gas price given to dynamic fee
+// transactions is their fee cap.
+func normalizeGasParams(gasPriceWei *big.Int, gasLimit uint64, config orm.ConfigReader) (gasFee, uint64) {
+	if !config.Dev() {
+		gasPriceWei, gasLimit = nil, 0
 	}
 
 	if gasLimit == 0 {
 		gasLimit = config.EthGasLimitDefault()
 	}
 
-	return gasPriceWei, gasLimit
+	if config.EthTxType() == orm.EthTxTypeDynamicFee {
+		feeCap := gasPriceWei
+		if feeCap == nil {
+			feeCap = config.EthGasFeeCapDefault()
+		}
+		tipCap := config.EthGasTipCapDefault()
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap = feeCap
+		}
+		return gasFee{GasPrice: feeCap, GasTipCap: tipCap, GasFeeCap: feeCap}, gasLimit
+	}
+
+	if gasPriceWei == nil {
+		gasPriceWei = config.EthGasPriceDefault()
+	}
+
+	return gasFee{GasPrice: gasPriceWei}, gasLimit
 }
 
 // createTx creates an ethereum transaction, and retries to submit the
@@ -254,12 +370,12 @@ func (txm *EthTxManager) createTx(
 	ma *ManagedAccount,
 	to common.Address,
 	data []byte,
-	gasPriceWei *big.Int,
+	fee gasFee,
 	gasLimit uint64,
 	value *assets.Eth) (*models.Tx, error) {
 
 	for nrc := 0; nrc < nonceReloadLimit+1; nrc++ {
-		tx, err := txm.sendInitialTx(surrogateID, ma, to, data, gasPriceWei, gasLimit, value)
+		tx, err := txm.sendInitialTx(surrogateID, ma, to, data, fee, gasLimit, value)
 		if err == nil {
 			return tx, nil
 		}
@@ -270,7 +386,7 @@ func (txm *EthTxManager) createTx(
 
 		logger.Warnw(
 			"Tx #0: another tx with this nonce already exists, will retry with network nonce",
-			"nonce", tx.Nonce, "gasPriceWei", gasPriceWei, "gasLimit", gasLimit, "error", err.Error(),
+			"nonce", tx.Nonce, "gasPriceWei", fee.GasPrice, "gasLimit", gasLimit, "error", err.Error(),
 		)
 
 		// Linear backoff
@@ -278,7 +394,7 @@ func (txm *EthTxManager) createTx(
 
 		logger.Warnw(
 			"Tx #0: another tx with this nonce already exists, retrying with network nonce",
-			"nonce", tx.Nonce, "gasPriceWei", gasPriceWei, "gasLimit", gasLimit, "error", err.Error(),
+			"nonce", tx.Nonce, "gasPriceWei", fee.GasPrice, "gasLimit", gasLimit, "error", err.Error(),
 		)
 
 		err = ma.ReloadNonce(txm)
@@ -300,7 +416,7 @@ func (txm *EthTxManager) sendInitialTx(
 	ma *ManagedAccount,
 	to common.Address,
 	data []byte,
-	gasPriceWei *big.Int,
+	fee gasFee,
 	gasLimit uint64,
 	value *assets.Eth) (*models.Tx, error) {
 
@@ -315,7 +431,7 @@ func (txm *EthTxManager) sendInitialTx(
 			to,
 			value.ToInt(),
 			gasLimit,
-			gasPriceWei,
+			fee,
 			data,
 			&ma.Address,
 			blockHeight,
@@ -390,12 +506,16 @@ func (txm *EthTxManager) newTx(
 	to common.Address,
 	amount *big.Int,
 	gasLimit uint64,
-	gasPrice *big.Int,
+	fee gasFee,
 	data []byte,
 	from *common.Address,
 	sentAt uint64) (*models.Tx, error) {
 
-	transaction := types.NewTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	if fee.isDynamic() {
+		return txm.newDynamicFeeTx(account, nonce, to, amount, gasLimit, fee, data, from, sentAt)
+	}
+
+	transaction := types.NewTransaction(nonce, to, amount, gasLimit, fee.GasPrice, data)
 
 	transaction, err := txm.keyStore.SignTx(account, transaction, txm.config.ChainID())
 	if err != nil {
@@ -421,6 +541,53 @@ func (txm *EthTxManager) newTx(
 	}, nil
 }
 
+// newDynamicFeeTx returns a newly signed EIP-1559 Ethereum Transaction
+func (txm *EthTxManager) newDynamicFeeTx(
+	account accounts.Account,
+	nonce uint64,
+	to common.Address,
+	amount *big.Int,
+	gasLimit uint64,
+	fee gasFee,
+	data []byte,
+	from *common.Address,
+	sentAt uint64) (*models.Tx, error) {
+
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	transaction := &eth.DynamicFeeTx{
+		ChainID:   txm.config.ChainID(),
+		Nonce:     nonce,
+		GasTipCap: fee.GasTipCap,
+		GasFeeCap: fee.GasFeeCap,
+		Gas:       gasLimit,
+		To:        to,
+		Value:     amount,
+		Data:      data,
+	}
+
+	signedRawTx, hash, err := txm.keyStore.SignDynamicFeeTx(account, transaction)
+	if err != nil {
+		return nil, errors.Wrap(err, "TxManager newDynamicFeeTx.SignDynamicFeeTx")
+	}
+
+	return &models.Tx{
+		From:        *from,
+		SentAt:      sentAt,
+		To:          to,
+		Nonce:       nonce,
+		Data:        data,
+		Value:       utils.NewBig(amount),
+		GasLimit:    gasLimit,
+		GasPrice:    utils.NewBig(fee.GasFeeCap),
+		GasTipCap:   utils.NewBig(fee.GasTipCap),
+		GasFeeCap:   utils.NewBig(fee.GasFeeCap),
+		Hash:        hash,
+		SignedRawTx: signedRawTx,
+	}, nil
+}
+
 // GetLINKBalance returns the balance of LINK at the given address
 func (txm *EthTxManager) GetLINKBalance(address common.Address) (*assets.Link, error) {
 	contractAddress := common.HexToAddress(txm.config.LinkContractAddress())
@@ -745,34 +912,81 @@ func (txm *EthTxManager) handleSafe(
 // - A configured percentage bump (ETH_GAS_BUMP_PERCENT)
 // - A configured fixed amount of Wei (ETH_GAS_PRICE_WEI)
 func (txm *EthTxManager) BumpGasByIncrement(originalGasPrice *big.Int) *big.Int {
+	return bumpByIncrement(originalGasPrice, txm.config.EthGasBumpPercent(), txm.config.EthGasBumpWei())
+}
+
+// BumpGasTipCapByIncrement returns a new tip for dynamic fee transactions
+// increased by the larger of:
+// - A configured percentage bump (ETH_GAS_TIP_CAP_BUMP_PERCENT)
+// - A configured fixed amount of Wei (ETH_GAS_TIP_CAP_BUMP_WEI)
+func (txm *EthTxManager) BumpGasTipCapByIncrement(originalTipCap *big.Int) *big.Int {
+	return bumpByIncrement(originalTipCap, txm.config.EthGasTipCapBumpPercent(), txm.config.EthGasTipCapBumpWei())
+}
+
+func bumpByIncrement(original *big.Int, percent uint16, wei *big.Int) *big.Int {
 	// Similar logic is used in geth
 	// See: https://github.com/ethereum/go-ethereum/blob/8d7aa9078f8a94c2c10b1d11e04242df0ea91e5b/core/tx_list.go#L255
 	// And: https://github.com/ethereum/go-ethereum/blob/8d7aa9078f8a94c2c10b1d11e04242df0ea91e5b/core/tx_pool.go#L171
-	percentageMultiplier := big.NewInt(100 + int64(txm.config.EthGasBumpPercent()))
-	minimumGasBumpByPercentage := new(big.Int).Div(
+	minimumBumpByPercentage := bumpByPercentage(original, percent)
+	minimumBumpByIncrement := new(big.Int).Add(original, wei)
+	if minimumBumpByIncrement.Cmp(minimumBumpByPercentage) < 0 {
+		return minimumBumpByPercentage
+	}
+	return minimumBumpByIncrement
+}
+
+func bumpByPercentage(original *big.Int, percent uint16) *big.Int {
+	percentageMultiplier := big.NewInt(100 + int64(percent))
+	return new(big.Int).Div(
 		new(big.Int).Mul(
-			originalGasPrice,
+			original,
 			percentageMultiplier,
 		),
 		big.NewInt(100),
 	)
-	minimumGasBumpByIncrement := new(big.Int).Add(originalGasPrice, txm.config.EthGasBumpWei())
-	if minimumGasBumpByIncrement.Cmp(minimumGasBumpByPercentage) < 0 {
-		return minimumGasBumpByPercentage
+}
+
+// bumpGasFee returns the fee of an attempt replacing one with the given fee.
+// Nodes only accept a replacement dynamic fee transaction if both its tip
+// and fee cap are bumped, so the fee cap is bumped by at least the same
+// percentage and amount as the tip.
+func (txm *EthTxManager) bumpGasFee(fee gasFee) gasFee {
+	if !fee.isDynamic() {
+		return gasFee{GasPrice: txm.BumpGasByIncrement(fee.GasPrice)}
+	}
+
+	tipCap := txm.BumpGasTipCapByIncrement(fee.GasTipCap)
+	feeCap := bumpByPercentage(fee.GasFeeCap, txm.config.EthGasTipCapBumpPercent())
+	if byTip := new(big.Int).Add(fee.GasFeeCap, new(big.Int).Sub(tipCap, fee.GasTipCap)); byTip.Cmp(feeCap) > 0 {
+		feeCap = byTip
+	}
+	return gasFee{GasPrice: feeCap, GasTipCap: tipCap, GasFeeCap: feeCap}
+}
+
+// attemptGasFee returns the gas fee offered by a transaction attempt.
+func attemptGasFee(txAttempt *models.TxAttempt) gasFee {
+	if !txAttempt.IsDynamicFee() {
+		return gasFee{GasPrice: txAttempt.GasPrice.ToInt()}
+	}
+	return gasFee{
+		GasPrice:  txAttempt.GasFeeCap.ToInt(),
+		GasTipCap: txAttempt.GasTipCap.ToInt(),
+		GasFeeCap: txAttempt.GasFeeCap.ToInt(),
 	}
-	return minimumGasBumpByIncrement
 }
 
 // bumpGas attempts a new transaction with an increased gas cost
 func (txm *EthTxManager) bumpGas(tx *models.Tx, attemptIndex int, blockHeight uint64) error {
 	txAttempt := tx.Attempts[attemptIndex]
 
-	originalGasPrice := txAttempt.GasPrice.ToInt()
+	originalFee := attemptGasFee(txAttempt)
+	originalGasPrice := originalFee.GasPrice
 
-	bumpedGasPrice := txm.BumpGasByIncrement(originalGasPrice)
+	bumpedFee := txm.bumpGasFee(originalFee)
 
 	for {
 		promNumGasBumps.Inc()
+		bumpedGasPrice := bumpedFee.GasPrice
 		if bumpedGasPrice.Cmp(txm.config.EthMaxGasPriceWei()) > 0 {
 			// NOTE: In the current design, a new tx attempt will be created even if this one returns error.
 			// If we do hit this scenario, we will keep creating new attempts that are guaranteed to fail
@@ -782,15 +996,19 @@ func (txm *EthTxManager) bumpGas(tx *models.Tx, attemptIndex int, blockHeight ui
 			logger.Error(err)
 			return err
 		}
-		bumpedTxAttempt, err := txm.createAttempt(tx, bumpedGasPrice, blockHeight)
+		bumpedTxAttempt, err := txm.createAttempt(tx, bumpedFee, blockHeight)
 		if isUnderPricedReplacementError(err) {
 			// This is not expected if we have bumped at least geth's required
 			// amount.
 			promGasBumpUnderpricedReplacement.Inc()
-			logger.Warnw(fmt.Sprintf("Gas bump was rejected by ethereum node as underpriced, bumping again. Your value of ETH_GAS_BUMP_PERCENT (%v) may be set too low", txm.config.EthGasBumpPercent()),
+			bumpPercentName, bumpPercent := "ETH_GAS_BUMP_PERCENT", txm.config.EthGasBumpPercent()
+			if bumpedFee.isDynamic() {
+				bumpPercentName, bumpPercent = "ETH_GAS_TIP_CAP_BUMP_PERCENT", txm.config.EthGasTipCapBumpPercent()
+			}
+			logger.Warnw(fmt.Sprintf("Gas bump was rejected by ethereum node as underpriced, bumping again. Your value of %s (%v) may be set too low", bumpPercentName, bumpPercent),
 				"originalGasPrice", originalGasPrice, "bumpedGasPrice", bumpedGasPrice,
 			)
-			bumpedGasPrice = txm.BumpGasByIncrement(bumpedGasPrice)
+			bumpedFee = txm.bumpGasFee(bumpedFee)
 			continue
 		}
 		if err != nil {
@@ -803,7 +1021,8 @@ func (txm *EthTxManager) bumpGas(tx *models.Tx, attemptIndex int, blockHeight ui
 		logger.Infow(
 			fmt.Sprintf("Tx #%d created with bumped gas %v", attemptIndex+1, bumpedGasPrice),
 			"originalTxHash", txAttempt.Hash,
-			"newTxHash", bumpedTxAttempt.Hash)
+			"newTxHash", bumpedTxAttempt.Hash,
+			"gasTipCap", bumpedFee.GasTipCap)
 
 		return nil
 	}
@@ -812,7 +1031,7 @@ func (txm *EthTxManager) bumpGas(tx *models.Tx, attemptIndex int, blockHeight ui
 // createAttempt adds a new transaction attempt to a transaction record
 func (txm *EthTxManager) createAttempt(
 	tx *models.Tx,
-	gasPriceWei *big.Int,
+	fee gasFee,
 	blockHeight uint64,
 ) (*models.TxAttempt, error) {
 	ma := txm.getAccount(tx.From)
@@ -826,7 +1045,7 @@ func (txm *EthTxManager) createAttempt(
 		tx.To,
 		tx.Value.ToInt(),
 		tx.GasLimit,
-		gasPriceWei,
+		fee,
 		tx.Data,
 		&ma.Address,
 		blockHeight,
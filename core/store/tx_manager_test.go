@@ -68,6 +68,102 @@ func TestTxManager_CreateTx_Success(t *testing.T) {
 	ethClient.AssertExpectations(t)
 }
 
+func TestTxManager_CreateTx_DynamicFee(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+
+	config := cltest.NewTestConfig(t)
+	config.Set("ETH_TX_TYPE", "dynamicfee")
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 2000000000)
+	config.Set("ETH_GAS_FEE_CAP_DEFAULT", 50000000000)
+	keyStore := strpkg.NewKeyStore(config.KeysDir())
+	account, err := keyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	require.NoError(t, keyStore.Unlock(cltest.Password))
+	manager := strpkg.NewEthTxManager(ethClient, config, keyStore, store.ORM)
+
+	from := account.Address
+	nonce := uint64(256)
+
+	manager.Register(keyStore.Accounts())
+
+	ethClient.On("GetNonce", from).Return(nonce, nil)
+
+	err = manager.Connect(cltest.Head(nonce))
+	require.NoError(t, err)
+
+	ethClient.On("SendRawTx", mock.Anything).Return(cltest.NewHash(), nil)
+
+	tx, err := manager.CreateTx(cltest.NewAddress(), hexutil.MustDecode("0x0000abcdef"))
+	require.NoError(t, err)
+
+	ntx, err := store.FindTx(tx.ID)
+	require.NoError(t, err)
+	require.Len(t, ntx.Attempts, 1)
+	attempt := ntx.Attempts[0]
+	assert.True(t, attempt.IsDynamicFee())
+	assert.Equal(t, "2000000000", attempt.GasTipCap.String())
+	assert.Equal(t, "50000000000", attempt.GasFeeCap.String())
+	assert.Equal(t, "50000000000", attempt.GasPrice.String())
+	assert.Equal(t, eth.DynamicFeeTxType, attempt.SignedRawTx[0])
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestTxManager_CancelTx(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+
+	config := cltest.NewTestConfig(t)
+	keyStore := strpkg.NewKeyStore(config.KeysDir())
+	account, err := keyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	require.NoError(t, keyStore.Unlock(cltest.Password))
+	manager := strpkg.NewEthTxManager(ethClient, config, keyStore, store.ORM)
+
+	from := account.Address
+	nonce := uint64(256)
+
+	manager.Register(keyStore.Accounts())
+	ethClient.On("GetNonce", from).Return(nonce, nil)
+	require.NoError(t, manager.Connect(cltest.Head(nonce)))
+
+	ethClient.On("SendRawTx", mock.Anything).Return(cltest.NewHash(), nil)
+
+	tx, err := manager.CreateTx(cltest.NewAddress(), hexutil.MustDecode("0x0000abcdef"))
+	require.NoError(t, err)
+
+	_, err = manager.CancelTx(tx.Hash, big.NewInt(1))
+	require.Error(t, err)
+
+	cancelled, err := manager.CancelTx(tx.Hash, nil)
+	require.NoError(t, err)
+	assert.True(t, cancelled.Cancelled)
+
+	ntx, err := store.FindTx(tx.ID)
+	require.NoError(t, err)
+	assert.True(t, ntx.Cancelled)
+	assert.Equal(t, nonce, ntx.Nonce)
+	assert.Equal(t, from, ntx.To)
+	assert.Empty(t, ntx.Data)
+	assert.Equal(t, "0", ntx.Value.String())
+	require.Len(t, ntx.Attempts, 2)
+	assert.Equal(t,
+		manager.BumpGasByIncrement(ntx.Attempts[0].GasPrice.ToInt()).String(),
+		ntx.Attempts[1].GasPrice.String(),
+	)
+
+	ethClient.AssertExpectations(t)
+}
+
 func TestTxManager_CreateTx_RoundRobinSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -1264,3 +1360,34 @@ func TestTxManager_BumpGasByIncrement(t *testing.T) {
 		})
 	}
 }
+
+func TestTxManager_BumpGasTipCapByIncrement(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+
+	config := cltest.NewTestConfig(t)
+	config.Set("ETH_GAS_TIP_CAP_BUMP_PERCENT", 10)
+	config.Set("ETH_GAS_TIP_CAP_BUMP_WEI", 1000000000)
+	keyStore := strpkg.NewKeyStore(config.KeysDir())
+	txm := strpkg.NewEthTxManager(ethClient, config, keyStore, store.ORM)
+
+	tests := []struct {
+		name                 string
+		originalTipCap       *big.Int
+		expectedBumpedTipCap *big.Int
+	}{
+		{"bumping tip from 1Gwei to 2Gwei", big.NewInt(1000000000), big.NewInt(2000000000)},
+		{"bumping tip from 20Gwei to 22Gwei", big.NewInt(20000000000), big.NewInt(22000000000)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := txm.BumpGasTipCapByIncrement(test.originalTipCap)
+			assert.Equal(t, test.expectedBumpedTipCap.String(), actual.String())
+		})
+	}
+}
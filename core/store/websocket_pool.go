@@ -0,0 +1,416 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const (
+	// webSocketWriteWait is the time allowed to write a message to the peer.
+	webSocketWriteWait = 10 * time.Second
+
+	// webSocketPongWait is the time allowed to read the next pong message
+	// from the peer.
+	webSocketPongWait = 60 * time.Second
+
+	// webSocketPingPeriod is how often pings are sent to the peer. Must be
+	// less than webSocketPongWait.
+	webSocketPingPeriod = (webSocketPongWait * 9) / 10
+
+	// webSocketIdleTimeout is how long a pooled connection stays open
+	// after the last run waiting on it.
+	webSocketIdleTimeout = 5 * time.Minute
+)
+
+// ErrWebSocketTimeout is returned when no matching message is received from
+// a websocket before the timeout.
+var ErrWebSocketTimeout = errors.New("timeout waiting for a matching websocket message")
+
+// DialContextFunc dials a network connection.
+type DialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// WebSocketRequest is a subscription to the messages pushed over a
+// websocket.
+type WebSocketRequest struct {
+	URL    string
+	Header http.Header
+	// Subscribe is sent once connected, if it is not empty.
+	Subscribe []byte
+	// ReadLimit is the maximum size of a message, in bytes.
+	ReadLimit int64
+	// DialContext dials the connection, or the default dialer if nil.
+	DialContext DialContextFunc
+}
+
+func (r WebSocketRequest) key() string {
+	method := "WEBSOCKET"
+	if r.DialContext != nil {
+		method += " DIALER"
+	}
+	return ResponseCacheKey(method, r.URL, r.Header, r.Subscribe)
+}
+
+// WebSocketPool shares websocket connections across runs, so that tasks
+// subscribing to the same feed wait for its next message rather than each
+// connecting and subscribing again. Connections are shared by requests with
+// the same URL, headers and subscribe message, and closed once idle.
+type WebSocketPool struct {
+	conns    map[string]*pooledWebSocket
+	prunedAt time.Time
+	mutex    sync.Mutex
+	closed   bool
+}
+
+// NewWebSocketPool returns an empty pool.
+func NewWebSocketPool() *WebSocketPool {
+	return &WebSocketPool{conns: make(map[string]*pooledWebSocket)}
+}
+
+// Receive waits for the first message of the subscription for which match
+// returns true, connecting and subscribing first if no pooled connection is
+// open. Only messages received after the call are matched. A nil pool opens
+// a connection for the call alone.
+func (p *WebSocketPool) Receive(
+	request WebSocketRequest,
+	match func([]byte) bool,
+	timeout time.Duration,
+) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	if p == nil {
+		conn := newPooledWebSocket(request)
+		defer conn.close()
+		return conn.receive(match, deadline)
+	}
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil, errors.New("websocket pool is closed")
+	}
+	if time.Since(p.prunedAt) > webSocketIdleTimeout {
+		p.prune()
+	}
+	key := request.key()
+	conn, ok := p.conns[key]
+	if !ok {
+		conn = newPooledWebSocket(request)
+		p.conns[key] = conn
+	}
+	// Marked as used before the pool is unlocked so that it is not pruned
+	// before the run waits on it
+	conn.touch()
+	p.mutex.Unlock()
+
+	return conn.receive(match, deadline)
+}
+
+// prune forgets the connections which have been closed and unused for
+// webSocketIdleTimeout, so that feeds no longer requested do not accumulate
+// in the pool. It must be called with the pool locked.
+func (p *WebSocketPool) prune() {
+	for key, conn := range p.conns {
+		if conn.idle() {
+			delete(p.conns, key)
+		}
+	}
+	p.prunedAt = time.Now()
+}
+
+// Close closes all pooled connections.
+func (p *WebSocketPool) Close() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for key, conn := range p.conns {
+		conn.close()
+		delete(p.conns, key)
+	}
+	p.closed = true
+}
+
+// pooledWebSocket is a connection reopened by the first run to wait on it
+// after it was closed, by the peer or for being idle.
+type pooledWebSocket struct {
+	request   WebSocketRequest
+	conn      *websocket.Conn
+	done      chan struct{}
+	dialing   chan struct{}
+	listeners map[*webSocketListener]struct{}
+	lastUsed  time.Time
+	sleeper   utils.Sleeper
+	retryAt   time.Time
+	retryErr  error
+	closed    bool
+	mutex     sync.Mutex
+}
+
+type webSocketListener struct {
+	match    func([]byte) bool
+	messages chan webSocketMessage
+}
+
+type webSocketMessage struct {
+	data []byte
+	err  error
+}
+
+func newPooledWebSocket(request WebSocketRequest) *pooledWebSocket {
+	return &pooledWebSocket{
+		request:   request,
+		listeners: make(map[*webSocketListener]struct{}),
+		sleeper:   utils.NewBackoffSleeper(),
+	}
+}
+
+// receive waits until the deadline for the first message for which match
+// returns true, connecting first if the connection is closed. The deadline
+// covers connecting, subscribing and waiting for the message.
+func (pws *pooledWebSocket) receive(match func([]byte) bool, deadline time.Time) ([]byte, error) {
+	listener := &webSocketListener{match: match, messages: make(chan webSocketMessage, 1)}
+	if err := pws.listen(listener, deadline); err != nil {
+		return nil, err
+	}
+	defer func() {
+		pws.mutex.Lock()
+		delete(pws.listeners, listener)
+		pws.lastUsed = time.Now()
+		pws.mutex.Unlock()
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case message := <-listener.messages:
+		return message.data, message.err
+	case <-timer.C:
+		return nil, ErrWebSocketTimeout
+	}
+}
+
+// listen adds the listener once the connection is open, connecting if it
+// is not. The connection is dialed without holding the lock, and runs
+// arriving while it is dialed wait for that attempt rather than dialing
+// again. Failed attempts are retried with a backoff, so that a peer which is
+// down is not dialed by every run.
+func (pws *pooledWebSocket) listen(listener *webSocketListener, deadline time.Time) error {
+	for {
+		pws.mutex.Lock()
+		if pws.closed {
+			pws.mutex.Unlock()
+			return fmt.Errorf("websocket %s closed", pws.request.URL)
+		}
+		if pws.conn != nil {
+			pws.listeners[listener] = struct{}{}
+			pws.lastUsed = time.Now()
+			pws.mutex.Unlock()
+			return nil
+		}
+		if wait := time.Until(pws.retryAt); wait > 0 {
+			err := pws.retryErr
+			pws.mutex.Unlock()
+			return errors.Wrapf(err, "retrying in %s", wait.Round(time.Millisecond))
+		}
+		if dialing := pws.dialing; dialing != nil {
+			pws.mutex.Unlock()
+			timer := time.NewTimer(time.Until(deadline))
+			select {
+			case <-dialing:
+				timer.Stop()
+				continue
+			case <-timer.C:
+				return ErrWebSocketTimeout
+			}
+		}
+		dialing := make(chan struct{})
+		pws.dialing = dialing
+		pws.mutex.Unlock()
+
+		conn, err := pws.dial(deadline)
+
+		pws.mutex.Lock()
+		pws.dialing = nil
+		close(dialing)
+		if err == nil && pws.closed {
+			conn.Close()
+			err = fmt.Errorf("websocket %s closed", pws.request.URL)
+		}
+		if err != nil {
+			pws.retryAt = time.Now().Add(pws.sleeper.After())
+			pws.retryErr = err
+			pws.mutex.Unlock()
+			return err
+		}
+		pws.sleeper.Reset()
+		pws.retryAt = time.Time{}
+		pws.retryErr = nil
+		pws.conn = conn
+		pws.done = make(chan struct{})
+		pws.listeners[listener] = struct{}{}
+		pws.lastUsed = time.Now()
+		go pws.readPump(conn)
+		go pws.writePump(conn, pws.done)
+		pws.mutex.Unlock()
+		return nil
+	}
+}
+
+func (pws *pooledWebSocket) dial(deadline time.Time) (*websocket.Conn, error) {
+	dialer := &websocket.Dialer{
+		Proxy:          http.ProxyFromEnvironment,
+		NetDialContext: pws.request.DialContext,
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	conn, _, err := dialer.DialContext(ctx, pws.request.URL, pws.request.Header)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to websocket %s", pws.request.URL)
+	}
+	if pws.request.ReadLimit > 0 {
+		conn.SetReadLimit(pws.request.ReadLimit)
+	}
+
+	if len(pws.request.Subscribe) > 0 {
+		_ = conn.SetWriteDeadline(deadline)
+		if err := conn.WriteMessage(websocket.TextMessage, pws.request.Subscribe); err != nil {
+			conn.Close()
+			return nil, errors.Wrapf(err, "unable to subscribe to websocket %s", pws.request.URL)
+		}
+		_ = conn.SetWriteDeadline(time.Time{})
+	}
+	return conn, nil
+}
+
+// readPump passes the text messages of the connection to the listeners they
+// match, until the connection is closed.
+//
+// Inspired by https://github.com/gorilla/websocket/blob/master/examples/chat/client.go#L56
+func (pws *pooledWebSocket) readPump(conn *websocket.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(webSocketPongWait))
+	conn.SetPongHandler(func(string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(webSocketPongWait))
+		return nil
+	})
+
+	for {
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNormalClosure) {
+				logger.Warnw("Websocket closed unexpectedly", "url", pws.request.URL, "error", err)
+			}
+			pws.disconnect(conn, errors.Wrapf(err, "websocket %s closed", pws.request.URL))
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(webSocketPongWait))
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		pws.mutex.Lock()
+		for listener := range pws.listeners {
+			if listener.match(message) {
+				select {
+				case listener.messages <- webSocketMessage{data: message}:
+				default:
+				}
+				delete(pws.listeners, listener)
+			}
+		}
+		pws.mutex.Unlock()
+	}
+}
+
+// writePump keeps the connection alive with pings, and closes it once no
+// run has waited on it for webSocketIdleTimeout.
+//
+// Inspired by https://github.com/gorilla/websocket/blob/master/examples/chat/client.go#L82
+func (pws *pooledWebSocket) writePump(conn *websocket.Conn, done chan struct{}) {
+	ticker := time.NewTicker(webSocketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		pws.mutex.Lock()
+		idle := len(pws.listeners) == 0 && time.Since(pws.lastUsed) > webSocketIdleTimeout
+		pws.mutex.Unlock()
+
+		if idle {
+			logger.Debugw("Closing idle websocket", "url", pws.request.URL)
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(webSocketWriteWait))
+			pws.disconnect(conn, nil)
+			return
+		}
+		if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteWait)); err != nil {
+			pws.disconnect(conn, errors.Wrapf(err, "websocket %s closed", pws.request.URL))
+			return
+		}
+	}
+}
+
+// disconnect closes the connection, if it is still the current one, failing
+// the runs waiting on it with err.
+func (pws *pooledWebSocket) disconnect(conn *websocket.Conn, err error) {
+	pws.mutex.Lock()
+	defer pws.mutex.Unlock()
+	if pws.conn != conn {
+		return
+	}
+	conn.Close()
+	close(pws.done)
+	pws.conn = nil
+	if err == nil {
+		err = fmt.Errorf("websocket %s closed", pws.request.URL)
+	}
+	for listener := range pws.listeners {
+		select {
+		case listener.messages <- webSocketMessage{err: err}:
+		default:
+		}
+		delete(pws.listeners, listener)
+	}
+}
+
+// touch marks the connection as used now.
+func (pws *pooledWebSocket) touch() {
+	pws.mutex.Lock()
+	pws.lastUsed = time.Now()
+	pws.mutex.Unlock()
+}
+
+// idle returns true if the connection is closed, and no run has waited on it
+// for webSocketIdleTimeout.
+func (pws *pooledWebSocket) idle() bool {
+	pws.mutex.Lock()
+	defer pws.mutex.Unlock()
+	return pws.conn == nil &&
+		pws.dialing == nil &&
+		len(pws.listeners) == 0 &&
+		time.Since(pws.lastUsed) > webSocketIdleTimeout
+}
+
+func (pws *pooledWebSocket) close() {
+	pws.mutex.Lock()
+	pws.closed = true
+	conn := pws.conn
+	pws.mutex.Unlock()
+	if conn != nil {
+		pws.disconnect(conn, nil)
+	}
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSocketPool_Prune(t *testing.T) {
+	t.Parallel()
+
+	pool := NewWebSocketPool()
+	defer pool.Close()
+
+	idle := newPooledWebSocket(WebSocketRequest{URL: "ws://idle.example.com"})
+	idle.lastUsed = time.Now().Add(-2 * webSocketIdleTimeout)
+	recent := newPooledWebSocket(WebSocketRequest{URL: "ws://recent.example.com"})
+	recent.lastUsed = time.Now()
+	waiting := newPooledWebSocket(WebSocketRequest{URL: "ws://waiting.example.com"})
+	waiting.lastUsed = time.Now().Add(-2 * webSocketIdleTimeout)
+	waiting.listeners[&webSocketListener{}] = struct{}{}
+
+	pool.conns["idle"] = idle
+	pool.conns["recent"] = recent
+	pool.conns["waiting"] = waiting
+
+	pool.mutex.Lock()
+	pool.prune()
+	pool.mutex.Unlock()
+
+	assert.NotContains(t, pool.conns, "idle")
+	assert.Contains(t, pool.conns, "recent")
+	assert.Contains(t, pool.conns, "waiting")
+}
@@ -0,0 +1,185 @@
+package store_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPushServer returns a websocket server which pushes the messages sent
+// on the returned channel to every subscribed connection, and counts the
+// connections made to it.
+func newPushServer(t *testing.T) (*httptest.Server, chan<- string, *int32) {
+	var connections int32
+	push := make(chan string)
+	var subscribers sync.Map
+
+	go func() {
+		for message := range push {
+			subscribers.Range(func(key, _ interface{}) bool {
+				_ = key.(*websocket.Conn).WriteMessage(websocket.TextMessage, []byte(message))
+				return true
+			})
+		}
+	}()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		atomic.AddInt32(&connections, 1)
+		defer conn.Close()
+
+		_, subscribe, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		assert.Equal(t, `{"subscribe":"ETH-USD"}`, string(subscribe))
+		subscribers.Store(conn, true)
+		defer subscribers.Delete(conn)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	return server, push, &connections
+}
+
+func webSocketURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestWebSocketPool_Receive(t *testing.T) {
+	t.Parallel()
+
+	server, push, connections := newPushServer(t)
+	defer server.Close()
+	defer close(push)
+
+	pool := strpkg.NewWebSocketPool()
+	defer pool.Close()
+	request := strpkg.WebSocketRequest{URL: webSocketURL(server), Subscribe: []byte(`{"subscribe":"ETH-USD"}`)}
+	isTicker := func(message []byte) bool { return strings.Contains(string(message), "ticker") }
+
+	for i := 0; i < 3; i++ {
+		received := make(chan []byte)
+		go func() {
+			message, err := pool.Receive(request, isTicker, 5*time.Second)
+			assert.NoError(t, err)
+			received <- message
+		}()
+
+		// Push until the run has subscribed, since messages pushed before it
+		// waits are not returned to it
+		ticker := time.NewTicker(20 * time.Millisecond)
+	Push:
+		for {
+			select {
+			case message := <-received:
+				assert.Equal(t, `{"type":"ticker","price":"1.5"}`, string(message))
+				break Push
+			case <-ticker.C:
+				push <- `{"type":"heartbeat"}`
+				push <- `{"type":"ticker","price":"1.5"}`
+			}
+		}
+		ticker.Stop()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(connections), "runs should share a pooled connection")
+}
+
+func TestWebSocketPool_Timeout(t *testing.T) {
+	t.Parallel()
+
+	server, push, _ := newPushServer(t)
+	defer server.Close()
+	defer close(push)
+
+	var pool *strpkg.WebSocketPool
+	request := strpkg.WebSocketRequest{URL: webSocketURL(server), Subscribe: []byte(`{"subscribe":"ETH-USD"}`)}
+	_, err := pool.Receive(request, func([]byte) bool { return true }, 50*time.Millisecond)
+	assert.Equal(t, strpkg.ErrWebSocketTimeout, err)
+}
+
+func TestWebSocketPool_ConnectionRefused(t *testing.T) {
+	t.Parallel()
+
+	server, push, _ := newPushServer(t)
+	url := webSocketURL(server)
+	close(push)
+	server.Close()
+
+	pool := strpkg.NewWebSocketPool()
+	defer pool.Close()
+	request := strpkg.WebSocketRequest{URL: url}
+	_, err := pool.Receive(request, func([]byte) bool { return true }, time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to connect to websocket")
+}
+
+func TestWebSocketPool_DeadlineCoversConnecting(t *testing.T) {
+	t.Parallel()
+
+	server, push, _ := newPushServer(t)
+	defer server.Close()
+	defer close(push)
+
+	var dialer net.Dialer
+	slowDial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		time.Sleep(500 * time.Millisecond)
+		return dialer.DialContext(ctx, network, address)
+	}
+	var pool *strpkg.WebSocketPool
+	request := strpkg.WebSocketRequest{
+		URL:         webSocketURL(server),
+		Subscribe:   []byte(`{"subscribe":"ETH-USD"}`),
+		DialContext: slowDial,
+	}
+
+	start := time.Now()
+	_, err := pool.Receive(request, func([]byte) bool { return true }, 700*time.Millisecond)
+	assert.Equal(t, strpkg.ErrWebSocketTimeout, err)
+	assert.True(t, time.Since(start) < 1200*time.Millisecond, "connecting should count towards the timeout")
+}
+
+func TestWebSocketPool_WaitsForDialWithoutBlocking(t *testing.T) {
+	t.Parallel()
+
+	var dials int32
+	hangingDial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	pool := strpkg.NewWebSocketPool()
+	defer pool.Close()
+	request := strpkg.WebSocketRequest{URL: "ws://example.com", DialContext: hangingDial}
+	matchAll := func([]byte) bool { return true }
+
+	go func() {
+		_, _ = pool.Receive(request, matchAll, 5*time.Second)
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&dials) == 1 }, time.Second, 10*time.Millisecond)
+
+	// A run arriving while the connection is dialed waits for it until its
+	// own timeout, rather than until the dial times out
+	start := time.Now()
+	_, err := pool.Receive(request, matchAll, 50*time.Millisecond)
+	assert.Equal(t, strpkg.ErrWebSocketTimeout, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&dials), "the connection should be dialed once")
+}
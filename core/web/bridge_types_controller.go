@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -85,7 +86,7 @@ func (btc *BridgeTypesController) Show(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, bt, "bridge")
+	jsonAPIResponse(c, presenters.NewBridgeType(bt, btc.App.GetStore()), "bridge")
 }
 
 // Update can change the restricted attributes for a bridge
@@ -117,12 +118,17 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 		jsonAPIError(c, http.StatusBadRequest, err)
 		return
 	}
+	previousSecret := bt.SigningSecret
 	if err := btc.App.GetStore().UpdateBridgeType(&bt, btr); err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	jsonAPIResponse(c, bt, "bridge")
+	pbt := presenters.NewBridgeType(bt, btc.App.GetStore())
+	if bt.SigningSecret != previousSecret {
+		pbt.SigningSecret = bt.SigningSecret
+	}
+	jsonAPIResponse(c, pbt, "bridge")
 }
 
 // Destroy removes a specific Bridge.
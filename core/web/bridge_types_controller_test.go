@@ -3,6 +3,7 @@ package web_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -182,6 +183,82 @@ func TestBridgeController_Show(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Response should be 404")
 }
 
+func TestBridgeController_Show_Health(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	_, bt := cltest.NewBridgeType(t, "unhealthybridge")
+	require.NoError(t, app.GetStore().CreateBridgeType(bt))
+
+	resp, cleanup := client.Get("/v2/bridge_types/" + bt.Name.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, "closed", cltest.ParseJSON(t, resp.Body).Get("data.attributes.health.circuit").String())
+
+	for i := uint16(0); i < app.Store.Config.BridgeCircuitBreakerThreshold(); i++ {
+		app.Store.BridgeHealth.RecordFailure(bt.Name, errors.New("connection refused"))
+	}
+
+	resp, cleanup = client.Get("/v2/bridge_types/" + bt.Name.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	health := cltest.ParseJSON(t, resp.Body).Get("data.attributes.health")
+	assert.Equal(t, "open", health.Get("circuit").String())
+	assert.Equal(t, "connection refused", health.Get("lastError").String())
+}
+
+func TestBridgeTypesController_SigningSecret(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body := `{"name":"signedbridge","url":"http://mybridge","signingEnabled":true}`
+	resp, cleanup := client.Post("/v2/bridge_types", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	secret := cltest.ParseJSON(t, resp.Body).Get("data.attributes.signingSecret").String()
+	require.NotEmpty(t, secret)
+
+	resp, cleanup = client.Get("/v2/bridge_types")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(cltest.ParseResponseBody(t, resp)), secret)
+
+	resp, cleanup = client.Get("/v2/bridge_types/signedbridge")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(cltest.ParseResponseBody(t, resp)), secret)
+
+	resp, cleanup = client.Patch("/v2/bridge_types/signedbridge", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(cltest.ParseResponseBody(t, resp)), secret)
+
+	rotate := `{"name":"signedbridge","url":"http://mybridge","signingEnabled":true,"rotateSigningSecret":true}`
+	resp, cleanup = client.Patch("/v2/bridge_types/signedbridge", bytes.NewBufferString(rotate))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	rotated := cltest.ParseJSON(t, resp.Body).Get("data.attributes.signingSecret").String()
+	require.NotEmpty(t, rotated)
+	assert.NotEqual(t, secret, rotated)
+
+	bt, err := app.Store.FindBridge(models.MustNewTaskType("signedbridge"))
+	require.NoError(t, err)
+	assert.Equal(t, rotated, bt.SigningSecret)
+
+	resp, cleanup = client.Delete("/v2/bridge_types/signedbridge")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(cltest.ParseResponseBody(t, resp)), rotated)
+}
+
 func TestBridgeController_Destroy(t *testing.T) {
 	t.Parallel()
 
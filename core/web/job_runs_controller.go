@@ -1,9 +1,12 @@
 package web
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -18,6 +21,9 @@ import (
 // JobRunsController manages JobRun requests in the node.
 type JobRunsController struct {
 	App chainlink.Application
+	// nonces holds the nonces of the signed bridge callbacks seen within
+	// the age they are accepted for, so that none can be replayed.
+	nonces *nonceSet
 }
 
 // Index returns paginated JobRuns for a given JobSpec
@@ -174,8 +180,13 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		return
 	}
 
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
 	var brr models.BridgeRunResult
-	if e := c.ShouldBindJSON(&brr); e != nil {
+	if e := json.Unmarshal(body, &brr); e != nil {
 		jsonAPIError(c, http.StatusInternalServerError, e)
 		return
 	}
@@ -195,6 +206,15 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
+	nonce, err := bt.VerifySignature(c.Request, body, time.Now())
+	if err != nil {
+		jsonAPIError(c, http.StatusUnauthorized, err)
+		return
+	}
+	if nonce != "" && !jrc.nonces.add(nonce, time.Now()) {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("bridge callback nonce has already been used"))
+		return
+	}
 
 	if err = jrc.App.ResumePendingBridge(runID, brr); errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("Job Run not found"))
@@ -230,3 +250,31 @@ func (jrc *JobRunsController) Cancel(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.JobRun{JobRun: *jr}, "job run")
 }
+
+type nonceSet struct {
+	seen  map[string]time.Time
+	mutex sync.Mutex
+}
+
+func newNonceSet() *nonceSet {
+	return &nonceSet{seen: make(map[string]time.Time)}
+}
+
+// add records the nonce, returning false if it was already seen.
+func (n *nonceSet) add(nonce string, now time.Time) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	// Timestamps are accepted up to the max age either side of now, so
+	// nonces must be remembered for twice as long
+	for seenNonce, seenAt := range n.seen {
+		if now.Sub(seenAt) > 2*models.BridgeSignatureMaxAge {
+			delete(n.seen, seenNonce)
+		}
+	}
+	if _, ok := n.seen[nonce]; ok {
+		return false
+	}
+	n.seen[nonce] = now
+	return true
+}
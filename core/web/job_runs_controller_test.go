@@ -362,6 +362,65 @@ func TestJobRunsController_Update_WrongAccessToken(t *testing.T) {
 	assert.Equal(t, models.RunStatusPendingBridge, jr.GetStatus())
 }
 
+func TestJobRunsController_Update_Signed(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	bta, bt := cltest.NewBridgeType(t)
+	bt.SigningEnabled = true
+	bt.SigningSecret = "signingsecret"
+	require.NoError(t, app.Store.CreateBridgeType(bt))
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{{Type: bt.Name}}
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRunPendingBridge(j)
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	body := fmt.Sprintf(`{"id":"%v","data":{"result": "100"}}`, jr.ID.String())
+	path := "/v2/runs/" + jr.ID.String()
+	signedHeadersFor := func(path string, timestamp time.Time, nonce string) map[string]string {
+		ts := fmt.Sprintf("%d", timestamp.Unix())
+		return map[string]string{
+			"Authorization":              "Bearer " + bta.IncomingToken,
+			models.BridgeTimestampHeader: ts,
+			models.BridgeNonceHeader:     nonce,
+			models.BridgeSignatureHeader: models.BridgeSignature(bt.SigningSecret, "PATCH", path, ts, nonce, []byte(body)),
+		}
+	}
+	signedHeaders := func(timestamp time.Time, nonce string) map[string]string {
+		return signedHeadersFor(path, timestamp, nonce)
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"unsigned", map[string]string{"Authorization": "Bearer " + bta.IncomingToken}},
+		{"stale", signedHeaders(time.Now().Add(-time.Hour), "nonce1")},
+		{"wrong secret", func() map[string]string {
+			headers := signedHeaders(time.Now(), "nonce2")
+			headers[models.BridgeSignatureHeader] = models.BridgeSignature("wrong", "PATCH", path, headers[models.BridgeTimestampHeader], "nonce2", []byte(body))
+			return headers
+		}()},
+		{"signed for another run", signedHeadersFor("/v2/runs/"+models.NewID().String(), time.Now(), "nonce3")},
+	}
+	for _, test := range tests {
+		resp, cleanup := client.Patch(path, bytes.NewBufferString(body), test.headers)
+		defer cleanup()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, test.name)
+	}
+
+	resp, cleanup := client.Patch(path, bytes.NewBufferString(body), signedHeaders(time.Now(), "nonce4"))
+	defer cleanup()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response should be successful")
+
+	jr = cltest.WaitForJobRunToComplete(t, app.Store, jr)
+	assert.Equal(t, "100", cltest.MustResultString(t, jr.Result))
+}
+
 func TestJobRunsController_Update_NotPending(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -1,7 +1,11 @@
 package web
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
@@ -9,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 )
@@ -62,13 +67,20 @@ func (jsc *JobSpecsController) getAndCheckJobSpec(
 		return models.JobSpec{}, http.StatusBadRequest, err
 	}
 	js = models.NewJobFromRequest(jsr)
+	if httpStatus, err := jsc.checkJobSpec(js); err != nil {
+		return models.JobSpec{}, httpStatus, err
+	}
+	return js, 0, nil
+}
+
+func (jsc *JobSpecsController) checkJobSpec(js models.JobSpec) (httpStatus int, err error) {
 	if err := jsc.requireImplemented(js); err != nil {
-		return models.JobSpec{}, http.StatusNotImplemented, err
+		return http.StatusNotImplemented, err
 	}
 	if err := services.ValidateJob(js, jsc.App.GetStore()); err != nil {
-		return models.JobSpec{}, http.StatusBadRequest, err
+		return http.StatusBadRequest, err
 	}
-	return js, 0, nil
+	return 0, nil
 }
 
 // Create adds validates, saves, and starts a new JobSpec.
@@ -115,6 +127,196 @@ func (jsc *JobSpecsController) Show(c *gin.Context) {
 	jsonAPIResponse(c, jobPresenter(jsc, j), "job")
 }
 
+// Update saves the job spec in the request body as a new version of an
+// existing job, keeping its ID. The initiators may be omitted, but must be
+// unchanged if given; runs in progress finish with their original version.
+// Example:
+//  "<application>/specs/:SpecID"
+func (jsc *JobSpecsController) Update(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	current, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var jsr models.JobSpecRequest
+	if err := c.ShouldBindJSON(&jsr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(jsr)
+	js.ID = current.ID
+	if len(jsr.Initiators) == 0 {
+		js.Initiators = current.Initiators
+	} else if !sameInitiators(js.Initiators, current.Initiators) {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("the initiators of a job cannot be changed, archive it and create a new job instead"))
+		return
+	}
+	if httpStatus, err := jsc.checkJobSpec(js); err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+
+	err = jsc.App.UpdateJob(js)
+	if errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+		jsonAPIError(c, http.StatusConflict, errors.New("JobSpec was updated concurrently, please retry"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	updated, err := store.FindJob(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobPresenter(jsc, updated), "job")
+}
+
+// Versions returns a snapshot of every version of a JobSpec, oldest first.
+// Example:
+//  "<application>/specs/:SpecID/versions"
+func (jsc *JobSpecsController) Versions(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	if _, err = store.Unscoped().FindJob(id); errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions, err := store.JobSpecVersions(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, versions, "specVersions")
+}
+
+// FluxRounds returns the decisions of the flux monitor about the rounds of a
+// JobSpec, newest first, optionally only those about a single round.
+// Example:
+//  "<application>/specs/:SpecID/flux_rounds?roundId=:roundId&size=1&page=2"
+func (jsc *JobSpecsController) FluxRounds(c *gin.Context, size, page, offset int) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var roundID uint64
+	if param := c.Query("roundId"); param != "" {
+		roundID, err = strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid roundId"))
+			return
+		}
+	}
+
+	var aggregator common.Address
+	if param := c.Query("aggregator"); param != "" {
+		if !common.IsHexAddress(param) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid aggregator %s", param))
+			return
+		}
+		aggregator = common.HexToAddress(param)
+	}
+
+	store := jsc.App.GetStore()
+	if _, err = store.Unscoped().FindJob(id); errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	events, count, err := store.FluxMonitorRoundEvents(id, aggregator, uint32(roundID), offset, size)
+	paginatedResponse(c, "FluxRounds", size, page, events, count, err)
+}
+
+// ApproveFluxRound approves an answer held by the outlier guard of the flux
+// monitor, so that the next answer within the circuit breaker of it is
+// submitted.
+// Example:
+//  "<application>/specs/:SpecID/flux_rounds/:RoundEventID/approve"
+func (jsc *JobSpecsController) ApproveFluxRound(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	eventID, err := strconv.ParseInt(c.Param("RoundEventID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid round event ID"))
+		return
+	}
+
+	store := jsc.App.GetStore()
+	event, err := store.FindFluxMonitorRoundEvent(id, eventID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("round event not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if event.Outcome != models.FluxMonitorOutcomeHeld {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("only held answers can be approved, round event %d is %s", event.ID, event.Outcome))
+		return
+	} else if event.ApprovedAt.Valid {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("round event %d is already approved", event.ID))
+		return
+	}
+
+	if err := store.ApproveFluxMonitorRoundEvent(&event); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, event, "fluxRounds")
+}
+
+// sameInitiators returns true if both lists have initiators of the same
+// types and parameters, in the same order.
+func sameInitiators(a, b []models.Initiator) bool {
+	ra := models.NewJobSpecRequestFromJob(models.JobSpec{Initiators: a}).Initiators
+	rb := models.NewJobSpecRequestFromJob(models.JobSpec{Initiators: b}).Initiators
+	if len(ra) != len(rb) {
+		return false
+	}
+	for i := range ra {
+		// Whether a runat initiator has already run is not part of its definition
+		ra[i].Ran, rb[i].Ran = false, false
+		ja, errA := json.Marshal(ra[i])
+		jb, errB := json.Marshal(rb[i])
+		if errA != nil || errB != nil || !bytes.Equal(ja, jb) {
+			return false
+		}
+	}
+	return true
+}
+
 // Destroy soft deletes a job spec.
 // Example:
 //  "<application>/specs/:SpecID"
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -613,3 +614,202 @@ func TestJobSpecsController_Destroy_MultipleJobs(t *testing.T) {
 	assert.Error(t, utils.JustError(app.Store.FindJob(job2.ID)))
 	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
 }
+
+func TestJobSpecsController_Update(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httpget", `{"get":"https://example.com/v1"}`)}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, app.Store.CreateJobRun(&run))
+
+	body := `{"tasks":[{"type":"httpget","params":{"get":"https://example.com/v2"}},{"type":"jsonparse","params":{"path":["last"]}}]}`
+	resp, cleanup := client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var updated models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &updated))
+	assert.Equal(t, job.ID, updated.ID)
+	assert.Equal(t, uint32(2), updated.Version)
+	require.Len(t, updated.Initiators, 1)
+	assert.Equal(t, models.InitiatorWeb, updated.Initiators[0].Type)
+	require.Len(t, updated.Tasks, 2)
+	assert.Equal(t, "https://example.com/v2", updated.Tasks[0].Params.Get("get").String())
+
+	// Runs created before the update keep the tasks of their version
+	run, err := app.Store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	require.Len(t, run.TaskRuns, 1)
+	assert.Equal(t, "https://example.com/v1", run.TaskRuns[0].TaskSpec.Params.Get("get").String())
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/versions")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var versions []models.JobSpecVersion
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &versions))
+	require.Len(t, versions, 2)
+	assert.Equal(t, uint32(1), versions[0].Version)
+	assert.Equal(t, "https://example.com/v1", versions[0].Spec.Get("tasks.0.params.get").String())
+	assert.Equal(t, uint32(2), versions[1].Version)
+	assert.Equal(t, "https://example.com/v2", versions[1].Spec.Get("tasks.0.params.get").String())
+}
+
+func TestJobSpecsController_FluxRounds(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	events := []models.FluxMonitorRoundEvent{
+		{RoundID: 1, Trigger: models.FluxMonitorTriggerPoll, Outcome: models.FluxMonitorOutcomeSubmitted},
+		{RoundID: 2, Trigger: models.FluxMonitorTriggerPoll, Outcome: models.FluxMonitorOutcomeBelowThreshold},
+		{RoundID: 2, Trigger: models.FluxMonitorTriggerNewRound, Outcome: models.FluxMonitorOutcomeSkipped, Reason: "not eligible to submit"},
+	}
+	for i := range events {
+		events[i].JobSpecID = job.ID
+		events[i].Aggregator = cltest.NewAddress()
+		events[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Second)
+		require.NoError(t, app.Store.CreateFluxMonitorRoundEvent(&events[i]))
+	}
+
+	resp, cleanup := client.Get("/v2/specs/" + job.ID.String() + "/flux_rounds?size=2")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body := cltest.ParseResponseBody(t, resp)
+
+	metaCount, err := cltest.ParseJSONAPIResponseMetaCount(body)
+	require.NoError(t, err)
+	assert.Equal(t, 3, metaCount)
+
+	var links jsonapi.Links
+	var rounds []models.FluxMonitorRoundEvent
+	require.NoError(t, web.ParsePaginatedResponse(body, &rounds, &links))
+	assert.NotEmpty(t, links["next"].Href)
+	require.Len(t, rounds, 2)
+	assert.Equal(t, models.FluxMonitorOutcomeSkipped, rounds[0].Outcome)
+	assert.Equal(t, "not eligible to submit", rounds[0].Reason)
+	assert.Equal(t, models.FluxMonitorOutcomeBelowThreshold, rounds[1].Outcome)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/flux_rounds?roundId=1")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	rounds = nil
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &rounds, &links))
+	require.Len(t, rounds, 1)
+	assert.Equal(t, models.FluxMonitorOutcomeSubmitted, rounds[0].Outcome)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/flux_rounds?roundId=2&aggregator=" + events[2].Aggregator.Hex())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	rounds = nil
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &rounds, &links))
+	require.Len(t, rounds, 1)
+	assert.Equal(t, models.FluxMonitorOutcomeSkipped, rounds[0].Outcome)
+
+	resp, cleanup = client.Get("/v2/specs/" + models.NewID().String() + "/flux_rounds")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/flux_rounds?roundId=latest")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/flux_rounds?aggregator=0x123")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestJobSpecsController_ApproveFluxRound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	held := models.FluxMonitorRoundEvent{RoundID: 2, Trigger: models.FluxMonitorTriggerPoll, Outcome: models.FluxMonitorOutcomeHeld}
+	submitted := models.FluxMonitorRoundEvent{RoundID: 1, Trigger: models.FluxMonitorTriggerPoll, Outcome: models.FluxMonitorOutcomeSubmitted}
+	for _, event := range []*models.FluxMonitorRoundEvent{&held, &submitted} {
+		event.JobSpecID = job.ID
+		event.Aggregator = cltest.NewAddress()
+		event.CreatedAt = time.Now()
+		require.NoError(t, app.Store.CreateFluxMonitorRoundEvent(event))
+	}
+	approvePath := func(jobID *models.ID, eventID string) string {
+		return "/v2/specs/" + jobID.String() + "/flux_rounds/" + eventID + "/approve"
+	}
+
+	resp, cleanup := client.Post(approvePath(job.ID, strconv.FormatInt(held.ID, 10)), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var approved models.FluxMonitorRoundEvent
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &approved))
+	assert.True(t, approved.ApprovedAt.Valid)
+
+	approvals, err := app.Store.FluxMonitorApprovals(job.ID, held.Aggregator, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, held.ID, approvals[0].ID)
+
+	resp, cleanup = client.Post(approvePath(job.ID, strconv.FormatInt(held.ID, 10)), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post(approvePath(job.ID, strconv.FormatInt(submitted.ID, 10)), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Post(approvePath(models.NewID(), strconv.FormatInt(held.ID, 10)), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Post(approvePath(job.ID, "latest"), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestJobSpecsController_Update_ChangedInitiators(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	body := `{"initiators":[{"type":"cron","params":{"schedule":"CRON_TZ=UTC * * * * *"}}],"tasks":[{"type":"noop"}]}`
+	resp, cleanup := client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	job, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), job.Version)
+}
+
+func TestJobSpecsController_Update_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	resp, cleanup := client.Patch("/v2/specs/"+models.NewID().String(), bytes.NewBufferString(`{"tasks":[{"type":"noop"}]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -195,7 +195,7 @@ func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
 func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	unauthedv2 := r.Group("/v2")
 
-	jr := JobRunsController{app}
+	jr := JobRunsController{App: app, nonces: newNonceSet()}
 	unauthedv2.PATCH("/runs/:RunID", jr.Update)
 
 	sa := ServiceAgreementsController{app}
@@ -218,8 +218,15 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/specs", j.Create)
 		authv2.GET("/specs", paginatedRequest(j.Index))
 		authv2.GET("/specs/:SpecID", j.Show)
+		authv2.PATCH("/specs/:SpecID", j.Update)
+		authv2.GET("/specs/:SpecID/versions", j.Versions)
+		authv2.GET("/specs/:SpecID/flux_rounds", paginatedRequest(j.FluxRounds))
+		authv2.POST("/specs/:SpecID/flux_rounds/:RoundEventID/approve", j.ApproveFluxRound)
 		authv2.DELETE("/specs/:SpecID", j.Destroy)
 
+		sim := SimulationsController{app}
+		authv2.POST("/specs/:SpecID", sim.Create)
+
 		authv2.GET("/runs", paginatedRequest(jr.Index))
 		authv2.GET("/runs/:RunID", jr.Show)
 		authv2.PUT("/runs/:RunID/cancellation", jr.Cancel)
@@ -233,6 +240,19 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.PATCH("/bridge_types/:BridgeName", bt.Update)
 		authv2.DELETE("/bridge_types/:BridgeName", bt.Destroy)
 
+		wm := WasmModulesController{app}
+		authv2.GET("/wasm_modules", paginatedRequest(wm.Index))
+		authv2.POST("/wasm_modules", wm.Create)
+		authv2.GET("/wasm_modules/:Name", wm.Show)
+		authv2.DELETE("/wasm_modules/:Name", wm.Destroy)
+
+		sc := SecretsController{app}
+		authv2.GET("/secrets", paginatedRequest(sc.Index))
+		authv2.POST("/secrets", sc.Create)
+		authv2.GET("/secrets/:Name", sc.Show)
+		authv2.PATCH("/secrets/:Name", sc.Update)
+		authv2.DELETE("/secrets/:Name", sc.Destroy)
+
 		w := WithdrawalsController{app}
 		authv2.POST("/withdrawals", w.Create)
 
@@ -254,6 +274,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		txs := TransactionsController{app}
 		authv2.GET("/transactions", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/:TxHash", txs.Show)
+		authv2.POST("/transactions/:TxHash/cancel", txs.Cancel)
+		authv2.POST("/transactions/:TxHash/replace", txs.Replace)
 
 		bdc := BulkDeletesController{app}
 		authv2.DELETE("/bulk_delete_runs", bdc.Delete)
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// SecretsController manages the secrets referenced in task params. The
+// values of secrets are never returned.
+type SecretsController struct {
+	App chainlink.Application
+}
+
+// Create encrypts and saves a secret.
+func (sc *SecretsController) Create(c *gin.Context) {
+	request := models.SecretRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := models.ValidateSecretName(request.Name); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := sc.App.GetStore()
+	_, err := store.FindSecret(request.Name)
+	if err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("secret %s already exists", request.Name))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	secret, err := store.SecretStore.Create(request)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, secret, "secret", http.StatusCreated)
+}
+
+// Index lists secrets, one page at a time.
+func (sc *SecretsController) Index(c *gin.Context, size, page, offset int) {
+	secrets, count, err := sc.App.GetStore().Secrets(offset, size)
+	paginatedResponse(c, "Secrets", size, page, secrets, count, err)
+}
+
+// Show returns the details of a secret, without its value.
+func (sc *SecretsController) Show(c *gin.Context) {
+	secret, ok := sc.find(c)
+	if !ok {
+		return
+	}
+	jsonAPIResponse(c, secret, "secret")
+}
+
+// Update replaces the value of a secret.
+func (sc *SecretsController) Update(c *gin.Context) {
+	secret, ok := sc.find(c)
+	if !ok {
+		return
+	}
+
+	request := models.SecretRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := sc.App.GetStore().SecretStore.Update(&secret, request.Value); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	jsonAPIResponse(c, secret, "secret")
+}
+
+// Destroy removes a secret.
+func (sc *SecretsController) Destroy(c *gin.Context) {
+	secret, ok := sc.find(c)
+	if !ok {
+		return
+	}
+	if err := sc.App.GetStore().SecretStore.Delete(&secret); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, secret, "secret")
+}
+
+func (sc *SecretsController) find(c *gin.Context) (models.Secret, bool) {
+	secret, err := sc.App.GetStore().FindSecret(c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("secret not found"))
+		return secret, false
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return secret, false
+	}
+	return secret, true
+}
@@ -0,0 +1,98 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body := `{"name":"API_KEY","value":"s3cr3t-value"}`
+	resp, cleanup := client.Post("/v2/secrets", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	require.NoError(t, app.Store.SecretStore.Unlock(cltest.Password))
+
+	resp, cleanup = client.Post("/v2/secrets", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	respJSON := cltest.ParseJSON(t, resp.Body)
+	assert.Equal(t, "API_KEY", respJSON.Get("data.id").String())
+	assert.False(t, respJSON.Get("data.attributes.value").Exists())
+	assert.NotContains(t, respJSON.String(), "s3cr3t-value")
+
+	secret, err := app.Store.FindSecret("API_KEY")
+	require.NoError(t, err)
+	assert.NotContains(t, secret.EncryptedValue, "s3cr3t-value")
+
+	resp, cleanup = client.Post("/v2/secrets", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post("/v2/secrets", bytes.NewBufferString(`{"name":"not valid","value":"x"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Post("/v2/secrets", bytes.NewBufferString(`{"name":"EMPTY"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/secrets", bytes.NewBufferString(`{"name":"SHORT","value":"abc"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
+
+func TestSecretsController_UpdateShowAndDestroy(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	require.NoError(t, app.Store.SecretStore.Unlock(cltest.Password))
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/secrets", bytes.NewBufferString(`{"name":"API_KEY","value":"old-value"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+
+	resp, cleanup = client.Patch("/v2/secrets/API_KEY", bytes.NewBufferString(`{"value":"new-value"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, "{{secret:API_KEY}}", app.Store.SecretStore.Redact("new-value"))
+	assert.Equal(t, "old-value", app.Store.SecretStore.Redact("old-value"))
+
+	resp, cleanup = client.Get("/v2/secrets/API_KEY")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, "API_KEY", cltest.ParseJSON(t, resp.Body).Get("data.attributes.name").String())
+
+	resp, cleanup = client.Get("/v2/secrets")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, int64(1), cltest.ParseJSON(t, resp.Body).Get("meta.count").Int())
+
+	resp, cleanup = client.Delete("/v2/secrets/API_KEY")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	_, err := app.Store.FindSecret("API_KEY")
+	assert.Equal(t, orm.ErrorNotFound, err)
+
+	resp, cleanup = client.Get("/v2/secrets/API_KEY")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SimulationsController performs job specs without saving them.
+type SimulationsController struct {
+	App chainlink.Application
+}
+
+// Create performs the tasks of the job spec in the request body with the
+// given input, and returns the output, error and timing of each task. Neither
+// the job nor its run are saved, and no Ethereum transactions are sent.
+// Tasks which would be retried after a backoff are returned pending_sleep.
+// It is routed as POST /specs/:SpecID, since the router cannot have a fixed
+// path alongside the job spec's, and answers every ID but "simulate" with a
+// 404.
+// Example:
+//  "<application>/specs/simulate"
+func (sc *SimulationsController) Create(c *gin.Context) {
+	if c.Param("SpecID") != "simulate" {
+		jsonAPIError(c, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+
+	var sr models.JobSpecSimulationRequest
+	if err := c.ShouldBindJSON(&sr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if len(sr.Spec.Initiators) == 0 {
+		sr.Spec.Initiators = []models.InitiatorRequest{{Type: models.InitiatorWeb}}
+	}
+	js := models.NewJobFromRequest(sr.Spec)
+	jsc := JobSpecsController{sc.App}
+	if httpStatus, err := jsc.checkJobSpec(js); err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+
+	simulation, err := services.SimulateJobRun(sc.App.GetStore(), js, sr.Input)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewJobSimulation(simulation.Run, simulation.Elapsed), "simulation")
+}
@@ -0,0 +1,111 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationsController_Create(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	body := `{
+		"spec": {"tasks": [
+			{"type": "multiply", "params": {"times": 100}},
+			{"type": "ethuint256"},
+			{"type": "ethtx", "params": {"address": "0x356a04bce728ba4c62a30294a55e6a8600a320b3", "functionSelector": "0x609ff1bd"}}
+		]},
+		"input": {"result": "1.5"}
+	}`
+	resp, cleanup := client.Post("/v2/specs/simulate", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var simulation presenters.JobSimulation
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &simulation))
+	assert.Equal(t, models.RunStatusCompleted, simulation.Status)
+	require.Len(t, simulation.Tasks, 3)
+	assert.Equal(t, "150", simulation.Tasks[0].Result.Get("result").String())
+	assert.Equal(t, models.RunStatusCompleted, simulation.Tasks[2].Status)
+	assert.Equal(t,
+		"0x609ff1bd0000000000000000000000000000000000000000000000000000000000000096",
+		simulation.Tasks[2].Result.Get("transaction.data").String())
+
+	// Neither the job nor its run are saved
+	assert.Len(t, cltest.AllJobs(t, app.Store), 0)
+	count, err := app.Store.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSimulationsController_Create_Retry(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := app.NewHTTPClient()
+	body := fmt.Sprintf(`{"spec": {"tasks": [
+		{"type": "httpget", "params": {"get": "%s"}, "retry": {"maxAttempts": 3, "initialBackoff": "1h"}}
+	]}}`, server.URL)
+
+	// The backoff is not waited for
+	start := time.Now()
+	resp, cleanup := client.Post("/v2/specs/simulate", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.True(t, time.Since(start) < time.Minute)
+
+	var simulation presenters.JobSimulation
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &simulation))
+	assert.Equal(t, models.RunStatusPendingSleep, simulation.Status)
+	require.Len(t, simulation.Tasks, 1)
+	assert.Equal(t, models.RunStatusPendingSleep, simulation.Tasks[0].Status)
+	require.NotNil(t, simulation.Tasks[0].RetryAt)
+	assert.True(t, simulation.Tasks[0].RetryAt.After(start))
+}
+
+func TestSimulationsController_Create_Invalid(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	body := `{"spec": {"tasks": [{"type": "nonexistent"}]}}`
+	resp, cleanup := client.Post("/v2/specs/simulate", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
+
+func TestSimulationsController_Create_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	body := `{"spec": {"tasks": [{"type": "noop"}]}}`
+	resp, cleanup := client.Post("/v2/specs/"+models.NewID().String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -1,9 +1,12 @@
 package web
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
@@ -46,3 +49,48 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewTxFromAttempt(*txAttempt), "transaction")
 }
+
+// Cancel cancels a pending Ethereum transaction, by sending a zero value
+// transfer to its sender at the same nonce with a bumped gas price.
+// Example:
+//  "<application>/transactions/:TxHash/cancel"
+func (tc *TransactionsController) Cancel(c *gin.Context) {
+	var ctr models.CancelTxRequest
+	if err := c.ShouldBindJSON(&ctr); err != nil && err != io.EOF {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	hash := common.HexToHash(c.Param("TxHash"))
+	tx, err := tc.App.GetStore().TxManager.CancelTx(hash, ctr.GasPrice.ToInt())
+	tc.respondWithReplacement(c, tx, err)
+}
+
+// Replace replaces the payload of a pending Ethereum transaction, by sending
+// the new payload at the same nonce with a bumped gas price.
+// Example:
+//  "<application>/transactions/:TxHash/replace"
+func (tc *TransactionsController) Replace(c *gin.Context) {
+	var rtr models.ReplaceTxRequest
+	if err := c.ShouldBindJSON(&rtr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	hash := common.HexToHash(c.Param("TxHash"))
+	tx, err := tc.App.GetStore().TxManager.ReplaceTx(hash, rtr)
+	tc.respondWithReplacement(c, tx, err)
+}
+
+func (tc *TransactionsController) respondWithReplacement(c *gin.Context, tx *models.Tx, err error) {
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Transaction not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, fmt.Errorf("transaction replacement failed: %v", err))
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewTx(tx), "transaction")
+}
@@ -1,6 +1,7 @@
 package web_test
 
 import (
+	"bytes"
 	"net/http"
 	"testing"
 
@@ -153,3 +154,128 @@ func TestTransactionsController_Show_NotFound(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
 }
+
+func TestTransactionsController_Cancel_Success(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+
+	ethMock := app.EthMock
+	ethMock.Context("app.StartAndConnect()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_chainId", app.Store.Config.ChainID())
+		ethMock.Register("eth_getTransactionCount", "0x100")
+	})
+
+	require.NoError(t, app.StartAndConnect())
+	store := app.GetStore()
+	client := app.NewHTTPClient()
+	from := cltest.GetAccountAddress(t, store)
+	tx := cltest.CreateTx(t, store, from, 1)
+
+	ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+	resp, cleanup := client.Post("/v2/transactions/"+tx.Hash.String()+"/cancel", bytes.NewBufferString(`{"gasPrice":"20000000000"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	ptx := presenters.Tx{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &ptx))
+	assert.True(t, ptx.Cancelled)
+	assert.Equal(t, "20000000000", ptx.GasPrice)
+	assert.Equal(t, from, *ptx.To)
+
+	ntx, err := store.FindTx(tx.ID)
+	require.NoError(t, err)
+	assert.True(t, ntx.Cancelled)
+	assert.Equal(t, tx.Nonce, ntx.Nonce)
+	assert.Equal(t, from, ntx.To)
+	assert.Empty(t, ntx.Data)
+	assert.Len(t, ntx.Attempts, 2)
+
+	ethMock.AllCalled()
+}
+
+func TestTransactionsController_Cancel_GasPriceTooLow(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+
+	ethMock := app.EthMock
+	ethMock.Context("app.StartAndConnect()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_chainId", app.Store.Config.ChainID())
+		ethMock.Register("eth_getTransactionCount", "0x100")
+	})
+
+	require.NoError(t, app.StartAndConnect())
+	store := app.GetStore()
+	client := app.NewHTTPClient()
+	from := cltest.GetAccountAddress(t, store)
+	tx := cltest.CreateTx(t, store, from, 1)
+
+	resp, cleanup := client.Post("/v2/transactions/"+tx.Hash.String()+"/cancel", bytes.NewBufferString(`{"gasPrice":"2"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	ntx, err := store.FindTx(tx.ID)
+	require.NoError(t, err)
+	assert.False(t, ntx.Cancelled)
+	assert.Len(t, ntx.Attempts, 1)
+}
+
+func TestTransactionsController_Replace_Success(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+
+	ethMock := app.EthMock
+	ethMock.Context("app.StartAndConnect()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_chainId", app.Store.Config.ChainID())
+		ethMock.Register("eth_getTransactionCount", "0x100")
+	})
+
+	require.NoError(t, app.StartAndConnect())
+	store := app.GetStore()
+	client := app.NewHTTPClient()
+	from := cltest.GetAccountAddress(t, store)
+	tx := cltest.CreateTx(t, store, from, 1)
+
+	ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+	resp, cleanup := client.Post("/v2/transactions/"+tx.Hash.String()+"/replace", bytes.NewBufferString(`{"data":"0xdeadbeef","gasLimit":100000}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	ntx, err := store.FindTx(tx.ID)
+	require.NoError(t, err)
+	assert.True(t, ntx.Replaced)
+	assert.False(t, ntx.Cancelled)
+	assert.Equal(t, tx.Nonce, ntx.Nonce)
+	assert.Equal(t, tx.To, ntx.To)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, ntx.Data)
+	assert.Equal(t, uint64(100000), ntx.GasLimit)
+	require.Len(t, ntx.Attempts, 2)
+	assert.True(t, ntx.Attempts[1].GasPrice.ToInt().Cmp(ntx.Attempts[0].GasPrice.ToInt()) > 0)
+
+	ethMock.AllCalled()
+}
+
+func TestTransactionsController_Replace_NotFound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+
+	ethMock := app.EthMock
+	ethMock.Context("app.StartAndConnect()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_chainId", app.Store.Config.ChainID())
+		ethMock.Register("eth_getTransactionCount", "0x100")
+	})
+
+	require.NoError(t, app.StartAndConnect())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/transactions/"+cltest.NewHash().String()+"/replace", bytes.NewBufferString(`{}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
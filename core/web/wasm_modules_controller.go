@@ -0,0 +1,105 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// WasmModulesController manages the modules run by the wasm adapter.
+type WasmModulesController struct {
+	App chainlink.Application
+}
+
+// Create registers a module, after checking that it compiles and follows
+// the ABI of the wasm adapter.
+func (wmc *WasmModulesController) Create(c *gin.Context) {
+	request := &models.WasmModuleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.Name.String() == "" {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("wasm module name is required"))
+		return
+	}
+
+	store := wmc.App.GetStore()
+	_, err := store.FindWasmModule(request.Name)
+	if err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("wasm module %s already exists", request.Name))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	hash, err := store.WasmRuntime.Compile(request.Wasm)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	module := models.WasmModule{
+		Name: request.Name,
+		Hash: hash,
+		Wasm: request.Wasm,
+	}
+	if err := store.CreateWasmModule(&module); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponseWithStatus(c, module, "wasmModule", http.StatusCreated)
+}
+
+// Index lists wasm modules, one page at a time.
+func (wmc *WasmModulesController) Index(c *gin.Context, size, page, offset int) {
+	modules, count, err := wmc.App.GetStore().WasmModules(offset, size)
+	paginatedResponse(c, "WasmModules", size, page, modules, count, err)
+}
+
+// Show returns the details of a wasm module.
+func (wmc *WasmModulesController) Show(c *gin.Context) {
+	module, ok := wmc.find(c)
+	if !ok {
+		return
+	}
+	jsonAPIResponse(c, module, "wasmModule")
+}
+
+// Destroy removes a wasm module.
+func (wmc *WasmModulesController) Destroy(c *gin.Context) {
+	module, ok := wmc.find(c)
+	if !ok {
+		return
+	}
+	if err := wmc.App.GetStore().DeleteWasmModule(&module); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, module, "wasmModule")
+}
+
+func (wmc *WasmModulesController) find(c *gin.Context) (models.WasmModule, bool) {
+	name, err := models.NewTaskType(c.Param("Name"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return models.WasmModule{}, false
+	}
+
+	module, err := wmc.App.GetStore().FindWasmModule(name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("wasm module not found"))
+		return module, false
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return module, false
+	}
+	return module, true
+}
@@ -0,0 +1,84 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoWasmModule returns its input as its output.
+const echoWasmModule = "AGFzbQEAAAABEQNgAX8Bf2ACf38BfmACf38AAwMCAAEFAwEAAQYHAX8BQYAICwcfAwZtZW1vcnkCAAhhbGxvY2F0ZQAAB3BlcmZvcm0AAQoaAgsAIwAjACAAaiQACwwAIACtQiCGIAGthAs="
+
+func TestWasmModulesController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body := `{"name":"Echo","wasm":"` + echoWasmModule + `"}`
+	resp, cleanup := client.Post("/v2/wasm_modules", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	respJSON := cltest.ParseJSON(t, resp.Body)
+	assert.Equal(t, "echo", respJSON.Get("data.id").String())
+	assert.Len(t, respJSON.Get("data.attributes.hash").String(), 64)
+
+	module, err := app.Store.FindWasmModule(models.MustNewTaskType("echo"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, module.Wasm)
+
+	resp, cleanup = client.Post("/v2/wasm_modules", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post("/v2/wasm_modules", bytes.NewBufferString(`{"name":"invalid","wasm":"AGFzbQEAAAA="}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/wasm_modules", bytes.NewBufferString(`{"wasm":"`+echoWasmModule+`"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestWasmModulesController_ShowAndDestroy(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/wasm_modules", bytes.NewBufferString(`{"name":"echo","wasm":"`+echoWasmModule+`"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+
+	resp, cleanup = client.Get("/v2/wasm_modules/echo")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, "echo", cltest.ParseJSON(t, resp.Body).Get("data.attributes.name").String())
+
+	resp, cleanup = client.Get("/v2/wasm_modules")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Equal(t, int64(1), cltest.ParseJSON(t, resp.Body).Get("meta.count").Int())
+
+	resp, cleanup = client.Delete("/v2/wasm_modules/echo")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	_, err := app.Store.FindWasmModule(models.MustNewTaskType("echo"))
+	assert.Equal(t, orm.ErrorNotFound, err)
+
+	resp, cleanup = client.Get("/v2/wasm_modules/echo")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}